@@ -0,0 +1,89 @@
+package client
+
+import (
+    "context"
+    "errors"
+    "time"
+    
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "rushkv/proto"
+)
+
+// ErrChangesTrimmed 服务器的变更日志已清理了起始序号之后的部分变更，无法从该序号继续读取
+var ErrChangesTrimmed = errors.New("change feed start sequence is older than the changes kept by the server")
+
+// Change 服务器持久变更日志中的一条变更
+type Change struct {
+    // 在产生变更的节点内单调递增，不同节点的序号互不相关
+    Seq    uint64
+    NodeID string
+    Type   proto.ChangeType
+    Key    string
+    Value  []byte
+    // 记录的版本和过期时间，ExpiresAt为零值表示不过期
+    Version   int64
+    ExpiresAt time.Time
+    // 变更写入该节点的时间
+    Time time.Time
+}
+
+// ChangeFeed 从序号fromSeq开始按顺序读取所连接节点的变更日志并对每个变更调用fn，
+// fromSeq为0时从保留的最早变更开始。读到末尾后继续等待新的变更，直到ctx被取消或fn返回错误。
+// 连接断开时自动重连并从下一个序号继续
+func (c *RushKVClient) ChangeFeed(ctx context.Context, fromSeq uint64, fn func(*Change) error) error {
+    req := &proto.ChangeFeedRequest{FromSeq: fromSeq}
+    for {
+        err := c.changeFeedOnce(ctx, req, fn)
+        if ctx.Err() != nil {
+            return ctx.Err()
+        }
+        var fnErr *watchCallbackError
+        if errors.As(err, &fnErr) {
+            return fnErr.err
+        }
+        if status.Code(err) == codes.OutOfRange {
+            return ErrChangesTrimmed
+        }
+        
+        select {
+        case <-time.After(watchRetryInterval):
+        case <-ctx.Done():
+            return ctx.Err()
+        }
+    }
+}
+
+// changeFeedOnce 建立一次变更流，并随收到的变更推进req.FromSeq
+func (c *RushKVClient) changeFeedOnce(ctx context.Context, req *proto.ChangeFeedRequest, fn func(*Change) error) error {
+    ctx, cancel := context.WithCancel(ctx)
+    defer cancel()
+    
+    stream, err := c.client.ChangeFeed(ctx, req)
+    if err != nil {
+        return err
+    }
+    for {
+        event, err := stream.Recv()
+        if err != nil {
+            return err
+        }
+        pair := event.Pair
+        change := &Change{
+            Seq:     event.Seq,
+            NodeID:  event.NodeId,
+            Type:    event.Type,
+            Key:     pair.Key,
+            Value:   pair.Value,
+            Version: pair.Version,
+            Time:    time.Unix(0, event.Timestamp),
+        }
+        if pair.ExpiresAt != 0 {
+            change.ExpiresAt = time.Unix(0, pair.ExpiresAt)
+        }
+        if err := fn(change); err != nil {
+            return &watchCallbackError{err: err}
+        }
+        req.FromSeq = event.Seq + 1
+    }
+}
\ No newline at end of file
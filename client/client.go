@@ -3,6 +3,7 @@ package client
 import (
     "context"
     "fmt"
+    "io"
     "time"
     
     "google.golang.org/grpc"
@@ -28,13 +29,16 @@ func NewRushKVClient(address string) (*RushKVClient, error) {
     }, nil
 }
 
-func (c *RushKVClient) Put(key string, value []byte) error {
+func (c *RushKVClient) Put(key string, value []byte, opts ...Option) error {
     ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
     defer cancel()
     
+    o := newOptions(opts)
     resp, err := c.client.Put(ctx, &proto.PutRequest{
-        Key:   key,
-        Value: value,
+        Key:         key,
+        Value:       value,
+        Consistency: o.consistency,
+        TtlSeconds:  ttlSeconds(o.ttl),
     })
     if err != nil {
         return fmt.Errorf("put failed: %v", err)
@@ -47,12 +51,16 @@ func (c *RushKVClient) Put(key string, value []byte) error {
     return nil
 }
 
-func (c *RushKVClient) Get(key string) ([]byte, error) {
+func (c *RushKVClient) Get(key string, opts ...Option) ([]byte, error) {
     ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
     defer cancel()
     
+    o := newOptions(opts)
     resp, err := c.client.Get(ctx, &proto.GetRequest{
-        Key: key,
+        Key:         key,
+        Consistency: o.consistency,
+        AtVersion:   o.atVersion,
+        AtTime:      o.atTimeNanos(),
     })
     if err != nil {
         return nil, fmt.Errorf("get failed: %v", err)
@@ -65,12 +73,65 @@ func (c *RushKVClient) Get(key string) ([]byte, error) {
     return resp.Value, nil
 }
 
-func (c *RushKVClient) Delete(key string) error {
+// GetResult MultiGet中每个key的读取结果，key不存在时Found为false
+type GetResult = proto.MultiGetResult
+
+// MultiGet 在一次请求中读取多个key，返回的结果与keys一一对应，
+// 单个key读取失败时对应结果的Error不为空
+func (c *RushKVClient) MultiGet(keys []string, opts ...Option) ([]*GetResult, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+    
+    o := newOptions(opts)
+    resp, err := c.client.MultiGet(ctx, &proto.MultiGetRequest{
+        Keys:        keys,
+        Consistency: o.consistency,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("multi get failed: %v", err)
+    }
+    
+    if !resp.Success {
+        return nil, fmt.Errorf("multi get failed: %s", resp.Error)
+    }
+    
+    return resp.Results, nil
+}
+
+// TTL 返回key的剩余存活时间，key未设置过期时间时第二个返回值为false
+func (c *RushKVClient) TTL(key string, opts ...Option) (time.Duration, bool, error) {
     ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
     defer cancel()
     
+    o := newOptions(opts)
+    resp, err := c.client.Get(ctx, &proto.GetRequest{
+        Key:         key,
+        Consistency: o.consistency,
+        AtVersion:   o.atVersion,
+        AtTime:      o.atTimeNanos(),
+    })
+    if err != nil {
+        return 0, false, fmt.Errorf("ttl failed: %v", err)
+    }
+    
+    if !resp.Success {
+        return 0, false, fmt.Errorf("ttl failed: %s", resp.Error)
+    }
+    if resp.ExpiresAt == 0 {
+        return 0, false, nil
+    }
+    
+    return time.Until(time.Unix(0, resp.ExpiresAt)), true, nil
+}
+
+func (c *RushKVClient) Delete(key string, opts ...Option) error {
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    
+    o := newOptions(opts)
     resp, err := c.client.Delete(ctx, &proto.DeleteRequest{
-        Key: key,
+        Key:         key,
+        Consistency: o.consistency,
     })
     if err != nil {
         return fmt.Errorf("delete failed: %v", err)
@@ -83,11 +144,241 @@ func (c *RushKVClient) Delete(key string) error {
     return nil
 }
 
+// BatchResult 批量写入中每个操作的结果
+type BatchResult = proto.BatchOpResult
+
+// Batch 在一次请求中执行多个OpPut和OpDelete操作，同一个key最多出现一次。
+// 返回的结果与ops一一对应，单个操作失败不影响其他操作
+func (c *RushKVClient) Batch(ops []*Op, opts ...Option) ([]*BatchResult, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+    
+    o := newOptions(opts)
+    resp, err := c.client.Batch(ctx, &proto.BatchRequest{
+        Ops:         ops,
+        Consistency: o.consistency,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("batch failed: %v", err)
+    }
+    
+    if !resp.Success {
+        return nil, fmt.Errorf("batch failed: %s", resp.Error)
+    }
+    
+    return resp.Results, nil
+}
+
+// Version key的一个历史版本
+type Version struct {
+    Value     []byte
+    Version   int64
+    Timestamp time.Time
+    Deleted   bool
+}
+
+// History 按版本从新到旧返回key保留的各个版本，包括删除，WithLimit限制返回的数量
+func (c *RushKVClient) History(key string, opts ...Option) ([]Version, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    
+    o := newOptions(opts)
+    resp, err := c.client.History(ctx, &proto.HistoryRequest{
+        Key:         key,
+        Limit:       int32(o.limit),
+        Consistency: o.consistency,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("history failed: %v", err)
+    }
+    
+    if !resp.Success {
+        return nil, fmt.Errorf("history failed: %s", resp.Error)
+    }
+    
+    versions := make([]Version, 0, len(resp.Versions))
+    for _, pair := range resp.Versions {
+        versions = append(versions, Version{
+            Value:     pair.Value,
+            Version:   pair.Version,
+            Timestamp: time.Unix(0, pair.Timestamp),
+            Deleted:   pair.Deleted,
+        })
+    }
+    return versions, nil
+}
+
+// ConflictError 条件写入的条件不满足，Version为key的当前版本，key不存在时为0
+type ConflictError struct {
+    Version int64
+}
+
+func (e *ConflictError) Error() string {
+    return fmt.Sprintf("condition not met (current version %d)", e.Version)
+}
+
+// GetVersion 返回key的值及其版本，版本用于CompareAndSwap
+func (c *RushKVClient) GetVersion(key string, opts ...Option) ([]byte, int64, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    
+    o := newOptions(opts)
+    resp, err := c.client.Get(ctx, &proto.GetRequest{
+        Key:         key,
+        Consistency: o.consistency,
+        AtVersion:   o.atVersion,
+        AtTime:      o.atTimeNanos(),
+    })
+    if err != nil {
+        return nil, 0, fmt.Errorf("get failed: %v", err)
+    }
+    
+    if !resp.Success {
+        return nil, 0, fmt.Errorf("get failed: %s", resp.Error)
+    }
+    
+    return resp.Value, resp.Version, nil
+}
+
+// CompareAndSwap 仅当key的当前版本等于expected时写入value，expected为0表示key不存在，
+// 返回写入的新版本。条件不满足时返回*ConflictError
+func (c *RushKVClient) CompareAndSwap(key string, expected int64, value []byte, opts ...Option) (int64, error) {
+    return c.conditionalWrite(&proto.CompareAndSwapRequest{
+        Key:             key,
+        Value:           value,
+        Condition:       proto.CasCondition_VERSION_EQUALS,
+        ExpectedVersion: expected,
+    }, opts)
+}
+
+// CompareValueAndSwap 仅当key存在且当前值等于expected时写入value
+func (c *RushKVClient) CompareValueAndSwap(key string, expected, value []byte, opts ...Option) (int64, error) {
+    return c.conditionalWrite(&proto.CompareAndSwapRequest{
+        Key:           key,
+        Value:         value,
+        Condition:     proto.CasCondition_VALUE_EQUALS,
+        ExpectedValue: expected,
+    }, opts)
+}
+
+// PutIfAbsent 仅当key不存在时写入value
+func (c *RushKVClient) PutIfAbsent(key string, value []byte, opts ...Option) (int64, error) {
+    return c.conditionalWrite(&proto.CompareAndSwapRequest{
+        Key:       key,
+        Value:     value,
+        Condition: proto.CasCondition_ABSENT,
+    }, opts)
+}
+
+// DeleteIfVersion 仅当key的当前版本等于expected时删除key
+func (c *RushKVClient) DeleteIfVersion(key string, expected int64, opts ...Option) error {
+    _, err := c.conditionalWrite(&proto.CompareAndSwapRequest{
+        Key:             key,
+        Condition:       proto.CasCondition_VERSION_EQUALS,
+        ExpectedVersion: expected,
+        Delete:          true,
+    }, opts)
+    return err
+}
+
+func (c *RushKVClient) conditionalWrite(req *proto.CompareAndSwapRequest, opts []Option) (int64, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    
+    o := newOptions(opts)
+    req.Consistency = o.consistency
+    req.TtlSeconds = ttlSeconds(o.ttl)
+    resp, err := c.client.CompareAndSwap(ctx, req)
+    if err != nil {
+        return 0, fmt.Errorf("compare and swap failed: %v", err)
+    }
+    
+    if resp.Conflict {
+        return 0, &ConflictError{Version: resp.Version}
+    }
+    if !resp.Success {
+        return 0, fmt.Errorf("compare and swap failed: %s", resp.Error)
+    }
+    
+    return resp.Version, nil
+}
+
+// KeyValue Scan返回的一条记录
+type KeyValue struct {
+    Key   string
+    Value []byte
+}
+
+// Scan 按key顺序返回[start, end)内的记录，end为空表示不设上界
+func (c *RushKVClient) Scan(start, end string, opts ...Option) ([]KeyValue, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+    
+    o := newOptions(opts)
+    stream, err := c.client.Scan(ctx, &proto.ScanRequest{
+        Start:   start,
+        End:     end,
+        Prefix:  o.prefix,
+        Limit:   int32(o.limit),
+        Reverse: o.reverse,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("scan failed: %v", err)
+    }
+    
+    var result []KeyValue
+    for {
+        pair, err := stream.Recv()
+        if err == io.EOF {
+            return result, nil
+        }
+        if err != nil {
+            return nil, fmt.Errorf("scan failed: %v", err)
+        }
+        result = append(result, KeyValue{Key: pair.Key, Value: pair.Value})
+    }
+}
+
 func (c *RushKVClient) GetClusterInfo() (*proto.ClusterInfoResponse, error) {
     ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
     defer cancel()
     
-    return c.client.GetClusterInfo(ctx, &proto.ClusterInfoRequest{})
+    return c.client.GetClusterInfo(ctx, &proto.ClusterInfoRequest{WithStatus: true})
+}
+
+func (c *RushKVClient) GetHints() ([]*proto.HintBacklog, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    
+    resp, err := c.client.GetHints(ctx, &proto.HintsRequest{Cluster: true})
+    if err != nil {
+        return nil, fmt.Errorf("get hints failed: %v", err)
+    }
+    if !resp.Success {
+        return nil, fmt.Errorf("get hints failed: %s", resp.Error)
+    }
+    
+    return resp.Backlogs, nil
+}
+
+// Compact 在集群所有节点上清理墓碑和过期记录，compactFiles为true时同时压缩存储文件
+func (c *RushKVClient) Compact(compactFiles bool) ([]*proto.CompactResult, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+    defer cancel()
+    
+    resp, err := c.client.Compact(ctx, &proto.CompactRequest{
+        Gc:      true,
+        Compact: compactFiles,
+        Cluster: true,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("compact failed: %v", err)
+    }
+    if !resp.Success {
+        return nil, fmt.Errorf("compact failed: %s", resp.Error)
+    }
+    
+    return resp.Results, nil
 }
 
 func (c *RushKVClient) Close() error {
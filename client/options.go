@@ -0,0 +1,122 @@
+package client
+
+import (
+    "fmt"
+    "strings"
+    "time"
+    
+    "rushkv/proto"
+)
+
+// ConsistencyLevel 一致性级别，决定协调节点需要等待多少个副本响应
+type ConsistencyLevel = proto.ConsistencyLevel
+
+const (
+    ConsistencyDefault = proto.ConsistencyLevel_DEFAULT
+    ConsistencyOne     = proto.ConsistencyLevel_ONE
+    ConsistencyQuorum  = proto.ConsistencyLevel_QUORUM
+    ConsistencyAll     = proto.ConsistencyLevel_ALL
+)
+
+// ParseConsistency 解析one/quorum/all形式的一致性级别
+func ParseConsistency(s string) (ConsistencyLevel, error) {
+    level, ok := proto.ConsistencyLevel_value[strings.ToUpper(s)]
+    if !ok {
+        return ConsistencyDefault, fmt.Errorf("unknown consistency level: %s", s)
+    }
+    return ConsistencyLevel(level), nil
+}
+
+// Option 单次请求的可选参数
+type Option func(*options)
+
+type options struct {
+    consistency  ConsistencyLevel
+    prefix       string
+    limit        int
+    reverse      bool
+    ttl          time.Duration
+    atVersion    int64
+    atTime       time.Time
+    startVersion int64
+}
+
+func newOptions(opts []Option) *options {
+    o := &options{}
+    for _, opt := range opts {
+        opt(o)
+    }
+    return o
+}
+
+// WithConsistency 指定请求的一致性级别
+func WithConsistency(level ConsistencyLevel) Option {
+    return func(o *options) {
+        o.consistency = level
+    }
+}
+
+// WithPrefix Scan只返回以prefix开头的key
+func WithPrefix(prefix string) Option {
+    return func(o *options) {
+        o.prefix = prefix
+    }
+}
+
+// WithLimit Scan最多返回n条记录
+func WithLimit(n int) Option {
+    return func(o *options) {
+        o.limit = n
+    }
+}
+
+// WithReverse Scan按key逆序返回
+func WithReverse() Option {
+    return func(o *options) {
+        o.reverse = true
+    }
+}
+
+// WithTTL Put写入的key在ttl后过期，不足1秒按1秒计算
+func WithTTL(ttl time.Duration) Option {
+    return func(o *options) {
+        o.ttl = ttl
+    }
+}
+
+// WithAtVersion Get读取版本不超过version的最新版本
+func WithAtVersion(version int64) Option {
+    return func(o *options) {
+        o.atVersion = version
+    }
+}
+
+// WithAtTime Get读取t时刻的版本
+func WithAtTime(t time.Time) Option {
+    return func(o *options) {
+        o.atTime = t
+    }
+}
+
+// WithStartVersion Watch先补发版本大于version的变更，用于断开后继续监听
+func WithStartVersion(version int64) Option {
+    return func(o *options) {
+        o.startVersion = version
+    }
+}
+
+// atTimeNanos 未设置时为0
+func (o *options) atTimeNanos() int64 {
+    if o.atTime.IsZero() {
+        return 0
+    }
+    return o.atTime.UnixNano()
+}
+
+// ttlSeconds 把ttl向上取整为秒，未设置时为0
+func ttlSeconds(ttl time.Duration) int64 {
+    if ttl <= 0 {
+        return 0
+    }
+    return int64((ttl + time.Second - 1) / time.Second)
+}
\ No newline at end of file
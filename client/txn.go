@@ -0,0 +1,105 @@
+package client
+
+import (
+    "context"
+    "fmt"
+    "time"
+    
+    "rushkv/proto"
+)
+
+// Compare 事务的比较条件
+type Compare = proto.TxnCompare
+
+// Op 事务中的操作
+type Op = proto.TxnOp
+
+// OpResult 事务中每个操作的结果
+type OpResult = proto.TxnOpResult
+
+// VersionEquals 条件：key的当前版本等于version，0表示key不存在
+func VersionEquals(key string, version int64) *Compare {
+    return &Compare{Key: key, Condition: proto.CasCondition_VERSION_EQUALS, ExpectedVersion: version}
+}
+
+// ValueEquals 条件：key存在且当前值等于value
+func ValueEquals(key string, value []byte) *Compare {
+    return &Compare{Key: key, Condition: proto.CasCondition_VALUE_EQUALS, ExpectedValue: value}
+}
+
+// Absent 条件：key不存在
+func Absent(key string) *Compare {
+    return &Compare{Key: key, Condition: proto.CasCondition_ABSENT}
+}
+
+// OpPut 写入key，ttl为0表示不过期
+func OpPut(key string, value []byte, ttl time.Duration) *Op {
+    return &Op{Type: proto.TxnOpType_PUT, Key: key, Value: value, TtlSeconds: ttlSeconds(ttl)}
+}
+
+// OpDelete 删除key
+func OpDelete(key string) *Op {
+    return &Op{Type: proto.TxnOpType_DELETE, Key: key}
+}
+
+// OpGet 读取key，可以看到同一事务中之前的写入
+func OpGet(key string) *Op {
+    return &Op{Type: proto.TxnOpType_GET, Key: key}
+}
+
+// Txn 多key原子事务：If中的条件全部满足时执行Then中的操作，否则执行Else中的操作
+type Txn struct {
+    client *RushKVClient
+    req    *proto.TxnRequest
+    opts   []Option
+}
+
+// TxnResult 事务的执行结果，Succeeded表示执行的是否为Then分支
+type TxnResult struct {
+    Succeeded bool
+    Results   []*OpResult
+}
+
+func (c *RushKVClient) Txn(opts ...Option) *Txn {
+    return &Txn{
+        client: c,
+        req:    &proto.TxnRequest{},
+        opts:   opts,
+    }
+}
+
+func (t *Txn) If(compares ...*Compare) *Txn {
+    t.req.Compare = append(t.req.Compare, compares...)
+    return t
+}
+
+func (t *Txn) Then(ops ...*Op) *Txn {
+    t.req.Success = append(t.req.Success, ops...)
+    return t
+}
+
+func (t *Txn) Else(ops ...*Op) *Txn {
+    t.req.Failure = append(t.req.Failure, ops...)
+    return t
+}
+
+func (t *Txn) Commit() (*TxnResult, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+    
+    o := newOptions(t.opts)
+    t.req.Consistency = o.consistency
+    resp, err := t.client.client.Txn(ctx, t.req)
+    if err != nil {
+        return nil, fmt.Errorf("txn failed: %v", err)
+    }
+    
+    if !resp.Success {
+        return nil, fmt.Errorf("txn failed: %s", resp.Error)
+    }
+    
+    return &TxnResult{
+        Succeeded: resp.Succeeded,
+        Results:   resp.Results,
+    }, nil
+}
\ No newline at end of file
@@ -0,0 +1,174 @@
+package client
+
+import (
+    "context"
+    "errors"
+    "strconv"
+    "time"
+    
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "rushkv/proto"
+)
+
+// 与服务器的连接断开后重新监听前的等待时间
+const watchRetryInterval = time.Second
+
+// 同一次写入在各节点上的变更应在该时间内到达协调节点，与服务器的去重窗口相同。
+// 服务器没有返回续传位置时，从已收到的最大版本减去该窗口继续
+const watchResumeWindow = 10 * time.Second
+
+// ErrWatchCompacted 服务器已不再保留起始版本之后的全部变更，无法从该版本继续监听
+var ErrWatchCompacted = errors.New("watch start version is older than the changes kept by the server")
+
+// WatchEvent 一次变更，Deleted为true表示key被删除
+type WatchEvent struct {
+    Key     string
+    Value   []byte
+    Version int64
+    Deleted bool
+}
+
+// Watch 监听key的变更并对每个变更调用fn，直到ctx被取消或fn返回错误。
+// 连接断开时自动重连并从服务器返回的低水位继续，不会漏掉期间和乱序到达的变更，重放的变更不会重复交给fn
+func (c *RushKVClient) Watch(ctx context.Context, key string, fn func(*WatchEvent) error, opts ...Option) error {
+    return c.watch(ctx, &proto.WatchRequest{Key: key}, fn, opts)
+}
+
+// WatchPrefix 监听以prefix开头的所有key的变更，prefix为空时监听所有key
+func (c *RushKVClient) WatchPrefix(ctx context.Context, prefix string, fn func(*WatchEvent) error, opts ...Option) error {
+    return c.watch(ctx, &proto.WatchRequest{Prefix: prefix}, fn, opts)
+}
+
+func (c *RushKVClient) watch(ctx context.Context, req *proto.WatchRequest, fn func(*WatchEvent) error, opts []Option) error {
+    o := newOptions(opts)
+    cursor := &watchCursor{
+        start:     o.startVersion,
+        delivered: make(map[string]int64),
+    }
+    
+    for {
+        req.StartVersion = cursor.start
+        err := c.watchOnce(ctx, req, cursor, fn)
+        if ctx.Err() != nil {
+            return ctx.Err()
+        }
+        var fnErr *watchCallbackError
+        if errors.As(err, &fnErr) {
+            return fnErr.err
+        }
+        if status.Code(err) == codes.OutOfRange {
+            return ErrWatchCompacted
+        }
+        
+        select {
+        case <-time.After(watchRetryInterval):
+        case <-ctx.Done():
+            return ctx.Err()
+        }
+    }
+}
+
+// watchCallbackError 区分回调返回的错误和连接错误，前者结束监听
+type watchCallbackError struct {
+    err error
+}
+
+func (e *watchCallbackError) Error() string {
+    return e.err.Error()
+}
+
+// watchCursor 重新监听的位置。各节点的变更可能乱序到达，从已收到的最大版本继续会漏掉较慢节点上版本更低的变更，
+// 因此从低水位继续，并记录每个key已交给回调的版本，丢弃重放的变更
+type watchCursor struct {
+    // 下一次监听的起始版本
+    start int64
+    // 已收到的最大版本
+    latest int64
+    // 版本不高于pruned的记录已从delivered中删除，之后重放的这些变更可能再次交给回调
+    pruned    int64
+    delivered map[string]int64
+}
+
+// deliver 记录收到的变更，已交给过回调的变更返回false
+func (c *watchCursor) deliver(pair *proto.KVPair) bool {
+    c.latest = max(c.latest, pair.Version)
+    if pair.Version <= c.delivered[pair.Key] {
+        return false
+    }
+    c.delivered[pair.Key] = pair.Version
+    
+    // 保留一个窗口内的记录，足以丢弃下一次监听重放的变更
+    if low := c.latest - 2*int64(watchResumeWindow); low > c.pruned {
+        c.prune(low)
+    }
+    return true
+}
+
+// resume 根据服务器在trailer中返回的低水位确定下一次监听的起始版本，没有返回时从已收到的最大版本减去窗口继续
+func (c *watchCursor) resume(trailer metadata.MD) {
+    low := c.latest - int64(watchResumeWindow)
+    if values := trailer.Get("rushkv-watch-resume"); len(values) > 0 {
+        if resume, err := strconv.ParseInt(values[0], 10, 64); err == nil {
+            low = resume
+        }
+    }
+    if low > c.start {
+        c.start = low
+    }
+    if c.start > c.pruned {
+        c.prune(c.start)
+    }
+}
+
+// prune 删除版本不高于low的记录，服务器不会重放这些变更
+func (c *watchCursor) prune(low int64) {
+    for key, version := range c.delivered {
+        if version <= low {
+            delete(c.delivered, key)
+        }
+    }
+    c.pruned = low
+}
+
+// watchOnce 建立一次监听流，把未交给过回调的变更交给fn，结束时更新cursor的起始版本
+func (c *RushKVClient) watchOnce(ctx context.Context, req *proto.WatchRequest, cursor *watchCursor, fn func(*WatchEvent) error) error {
+    ctx, cancel := context.WithCancel(ctx)
+    defer cancel()
+    
+    stream, err := c.client.Watch(ctx, req)
+    if err != nil {
+        return err
+    }
+    // 从当前时刻开始的监听在重连时从服务器确定的起始版本继续
+    header, err := stream.Header()
+    if err != nil {
+        return err
+    }
+    if values := header.Get("rushkv-watch-start"); len(values) > 0 && cursor.start == 0 {
+        if start, err := strconv.ParseInt(values[0], 10, 64); err == nil {
+            cursor.start = start
+        }
+    }
+    
+    for {
+        event, err := stream.Recv()
+        if err != nil {
+            cursor.resume(stream.Trailer())
+            return err
+        }
+        pair := event.Pair
+        if !cursor.deliver(pair) {
+            continue
+        }
+        if err := fn(&WatchEvent{
+            Key:     pair.Key,
+            Value:   pair.Value,
+            Version: pair.Version,
+            Deleted: event.Type == proto.TxnOpType_DELETE,
+        }); err != nil {
+            return &watchCallbackError{err: err}
+        }
+    }
+}
@@ -2,15 +2,20 @@ package main
 
 import (
     "bufio"
+    "context"
+    "errors"
     "flag"
     "fmt"
     "log"
     "os"
+    "os/signal"
+    "sort"
     "strconv"
     "strings"
     "time"
     
     "rushkv/client"
+    "rushkv/proto"
 )
 
 // CLI represents the command line interface client
@@ -39,20 +44,163 @@ func (cli *CLI) printHelp() {
     fmt.Println("  put <key> <value>     - Store a key-value pair")
     fmt.Println("  get <key>             - Retrieve value for a key")
     fmt.Println("  delete <key>          - Delete a key-value pair")
+    fmt.Println("  mget <key> [<key> ...] - Retrieve values for several keys in one request")
+    fmt.Println("  mset <k> <v> [<k> <v> ...]")
+    fmt.Println("                        - Store several key-value pairs in one request")
+    fmt.Println("  mdel <key> [<key> ...]")
+    fmt.Println("                        - Delete several keys in one request")
     fmt.Println("  exists <key>          - Check if a key exists")
+    fmt.Println("  ttl <key>             - Show remaining time before a key expires")
+    fmt.Println("  history <key>         - Show the versions kept for a key, newest first")
+    fmt.Println("  cas <key> <ver> <val> - Store only if the key is at version <ver> (0 = absent)")
+    fmt.Println("  txn [if <cmp>, ...] then <op>, ... [else <op>, ...]")
+    fmt.Println("                        - Run operations atomically across keys")
+    fmt.Println("                          <cmp>: version <key> <ver> | value <key> <val> | absent <key>")
+    fmt.Println("                          <op>:  put <key> <val> | delete <key> | get <key>")
+    fmt.Println("  scan [start] [end]    - List key-value pairs in [start, end)")
+    fmt.Println("  watch <prefix>        - Print changes to keys with the prefix until Ctrl+C")
+    fmt.Println("  changes [--from <seq>] - Print the change log of the connected node until Ctrl+C")
     fmt.Println("  cluster               - Show cluster information")
+    fmt.Println("  hints                 - Show pending hints for unavailable replicas")
+    fmt.Println("  gc                    - Remove expired keys and old tombstones on every node")
+    fmt.Println("  compact               - Run gc, then compact the storage file on every node")
     fmt.Println("  stats                 - Show client statistics")
     fmt.Println("  benchmark <n>         - Run performance test (n operations)")
     fmt.Println("  help                  - Show this help message")
     fmt.Println("  exit                  - Exit the client")
+    fmt.Println("Options:")
+    fmt.Println("  --consistency <level> - Consistency level for put/get/delete/mget/mset/mdel (one, quorum, all)")
+    fmt.Println("  --prefix <prefix>     - Only scan keys with the given prefix")
+    fmt.Println("  --limit <n>           - Maximum number of pairs returned by scan, or changes printed by watch/changes")
+    fmt.Println("  --reverse             - Scan in descending key order")
+    fmt.Println("  --ttl <duration>      - Expire the key after the given time (e.g. 30s, 5m)")
+    fmt.Println("  --at-version <ver>    - get: read the newest version not above <ver>")
+    fmt.Println("  --at-time <time>      - get: read the key as of an RFC 3339 time or a duration ago (e.g. 10m)")
+    fmt.Println("  --if-absent           - cas <key> <value>: store only if the key does not exist")
+    fmt.Println("  --if-value <old>      - cas <key> <value>: store only if the current value is <old>")
+    fmt.Println("  --delete              - cas <key> <ver>: delete only if the key is at version <ver>")
+    fmt.Println("  --from <ver>          - watch: first print the changes after version <ver>")
+    fmt.Println("                          changes: start at sequence <seq> (default: oldest retained)")
     fmt.Println()
 }
 
+// boolFlags lists options that take no value
+var boolFlags = map[string]bool{
+    "reverse":   true,
+    "if-absent": true,
+    "delete":    true,
+}
+
+// parseFlags separates --name value options from positional arguments
+func parseFlags(args []string) ([]string, map[string]string) {
+    positional := make([]string, 0, len(args))
+    flags := make(map[string]string)
+    
+    for i := 0; i < len(args); i++ {
+        if name := strings.TrimPrefix(args[i], "--"); name != args[i] && boolFlags[name] {
+            flags[name] = "true"
+            continue
+        }
+        if strings.HasPrefix(args[i], "--") && i+1 < len(args) {
+            flags[strings.TrimPrefix(args[i], "--")] = args[i+1]
+            i++
+            continue
+        }
+        positional = append(positional, args[i])
+    }
+    
+    return positional, flags
+}
+
+// requestOptions builds client request options from command flags
+func requestOptions(flags map[string]string) ([]client.Option, error) {
+    var opts []client.Option
+    
+    if level, ok := flags["consistency"]; ok {
+        consistency, err := client.ParseConsistency(level)
+        if err != nil {
+            return nil, err
+        }
+        opts = append(opts, client.WithConsistency(consistency))
+    }
+    if prefix, ok := flags["prefix"]; ok {
+        opts = append(opts, client.WithPrefix(prefix))
+    }
+    if limit, ok := flags["limit"]; ok {
+        n, err := strconv.Atoi(limit)
+        if err != nil || n < 0 {
+            return nil, fmt.Errorf("invalid limit: %s", limit)
+        }
+        opts = append(opts, client.WithLimit(n))
+    }
+    if flags["reverse"] == "true" {
+        opts = append(opts, client.WithReverse())
+    }
+    if value, ok := flags["at-version"]; ok {
+        version, err := strconv.ParseInt(value, 10, 64)
+        if err != nil || version <= 0 {
+            return nil, fmt.Errorf("invalid version: %s", value)
+        }
+        opts = append(opts, client.WithAtVersion(version))
+    }
+    if value, ok := flags["at-time"]; ok {
+        t, err := parseTime(value)
+        if err != nil {
+            return nil, err
+        }
+        opts = append(opts, client.WithAtTime(t))
+    }
+    if value, ok := flags["ttl"]; ok {
+        ttl, err := parseTTL(value)
+        if err != nil {
+            return nil, err
+        }
+        opts = append(opts, client.WithTTL(ttl))
+    }
+    
+    return opts, nil
+}
+
+// parseTime accepts an RFC 3339 time or a duration before now
+func parseTime(value string) (time.Time, error) {
+    if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+        return t, nil
+    }
+    ago, err := time.ParseDuration(value)
+    if err != nil || ago < 0 {
+        return time.Time{}, fmt.Errorf("invalid time: %s", value)
+    }
+    return time.Now().Add(-ago), nil
+}
+
+// parseTTL accepts a Go duration or a plain number of seconds
+func parseTTL(value string) (time.Duration, error) {
+    ttl, err := time.ParseDuration(value)
+    if err != nil {
+        seconds, convErr := strconv.Atoi(value)
+        if convErr != nil {
+            return 0, fmt.Errorf("invalid ttl: %s", value)
+        }
+        ttl = time.Duration(seconds) * time.Second
+    }
+    if ttl <= 0 {
+        return 0, fmt.Errorf("invalid ttl: %s", value)
+    }
+    return ttl, nil
+}
+
 // handlePut processes the put command
 func (cli *CLI) handlePut(args []string) {
+    args, flags := parseFlags(args)
     if len(args) < 2 {
         fmt.Println("Error: put command requires key and value arguments")
-        fmt.Println("Usage: put <key> <value>")
+        fmt.Println("Usage: put <key> <value> [--ttl <duration>] [--consistency <level>]")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
         return
     }
     
@@ -60,7 +208,7 @@ func (cli *CLI) handlePut(args []string) {
     value := strings.Join(args[1:], " ")
     
     start := time.Now()
-    err := cli.client.Put(key, []byte(value))
+    err = cli.client.Put(key, []byte(value), opts...)
     duration := time.Since(start)
     
     if err != nil {
@@ -72,37 +220,51 @@ func (cli *CLI) handlePut(args []string) {
 
 // handleGet processes the get command
 func (cli *CLI) handleGet(args []string) {
+    args, flags := parseFlags(args)
     if len(args) < 1 {
         fmt.Println("Error: get command requires key argument")
-        fmt.Println("Usage: get <key>")
+        fmt.Println("Usage: get <key> [--at-version <ver>] [--at-time <time>] [--consistency <level>]")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
         return
     }
     
     key := args[0]
     
     start := time.Now()
-    value, err := cli.client.Get(key)
+    value, version, err := cli.client.GetVersion(key, opts...)
     duration := time.Since(start)
     
     if err != nil {
         fmt.Printf("Error: %v\n", err)
     } else {
-        fmt.Printf("Value for key '%s': %s (took: %v)\n", key, string(value), duration)
+        fmt.Printf("Value for key '%s': %s (version: %d, took: %v)\n", key, string(value), version, duration)
     }
 }
 
 // handleDelete processes the delete command
 func (cli *CLI) handleDelete(args []string) {
+    args, flags := parseFlags(args)
     if len(args) < 1 {
         fmt.Println("Error: delete command requires key argument")
-        fmt.Println("Usage: delete <key>")
+        fmt.Println("Usage: delete <key> [--consistency <level>]")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
         return
     }
     
     key := args[0]
     
     start := time.Now()
-    err := cli.client.Delete(key)
+    err = cli.client.Delete(key, opts...)
     duration := time.Since(start)
     
     if err != nil {
@@ -112,6 +274,119 @@ func (cli *CLI) handleDelete(args []string) {
     }
 }
 
+// handleMGet retrieves several keys with a single request
+func (cli *CLI) handleMGet(args []string) {
+    args, flags := parseFlags(args)
+    if len(args) < 1 {
+        fmt.Println("Error: mget command requires at least one key")
+        fmt.Println("Usage: mget <key> [<key> ...] [--consistency <level>]")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    start := time.Now()
+    results, err := cli.client.MultiGet(args, opts...)
+    duration := time.Since(start)
+    
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    found := 0
+    for _, r := range results {
+        switch {
+        case r.Error != "":
+            fmt.Printf("  %s: failed: %s\n", r.Key, r.Error)
+        case !r.Found:
+            fmt.Printf("  %s: not found\n", r.Key)
+        default:
+            found++
+            fmt.Printf("  %s = %s (version: %d)\n", r.Key, string(r.Value), r.Version)
+        }
+    }
+    fmt.Printf("(%d of %d keys found, took: %v)\n", found, len(results), duration)
+}
+
+// handleMSet stores several key-value pairs with a single batch request
+func (cli *CLI) handleMSet(args []string) {
+    args, flags := parseFlags(args)
+    if len(args) < 2 || len(args)%2 != 0 {
+        fmt.Println("Error: mset command requires key and value pairs")
+        fmt.Println("Usage: mset <key> <value> [<key> <value> ...] [--ttl <duration>] [--consistency <level>]")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    var ttl time.Duration
+    if value, ok := flags["ttl"]; ok {
+        if ttl, err = parseTTL(value); err != nil {
+            fmt.Printf("Error: %v\n", err)
+            return
+        }
+    }
+    
+    ops := make([]*client.Op, 0, len(args)/2)
+    for i := 0; i < len(args); i += 2 {
+        ops = append(ops, client.OpPut(args[i], []byte(args[i+1]), ttl))
+    }
+    cli.runBatchOps("stored", ops, opts)
+}
+
+// handleMDel deletes several keys with a single batch request
+func (cli *CLI) handleMDel(args []string) {
+    args, flags := parseFlags(args)
+    if len(args) < 1 {
+        fmt.Println("Error: mdel command requires at least one key")
+        fmt.Println("Usage: mdel <key> [<key> ...] [--consistency <level>]")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    ops := make([]*client.Op, 0, len(args))
+    for _, key := range args {
+        ops = append(ops, client.OpDelete(key))
+    }
+    cli.runBatchOps("deleted", ops, opts)
+}
+
+// runBatchOps sends ops as one batch and prints the result of each operation
+func (cli *CLI) runBatchOps(verb string, ops []*client.Op, opts []client.Option) {
+    start := time.Now()
+    results, err := cli.client.Batch(ops, opts...)
+    duration := time.Since(start)
+    
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    succeeded := 0
+    for _, r := range results {
+        if r.Success {
+            succeeded++
+            fmt.Printf("  %s: %s at version %d\n", r.Key, verb, r.Version)
+        } else {
+            fmt.Printf("  %s: failed: %s\n", r.Key, r.Error)
+        }
+    }
+    fmt.Printf("(%d of %d keys %s, took: %v)\n", succeeded, len(results), verb, duration)
+}
+
 // handleExists checks if a key exists
 func (cli *CLI) handleExists(args []string) {
     if len(args) < 1 {
@@ -133,6 +408,405 @@ func (cli *CLI) handleExists(args []string) {
     }
 }
 
+// handleCAS processes the cas command, writing only when its condition holds
+func (cli *CLI) handleCAS(args []string) {
+    args, flags := parseFlags(args)
+    _, ifValue := flags["if-value"]
+    if len(args) < 2 || (len(args) < 3 && flags["delete"] != "true" && flags["if-absent"] != "true" && !ifValue) {
+        fmt.Println("Error: cas command requires key, condition and value arguments")
+        fmt.Println("Usage: cas <key> <version> <value> | cas <key> <value> --if-absent | cas <key> <value> --if-value <old> | cas <key> <version> --delete")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    key := args[0]
+    
+    start := time.Now()
+    var version int64
+    switch {
+    case flags["if-absent"] == "true":
+        version, err = cli.client.PutIfAbsent(key, []byte(strings.Join(args[1:], " ")), opts...)
+    case ifValue:
+        version, err = cli.client.CompareValueAndSwap(key, []byte(flags["if-value"]), []byte(strings.Join(args[1:], " ")), opts...)
+    default:
+        expected, parseErr := strconv.ParseInt(args[1], 10, 64)
+        if parseErr != nil {
+            fmt.Printf("Error: invalid version: %s\n", args[1])
+            return
+        }
+        if flags["delete"] == "true" {
+            err = cli.client.DeleteIfVersion(key, expected, opts...)
+        } else {
+            version, err = cli.client.CompareAndSwap(key, expected, []byte(strings.Join(args[2:], " ")), opts...)
+        }
+    }
+    duration := time.Since(start)
+    
+    var conflict *client.ConflictError
+    switch {
+    case errors.As(err, &conflict):
+        fmt.Printf("Condition not met for '%s', current version: %d (took: %v)\n", key, conflict.Version, duration)
+    case err != nil:
+        fmt.Printf("Error: %v\n", err)
+    case flags["delete"] == "true":
+        fmt.Printf("Successfully deleted '%s' (took: %v)\n", key, duration)
+    default:
+        fmt.Printf("Successfully stored '%s' at version %d (took: %v)\n", key, version, duration)
+    }
+}
+
+// handleTxn processes the txn command
+func (cli *CLI) handleTxn(args []string) {
+    args, flags := parseFlags(args)
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    txn := cli.client.Txn(opts...)
+    thenOps, elseOps, err := parseTxn(txn, args)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        fmt.Println("Usage: txn [if <cmp>, ...] then <op>, ... [else <op>, ...]")
+        return
+    }
+    
+    start := time.Now()
+    result, err := txn.Commit()
+    duration := time.Since(start)
+    
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    ops := thenOps
+    if result.Succeeded {
+        fmt.Printf("Conditions met, ran the then branch (took: %v)\n", duration)
+    } else {
+        ops = elseOps
+        fmt.Printf("Conditions not met, ran the else branch (took: %v)\n", duration)
+    }
+    for i, r := range result.Results {
+        op := ops[i]
+        switch {
+        case !r.Found:
+            fmt.Printf("  %s %s: not found\n", op, r.Key)
+        case op == "get":
+            fmt.Printf("  %s %s: %s (version: %d)\n", op, r.Key, string(r.Value), r.Version)
+        default:
+            fmt.Printf("  %s %s: version %d\n", op, r.Key, r.Version)
+        }
+    }
+}
+
+// parseTxn adds the compares and operations in args to txn and returns the
+// operation names of each branch. Items within a clause are separated by commas.
+func parseTxn(txn *client.Txn, args []string) ([]string, []string, error) {
+    clauses := make(map[string][][]string)
+    clause := ""
+    var item []string
+    flush := func() {
+        if len(item) > 0 {
+            clauses[clause] = append(clauses[clause], item)
+            item = nil
+        }
+    }
+    for _, arg := range args {
+        switch strings.ToLower(arg) {
+        case "if", "then", "else":
+            flush()
+            clause = strings.ToLower(arg)
+            continue
+        }
+        if clause == "" {
+            return nil, nil, fmt.Errorf("expected if or then, got %s", arg)
+        }
+        
+        ends := strings.HasSuffix(arg, ",")
+        if arg = strings.TrimSuffix(arg, ","); arg != "" {
+            item = append(item, arg)
+        }
+        if ends {
+            flush()
+        }
+    }
+    flush()
+    if len(clauses["then"]) == 0 && len(clauses["else"]) == 0 {
+        return nil, nil, fmt.Errorf("txn requires a then or else clause")
+    }
+    
+    for _, cmp := range clauses["if"] {
+        switch {
+        case cmp[0] == "version" && len(cmp) == 3:
+            version, err := strconv.ParseInt(cmp[2], 10, 64)
+            if err != nil {
+                return nil, nil, fmt.Errorf("invalid version: %s", cmp[2])
+            }
+            txn.If(client.VersionEquals(cmp[1], version))
+        case cmp[0] == "value" && len(cmp) >= 3:
+            txn.If(client.ValueEquals(cmp[1], []byte(strings.Join(cmp[2:], " "))))
+        case cmp[0] == "absent" && len(cmp) == 2:
+            txn.If(client.Absent(cmp[1]))
+        default:
+            return nil, nil, fmt.Errorf("invalid compare: %s", strings.Join(cmp, " "))
+        }
+    }
+    
+    parseOps := func(items [][]string) ([]*client.Op, []string, error) {
+        var ops []*client.Op
+        var names []string
+        for _, op := range items {
+            switch {
+            case op[0] == "put" && len(op) >= 3:
+                ops = append(ops, client.OpPut(op[1], []byte(strings.Join(op[2:], " ")), 0))
+            case (op[0] == "delete" || op[0] == "del") && len(op) == 2:
+                ops = append(ops, client.OpDelete(op[1]))
+                op[0] = "delete"
+            case op[0] == "get" && len(op) == 2:
+                ops = append(ops, client.OpGet(op[1]))
+            default:
+                return nil, nil, fmt.Errorf("invalid operation: %s", strings.Join(op, " "))
+            }
+            names = append(names, op[0])
+        }
+        return ops, names, nil
+    }
+    thenOps, thenNames, err := parseOps(clauses["then"])
+    if err != nil {
+        return nil, nil, err
+    }
+    elseOps, elseNames, err := parseOps(clauses["else"])
+    if err != nil {
+        return nil, nil, err
+    }
+    txn.Then(thenOps...).Else(elseOps...)
+    
+    return thenNames, elseNames, nil
+}
+
+// handleHistory lists the versions kept for a key
+func (cli *CLI) handleHistory(args []string) {
+    args, flags := parseFlags(args)
+    if len(args) < 1 {
+        fmt.Println("Error: history command requires key argument")
+        fmt.Println("Usage: history <key> [--limit <n>] [--consistency <level>]")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    key := args[0]
+    
+    start := time.Now()
+    versions, err := cli.client.History(key, opts...)
+    duration := time.Since(start)
+    
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    for _, v := range versions {
+        if v.Deleted {
+            fmt.Printf("  %d  %s  (deleted)\n", v.Version, v.Timestamp.Format(time.RFC3339Nano))
+        } else {
+            fmt.Printf("  %d  %s  %s\n", v.Version, v.Timestamp.Format(time.RFC3339Nano), string(v.Value))
+        }
+    }
+    fmt.Printf("(%d versions, took: %v)\n", len(versions), duration)
+}
+
+// handleTTL shows how long a key has left before it expires
+func (cli *CLI) handleTTL(args []string) {
+    args, flags := parseFlags(args)
+    if len(args) < 1 {
+        fmt.Println("Error: ttl command requires key argument")
+        fmt.Println("Usage: ttl <key> [--consistency <level>]")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    key := args[0]
+    
+    remaining, expires, err := cli.client.TTL(key, opts...)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+    } else if !expires {
+        fmt.Printf("Key '%s' does not expire\n", key)
+    } else {
+        fmt.Printf("Key '%s' expires in %v\n", key, remaining.Round(time.Second))
+    }
+}
+
+// handleScan processes the scan command
+func (cli *CLI) handleScan(args []string) {
+    args, flags := parseFlags(args)
+    if len(args) > 2 {
+        fmt.Println("Usage: scan [start] [end] [--prefix <prefix>] [--limit <n>] [--reverse]")
+        return
+    }
+    
+    opts, err := requestOptions(flags)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    var start, end string
+    if len(args) > 0 {
+        start = args[0]
+    }
+    if len(args) > 1 {
+        end = args[1]
+    }
+    
+    begin := time.Now()
+    pairs, err := cli.client.Scan(start, end, opts...)
+    duration := time.Since(begin)
+    
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    for _, pair := range pairs {
+        fmt.Printf("%s = %s\n", pair.Key, string(pair.Value))
+    }
+    fmt.Printf("(%d pairs, took: %v)\n", len(pairs), duration)
+}
+
+// errLimitReached is returned by streaming callbacks to stop once --limit events were printed
+var errLimitReached = errors.New("limit reached")
+
+// handleWatch prints changes to keys with a prefix until interrupted or the limit is reached
+func (cli *CLI) handleWatch(args []string) {
+    args, flags := parseFlags(args)
+    if len(args) > 1 {
+        fmt.Println("Usage: watch [prefix] [--from <version>] [--limit <n>]")
+        return
+    }
+    
+    var prefix string
+    if len(args) > 0 {
+        prefix = args[0]
+    }
+    var opts []client.Option
+    if value, ok := flags["from"]; ok {
+        version, err := strconv.ParseInt(value, 10, 64)
+        if err != nil || version <= 0 {
+            fmt.Printf("Error: invalid version: %s\n", value)
+            return
+        }
+        opts = append(opts, client.WithStartVersion(version))
+    }
+    limit := 0
+    if value, ok := flags["limit"]; ok {
+        n, err := strconv.Atoi(value)
+        if err != nil || n < 0 {
+            fmt.Printf("Error: invalid limit: %s\n", value)
+            return
+        }
+        limit = n
+    }
+    
+    // Ctrl+C stops the watch and returns to the prompt
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+    defer stop()
+    
+    fmt.Printf("Watching keys with prefix '%s' (Ctrl+C to stop)\n", prefix)
+    received := 0
+    err := cli.client.WatchPrefix(ctx, prefix, func(event *client.WatchEvent) error {
+        if event.Deleted {
+            fmt.Printf("  DELETE %s (version: %d)\n", event.Key, event.Version)
+        } else {
+            fmt.Printf("  PUT %s = %s (version: %d)\n", event.Key, string(event.Value), event.Version)
+        }
+        received++
+        if limit > 0 && received >= limit {
+            return errLimitReached
+        }
+        return nil
+    }, opts...)
+    
+    if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, errLimitReached) {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    fmt.Printf("(%d changes)\n", received)
+}
+
+// handleChanges prints the persistent change log of the connected node until interrupted or the limit is reached
+func (cli *CLI) handleChanges(args []string) {
+    args, flags := parseFlags(args)
+    if len(args) > 0 {
+        fmt.Println("Usage: changes [--from <seq>] [--limit <n>]")
+        return
+    }
+    
+    var from uint64
+    if value, ok := flags["from"]; ok {
+        seq, err := strconv.ParseUint(value, 10, 64)
+        if err != nil {
+            fmt.Printf("Error: invalid sequence: %s\n", value)
+            return
+        }
+        from = seq
+    }
+    limit := 0
+    if value, ok := flags["limit"]; ok {
+        n, err := strconv.Atoi(value)
+        if err != nil || n < 0 {
+            fmt.Printf("Error: invalid limit: %s\n", value)
+            return
+        }
+        limit = n
+    }
+    
+    // Ctrl+C stops the feed and returns to the prompt
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+    defer stop()
+    
+    fmt.Println("Reading change log (Ctrl+C to stop)")
+    received := 0
+    err := cli.client.ChangeFeed(ctx, from, func(change *client.Change) error {
+        switch change.Type {
+        case proto.ChangeType_CHANGE_PUT:
+            fmt.Printf("  #%d PUT %s = %s (version: %d)\n", change.Seq, change.Key, string(change.Value), change.Version)
+        case proto.ChangeType_CHANGE_DELETE:
+            fmt.Printf("  #%d DELETE %s (version: %d)\n", change.Seq, change.Key, change.Version)
+        default:
+            fmt.Printf("  #%d EXPIRE %s (version: %d)\n", change.Seq, change.Key, change.Version)
+        }
+        received++
+        if limit > 0 && received >= limit {
+            return errLimitReached
+        }
+        return nil
+    })
+    
+    if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, errLimitReached) {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    fmt.Printf("(%d changes)\n", received)
+}
+
 // handleCluster displays cluster information
 func (cli *CLI) handleCluster() {
     start := time.Now()
@@ -146,6 +820,7 @@ func (cli *CLI) handleCluster() {
     
     fmt.Printf("\nCluster Information (took: %v):\n", duration)
     fmt.Printf("Leader: %s\n", clusterInfo.Leader)
+    fmt.Printf("Cluster Version: %d\n", clusterInfo.Version)
     fmt.Printf("Node Count: %d\n", len(clusterInfo.Nodes))
     fmt.Println("Node List:")
     
@@ -154,12 +829,100 @@ func (cli *CLI) handleCluster() {
         if node.IsLeader {
             status = "Leader"
         }
-        fmt.Printf("  - ID: %s, Address: %s:%d, Status: %s\n", 
-            node.Id, node.Address, node.Port, status)
+        fmt.Printf("  - ID: %s, Address: %s:%d, Status: %s, State: %s\n",
+            node.Id, node.Address, node.Port, status, strings.ToLower(node.State.String()))
+        printNodeStatus(node.Status)
     }
     fmt.Println()
 }
 
+// printNodeStatus displays a node's read repair, anti-entropy and rebalancing progress
+func printNodeStatus(status *proto.NodeStatus) {
+    if status == nil {
+        fmt.Println("    Status: unknown (node unreachable)")
+        return
+    }
+    
+    if repair := status.ReadRepair; repair != nil {
+        fmt.Printf("    Read Repair: %d inconsistent reads, %d replicas repaired, %d failed\n",
+            repair.Reads, repair.Replicas, repair.Failures)
+    }
+    
+    if ae := status.AntiEntropy; ae != nil {
+        lastSync := "never"
+        if ae.LastFullSync > 0 {
+            lastSync = time.Unix(ae.LastFullSync, 0).Format("2006-01-02 15:04:05")
+        }
+        fmt.Printf("    Anti-Entropy: last full sync %s, %d/%d ranges differed in last round, %d keys repaired\n",
+            lastSync, ae.RangesDiffering, ae.RangesCompared, ae.KeysRepaired)
+        if ae.LastError != "" {
+            fmt.Printf("    Anti-Entropy Error: %s\n", ae.LastError)
+        }
+    }
+    
+    rebalance := status.Rebalance
+    switch {
+    case rebalance == nil || rebalance.StartedAt == 0:
+        fmt.Println("    Rebalance: idle")
+    case rebalance.Active:
+        fmt.Printf("    Rebalance: in progress, %d moved ranges, %d/%d keys scanned, %d transferred\n",
+            rebalance.MovedRanges, rebalance.KeysScanned, rebalance.KeysTotal, rebalance.KeysTransferred)
+    default:
+        fmt.Printf("    Rebalance: finished at %s, %d keys transferred\n",
+            time.Unix(rebalance.FinishedAt, 0).Format("2006-01-02 15:04:05"), rebalance.KeysTransferred)
+    }
+}
+
+// handleHints displays the hint backlog across the cluster
+func (cli *CLI) handleHints() {
+    backlogs, err := cli.client.GetHints()
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    if len(backlogs) == 0 {
+        fmt.Println("No pending hints")
+        return
+    }
+    
+    fmt.Println("\nPending Hints:")
+    for _, backlog := range backlogs {
+        fmt.Printf("  - %s -> %s: %d hints, oldest %s\n",
+            backlog.Holder, backlog.Target, backlog.Count,
+            time.Unix(backlog.Oldest, 0).Format("2006-01-02 15:04:05"))
+    }
+    fmt.Println()
+}
+
+// handleCompact removes garbage on every node and optionally compacts the storage files
+func (cli *CLI) handleCompact(compactFiles bool) {
+    start := time.Now()
+    results, err := cli.client.Compact(compactFiles)
+    duration := time.Since(start)
+    
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        return
+    }
+    
+    sort.Slice(results, func(i, j int) bool {
+        return results[i].NodeId < results[j].NodeId
+    })
+    for _, r := range results {
+        if r.Error != "" {
+            fmt.Printf("  - %s: failed: %s\n", r.NodeId, r.Error)
+            continue
+        }
+        fmt.Printf("  - %s: removed %d tombstones and %d expired keys", r.NodeId, r.TombstonesRemoved, r.ExpiredRemoved)
+        if compactFiles {
+            fmt.Printf(", storage %d -> %d bytes", r.SizeBefore, r.SizeAfter)
+        }
+        fmt.Println()
+    }
+    fmt.Printf("(took: %v)\n", duration)
+}
+
 // handleStats displays client statistics
 func (cli *CLI) handleStats() {
     fmt.Println("\nClient Statistics:")
@@ -261,10 +1024,36 @@ func (cli *CLI) processCommand(input string) bool {
         cli.handleGet(args)
     case "delete", "del":
         cli.handleDelete(args)
+    case "mget":
+        cli.handleMGet(args)
+    case "mset":
+        cli.handleMSet(args)
+    case "mdel":
+        cli.handleMDel(args)
     case "exists":
         cli.handleExists(args)
+    case "txn":
+        cli.handleTxn(args)
+    case "cas":
+        cli.handleCAS(args)
+    case "history":
+        cli.handleHistory(args)
+    case "ttl":
+        cli.handleTTL(args)
+    case "scan":
+        cli.handleScan(args)
+    case "watch":
+        cli.handleWatch(args)
+    case "changes":
+        cli.handleChanges(args)
     case "cluster":
         cli.handleCluster()
+    case "hints":
+        cli.handleHints()
+    case "gc":
+        cli.handleCompact(false)
+    case "compact":
+        cli.handleCompact(true)
     case "stats":
         cli.handleStats()
     case "benchmark", "bench":
@@ -0,0 +1,143 @@
+package main
+
+import (
+    "flag"
+    "fmt"
+    "log"
+    "os"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "text/tabwriter"
+    "time"
+    
+    "rushkv/hlc"
+    "rushkv/storage"
+)
+
+// phase is one step of the benchmark workload
+type phase struct {
+    name string
+    op   func(e storage.Engine, i int) error
+}
+
+// result holds the measurements of one phase against one engine
+type result struct {
+    engine   string
+    phase    string
+    duration time.Duration
+    errors   int64
+}
+
+func main() {
+    var (
+        engines  = flag.String("engines", "bolt,lsm,memory", "Comma-separated storage engines to compare")
+        n        = flag.Int("n", 10000, "Number of keys")
+        clients  = flag.Int("clients", 1, "Number of concurrent clients")
+        history  = flag.Int("history-versions", storage.DefaultHistoryVersions, "Number of versions kept per key")
+        valueLen = flag.Int("value-size", 0, "Value size in bytes (0 uses the CLI benchmark values)")
+        dir      = flag.String("dir", "", "Directory for engine data (default: a temporary directory)")
+    )
+    flag.Parse()
+    
+    // Same keys, values and order of operations as the CLI benchmark command
+    value := func(i int) []byte {
+        if *valueLen > 0 {
+            return []byte(strings.Repeat("v", *valueLen))
+        }
+        return []byte(fmt.Sprintf("bench_value_%d_%d", i, time.Now().UnixNano()))
+    }
+    // Versions come from a hybrid logical clock, as on a server
+    clock := hlc.New(0)
+    phases := []phase{
+        {"PUT", func(e storage.Engine, i int) error {
+            _, err := e.Apply(storage.NewKVPair(benchKey(i), value(i), clock.Now()))
+            return err
+        }},
+        {"GET", func(e storage.Engine, i int) error {
+            pair, err := e.GetPair(benchKey(i))
+            if err == nil && pair == nil {
+                err = fmt.Errorf("key not found")
+            }
+            return err
+        }},
+        {"DELETE", func(e storage.Engine, i int) error {
+            _, err := e.Apply(storage.NewTombstone(benchKey(i), clock.Now()))
+            return err
+        }},
+    }
+    
+    fmt.Printf("Running the benchmark workload (%d keys, %d clients)...\n", *n, *clients)
+    var results []result
+    for _, name := range strings.Split(*engines, ",") {
+        engineResults, err := runEngine(strings.TrimSpace(name), *dir, *history, *n, *clients, phases)
+        if err != nil {
+            log.Fatalf("Benchmark of %s engine failed: %v", name, err)
+        }
+        results = append(results, engineResults...)
+    }
+    
+    fmt.Println()
+    w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(w, "ENGINE\tOPERATION\tTOTAL TIME\tTHROUGHPUT (ops/sec)\tAVG LATENCY (ms)\tERRORS")
+    for _, r := range results {
+        fmt.Fprintf(w, "%s\t%s\t%v\t%.2f\t%.3f\t%d\n",
+            r.engine, r.phase, r.duration.Round(time.Millisecond),
+            float64(int64(*n)-r.errors)/r.duration.Seconds(),
+            r.duration.Seconds()*1000*float64(*clients)/float64(*n),
+            r.errors)
+    }
+    w.Flush()
+}
+
+// runEngine opens a fresh engine and runs every phase against it
+func runEngine(name, dir string, history, n, clients int, phases []phase) ([]result, error) {
+    dataPath, err := os.MkdirTemp(dir, "enginebench-"+name+"-")
+    if err != nil {
+        return nil, err
+    }
+    defer os.RemoveAll(dataPath)
+    
+    e, err := storage.Open(name, dataPath)
+    if err != nil {
+        return nil, err
+    }
+    defer e.Close()
+    e.SetHistoryVersions(history)
+    
+    var results []result
+    for _, p := range phases {
+        fmt.Printf("  %s %s\n", name, p.name)
+        results = append(results, runPhase(e, name, p, n, clients))
+    }
+    return results, nil
+}
+
+// runPhase runs one operation for every key, spread over the clients
+func runPhase(e storage.Engine, name string, p phase, n, clients int) result {
+    var next, errors int64
+    var wg sync.WaitGroup
+    start := time.Now()
+    for c := 0; c < clients; c++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for {
+                i := int(atomic.AddInt64(&next, 1)) - 1
+                if i >= n {
+                    return
+                }
+                if err := p.op(e, i); err != nil {
+                    atomic.AddInt64(&errors, 1)
+                }
+            }
+        }()
+    }
+    wg.Wait()
+    
+    return result{engine: name, phase: p.name, duration: time.Since(start), errors: errors}
+}
+
+func benchKey(i int) string {
+    return fmt.Sprintf("bench_key_%d", i)
+}
\ No newline at end of file
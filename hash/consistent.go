@@ -103,3 +103,183 @@ func (ch *ConsistentHash) GetNodes() []string {
 
 	return nodes
 }
+
+// GetReplicas 返回负责key的至多n个不同节点，第一个为主节点，其余沿环顺时针依次选取
+func (ch *ConsistentHash) GetReplicas(key string, n int) []string {
+	return ch.ReplicasForHash(ch.hash(key), n)
+}
+
+// ReplicasForHash 返回负责哈希值hash的至多n个不同节点
+func (ch *ConsistentHash) ReplicasForHash(hash int, n int) []string {
+	ch.mutex.RLock()
+	defer ch.mutex.RUnlock()
+
+	if len(ch.keys) == 0 || n <= 0 {
+		return nil
+	}
+
+	idx := sort.Search(len(ch.keys), func(i int) bool {
+		return ch.keys[i] >= hash
+	})
+
+	nodes := make([]string, 0, n)
+	seen := make(map[string]bool)
+	for i := 0; i < len(ch.keys) && len(nodes) < n; i++ {
+		node := ch.hashMap[ch.keys[(idx+i)%len(ch.keys)]]
+		if !seen[node] {
+			seen[node] = true
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// Hash 返回key在环上的位置
+func (ch *ConsistentHash) Hash(key string) int {
+	return ch.hash(key)
+}
+
+// Clone 返回当前哈希环的副本
+func (ch *ConsistentHash) Clone() *ConsistentHash {
+	ch.mutex.RLock()
+	defer ch.mutex.RUnlock()
+
+	clone := NewConsistentHash(ch.replicas)
+	clone.keys = append([]int(nil), ch.keys...)
+	for k, v := range ch.hashMap {
+		clone.hashMap[k] = v
+	}
+	return clone
+}
+
+// points 返回环上所有虚拟节点的位置
+func (ch *ConsistentHash) points() []int {
+	ch.mutex.RLock()
+	defer ch.mutex.RUnlock()
+	return append([]int(nil), ch.keys...)
+}
+
+// 哈希值取SHA1的前4个字节，环的大小为2^32
+const ringSize = 1 << 32
+
+// Range 环上的一段区间(Start, End]，Start >= End时跨越环的起点
+type Range struct {
+	Start int
+	End   int
+}
+
+func (r Range) Contains(hash int) bool {
+	if r.Start < r.End {
+		return hash > r.Start && hash <= r.End
+	}
+	return hash > r.Start || hash <= r.End
+}
+
+// Size 返回区间包含的哈希值个数，Start == End时为整个环
+func (r Range) Size() int {
+	if r.Start < r.End {
+		return r.End - r.Start
+	}
+	return r.End - r.Start + ringSize
+}
+
+// Offset 返回hash相对区间起点的偏移，取值范围[0, Size())
+func (r Range) Offset(hash int) int {
+	return ((hash-r.Start-1)%ringSize + ringSize) % ringSize
+}
+
+// ReplicaRange 环上一段区间及负责它的副本
+type ReplicaRange struct {
+	Range    Range
+	Replicas []string
+}
+
+// Ranges 按虚拟节点把环切分为区间，返回每个区间在n副本下的负责节点，按End排序
+func (ch *ConsistentHash) Ranges(n int) []ReplicaRange {
+	points := ch.points()
+	ranges := make([]ReplicaRange, 0, len(points))
+	for i, end := range points {
+		ranges = append(ranges, ReplicaRange{
+			Range:    Range{Start: points[(i+len(points)-1)%len(points)], End: end},
+			Replicas: ch.ReplicasForHash(end, n),
+		})
+	}
+	return ranges
+}
+
+// RangeIndex 返回ranges中包含hash的区间下标，ranges需由Ranges返回
+func RangeIndex(ranges []ReplicaRange, hash int) int {
+	if len(ranges) == 0 {
+		return -1
+	}
+	idx := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].Range.End >= hash
+	})
+	if idx == len(ranges) {
+		return 0
+	}
+	return idx
+}
+
+// RangeMove 在两个哈希环之间副本集合发生变化的区间
+type RangeMove struct {
+	Range       Range
+	OldReplicas []string
+	NewReplicas []string
+}
+
+// MovedRanges 比较两个哈希环，返回n副本下负责节点发生变化的区间
+func MovedRanges(oldRing, newRing *ConsistentHash, n int) []RangeMove {
+	// 两个环的虚拟节点把整个环切分成若干区间，每个区间内的副本集合不变
+	pointSet := make(map[int]bool)
+	for _, p := range oldRing.points() {
+		pointSet[p] = true
+	}
+	for _, p := range newRing.points() {
+		pointSet[p] = true
+	}
+	if len(pointSet) == 0 {
+		return nil
+	}
+
+	points := make([]int, 0, len(pointSet))
+	for p := range pointSet {
+		points = append(points, p)
+	}
+	sort.Ints(points)
+
+	var moves []RangeMove
+	for i, end := range points {
+		start := points[(i+len(points)-1)%len(points)]
+		oldReplicas := oldRing.ReplicasForHash(end, n)
+		newReplicas := newRing.ReplicasForHash(end, n)
+		if sameNodes(oldReplicas, newReplicas) {
+			continue
+		}
+		moves = append(moves, RangeMove{
+			Range:       Range{Start: start, End: end},
+			OldReplicas: oldReplicas,
+			NewReplicas: newReplicas,
+		})
+	}
+
+	return moves
+}
+
+// sameNodes 判断两组副本是否包含相同的节点，顺序不同不需要迁移数据
+func sameNodes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(a))
+	for _, node := range a {
+		set[node] = true
+	}
+	for _, node := range b {
+		if !set[node] {
+			return false
+		}
+	}
+	return true
+}
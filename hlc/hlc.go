@@ -0,0 +1,83 @@
+// Package hlc 混合逻辑时钟。时间戳是Unix纳秒，逻辑计数合并在最低位：
+// 本地事件取物理时间和上一个时间戳加1中较大的值，收到其他节点的时间戳后不再产生比它小的值。
+// 因此时间戳接近物理时间，同时保持跨节点的因果顺序，可以直接与以前的纳秒版本号比较
+package hlc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OffsetError 其他节点的时间戳超出本地物理时间太多，通常是该节点的时钟有误
+type OffsetError struct {
+	Remote    int64
+	Physical  int64
+	MaxOffset time.Duration
+}
+
+func (e *OffsetError) Error() string {
+	return fmt.Sprintf("remote clock %d is %v ahead of local clock, more than the maximum offset %v",
+		e.Remote, time.Duration(e.Remote-e.Physical), e.MaxOffset)
+}
+
+// Clock 混合逻辑时钟，可以并发使用
+type Clock struct {
+	mutex sync.Mutex
+	// 物理时钟，返回Unix纳秒
+	physical func() int64
+	// 接受的其他节点时间戳最多领先本地物理时间的量，为0时不限制
+	maxOffset time.Duration
+	// 已产生或收到的最大时间戳
+	last int64
+}
+
+// New 创建使用系统时钟的时钟
+func New(maxOffset time.Duration) *Clock {
+	return NewWithPhysical(func() int64 { return time.Now().UnixNano() }, maxOffset)
+}
+
+// NewWithPhysical 创建使用指定物理时钟的时钟，可用于模拟时钟偏差
+func NewWithPhysical(physical func() int64, maxOffset time.Duration) *Clock {
+	return &Clock{
+		physical:  physical,
+		maxOffset: maxOffset,
+	}
+}
+
+// Now 返回一个大于之前所有已产生和已收到时间戳的新时间戳
+func (c *Clock) Now() int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if now := c.physical(); now > c.last {
+		c.last = now
+	} else {
+		c.last++
+	}
+	return c.last
+}
+
+// Last 返回已产生或收到的最大时间戳，不推进时钟
+func (c *Clock) Last() int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return max(c.last, c.physical())
+}
+
+// Update 收到其他节点的时间戳，之后产生的时间戳都大于它。
+// 时间戳领先本地物理时间超过maxOffset时不接受，返回*OffsetError
+func (c *Clock) Update(remote int64) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.maxOffset > 0 {
+		if physical := c.physical(); remote-physical > int64(c.maxOffset) {
+			return &OffsetError{Remote: remote, Physical: physical, MaxOffset: c.maxOffset}
+		}
+	}
+	if remote > c.last {
+		c.last = remote
+	}
+	return nil
+}
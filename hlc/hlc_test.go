@@ -0,0 +1,91 @@
+package hlc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// manualClock 测试中手动调整的物理时钟
+type manualClock struct {
+	now int64
+}
+
+func (m *manualClock) read() int64 {
+	return m.now
+}
+
+func TestNowMonotonicWhenPhysicalGoesBackwards(t *testing.T) {
+	physical := &manualClock{now: int64(time.Hour)}
+	c := NewWithPhysical(physical.read, 0)
+
+	first := c.Now()
+	if first != physical.now {
+		t.Fatalf("Now = %d, want the physical time %d", first, physical.now)
+	}
+
+	physical.now -= int64(time.Second)
+	second := c.Now()
+	third := c.Now()
+	if second <= first || third <= second {
+		t.Fatalf("Now after the clock went backwards = %d, %d, want increasing after %d", second, third, first)
+	}
+	if second-first > 1 {
+		t.Errorf("Now jumped by %d, want the logical counter to advance by 1", second-first)
+	}
+
+	// 物理时钟追上后重新使用物理时间
+	physical.now = first + int64(time.Second)
+	if now := c.Now(); now != physical.now {
+		t.Errorf("Now after the clock caught up = %d, want %d", now, physical.now)
+	}
+}
+
+func TestUpdateWithinMaxOffset(t *testing.T) {
+	physical := &manualClock{now: int64(time.Hour)}
+	c := NewWithPhysical(physical.read, 500*time.Millisecond)
+
+	remote := physical.now + int64(300*time.Millisecond)
+	if err := c.Update(remote); err != nil {
+		t.Fatalf("Update with a remote clock 300ms ahead: %v", err)
+	}
+	if last := c.Last(); last != remote {
+		t.Errorf("Last = %d, want the remote time %d", last, remote)
+	}
+	if now := c.Now(); now <= remote {
+		t.Errorf("Now = %d, want greater than the remote time %d", now, remote)
+	}
+
+	// 较旧的远端时间不会让时钟后退
+	before := c.Last()
+	if err := c.Update(physical.now - int64(time.Second)); err != nil {
+		t.Fatalf("Update with an older remote clock: %v", err)
+	}
+	if c.Last() != before {
+		t.Errorf("Last = %d after an older update, want %d", c.Last(), before)
+	}
+}
+
+func TestUpdateBeyondMaxOffset(t *testing.T) {
+	physical := &manualClock{now: int64(time.Hour)}
+	c := NewWithPhysical(physical.read, 500*time.Millisecond)
+
+	remote := physical.now + int64(time.Second)
+	err := c.Update(remote)
+	var offsetErr *OffsetError
+	if !errors.As(err, &offsetErr) {
+		t.Fatalf("Update with a remote clock 1s ahead = %v, want *OffsetError", err)
+	}
+	if offsetErr.Remote != remote || offsetErr.Physical != physical.now || offsetErr.MaxOffset != 500*time.Millisecond {
+		t.Errorf("OffsetError = %+v", offsetErr)
+	}
+	if now := c.Now(); now >= remote {
+		t.Errorf("Now = %d, want the rejected remote time %d not to be adopted", now, remote)
+	}
+
+	// maxOffset为0时不检查
+	unchecked := NewWithPhysical(physical.read, 0)
+	if err := unchecked.Update(remote); err != nil {
+		t.Errorf("Update without a maximum offset: %v", err)
+	}
+}
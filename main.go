@@ -5,8 +5,11 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
+	"rushkv/proto"
 	"rushkv/server"
 )
 
@@ -16,11 +19,71 @@ func main() {
 		address  = flag.String("addr", "localhost", "Server address")
 		port     = flag.Int("port", 8080, "Server port")
 		dataPath = flag.String("data", "./data", "Data directory")
+		engine   = flag.String("engine", "bolt", "Storage engine (bolt, lsm, memory); memory keeps data only until the process exits")
+		replicas = flag.Int("replicas", 1, "Number of replicas per key (must be the same on every node)")
+		level    = flag.String("consistency", "quorum", "Default consistency level (one, quorum, all)")
+		repair   = flag.String("read-repair", "async", "Read repair mode (async, sync, off)")
+		boot     = flag.Bool("bootstrap", true, "Start a new cluster when no saved cluster state exists")
+		join     = flag.String("join", "", "Comma-separated seed nodes (host:port) of an existing cluster to join")
+		dead     = flag.Duration("dead-timeout", 30*time.Second, "How long a node must stay dead before it is removed from the cluster")
+		hintTTL  = flag.Duration("hint-ttl", 3*time.Hour, "How long hints for unavailable replicas are kept")
+		maxHints = flag.Int("max-hints", 10000, "Maximum number of hints kept per unavailable node")
+		aeEvery  = flag.Duration("anti-entropy-interval", 10*time.Minute, "Interval between anti-entropy rounds with other replicas (0 disables)")
+		aeRate   = flag.Int("anti-entropy-rate", 1000, "Maximum keys per second scanned or pulled by anti-entropy (0 means unlimited)")
+		grace    = flag.Duration("tombstone-grace", 24*time.Hour, "How long deleted and expired keys are remembered before they are removed (0 keeps them)")
+		history  = flag.Int("history-versions", 10, "Number of versions kept per key, including the current one (1 disables history)")
+		watchLog = flag.Int("watch-log-size", 10000, "Number of recent changes each node keeps so reconnecting watchers can resume")
+		clRetain = flag.Duration("changelog-retention", 24*time.Hour, "How long entries of the persistent change log are kept (0 keeps them regardless of age)")
+		clMax    = flag.Int("changelog-max-entries", 1000000, "Maximum number of entries kept in the persistent change log (0 means unlimited)")
+		sinkDir  = flag.String("changelog-sink", "", "Directory to export the local change log to as rotating newline-delimited JSON files (empty disables)")
+		sinkSize = flag.Int64("changelog-sink-max-bytes", 64<<20, "Size at which the change log export file is rotated")
+		sinkKeep = flag.Int("changelog-sink-max-files", 10, "Number of rotated change log export files to keep")
+		offset   = flag.Duration("max-clock-offset", 500*time.Millisecond, "Maximum amount another node's clock may be ahead of this node's before its requests are rejected (0 disables the check)")
 	)
 	flag.Parse()
 
+	config := server.DefaultConfig()
+	config.NodeID = *nodeID
+	config.Address = *address
+	config.Port = *port
+	config.DataPath = *dataPath
+	config.Engine = *engine
+	config.ReplicationFactor = *replicas
+	config.Bootstrap = *boot
+	config.DeadNodeTimeout = *dead
+	config.HintTTL = *hintTTL
+	config.MaxHintsPerNode = *maxHints
+	config.AntiEntropyInterval = *aeEvery
+	config.AntiEntropyRate = *aeRate
+	config.HistoryVersions = *history
+	config.TombstoneGrace = *grace
+	config.WatchLogSize = *watchLog
+	config.ChangeLogRetention = *clRetain
+	config.ChangeLogMaxEntries = *clMax
+	config.ChangeSinkDir = *sinkDir
+	config.ChangeSinkMaxBytes = *sinkSize
+	config.ChangeSinkMaxFiles = *sinkKeep
+	config.MaxClockOffset = *offset
+	if *join != "" {
+		// 加入已有集群的节点不能自行初始化集群
+		config.Seeds = strings.Split(*join, ",")
+		config.Bootstrap = false
+	}
+
+	consistency, ok := proto.ConsistencyLevel_value[strings.ToUpper(*level)]
+	if !ok {
+		log.Fatalf("Unknown consistency level: %s", *level)
+	}
+	config.DefaultConsistency = proto.ConsistencyLevel(consistency)
+
+	readRepair, err := server.ParseReadRepairMode(*repair)
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.ReadRepair = readRepair
+
 	// 创建服务器
-	srv, err := server.NewRushKVServer(*nodeID, *address, *port, *dataPath)
+	srv, err := server.NewRushKVServer(config)
 	if err != nil {
 		log.Fatalf("Failed to create server: %v", err)
 	}
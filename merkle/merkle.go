@@ -0,0 +1,122 @@
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"rushkv/hash"
+)
+
+// Tree 覆盖哈希环上一段区间的Merkle树。叶子把区间等分为2^depth段，
+// 叶子哈希为其中所有记录哈希的异或，与记录加入的顺序无关
+type Tree struct {
+	rng   hash.Range
+	depth int
+	// 按层存储的完全二叉树，nodes[0]为根，nodes[i]的子节点为nodes[2i+1]和nodes[2i+2]
+	nodes [][]byte
+	built bool
+}
+
+// New 创建覆盖区间r、深度为depth的空树
+func New(r hash.Range, depth int) *Tree {
+	nodes := make([][]byte, 1<<(depth+1)-1)
+	for i := range nodes {
+		nodes[i] = make([]byte, sha256.Size)
+	}
+	return &Tree{
+		rng:   r,
+		depth: depth,
+		nodes: nodes,
+	}
+}
+
+// FromNodes 由对端发来的节点哈希还原一棵树
+func FromNodes(r hash.Range, depth int, nodes [][]byte) (*Tree, error) {
+	if len(nodes) != 1<<(depth+1)-1 {
+		return nil, fmt.Errorf("expected %d nodes for depth %d, got %d", 1<<(depth+1)-1, depth, len(nodes))
+	}
+	return &Tree{
+		rng:   r,
+		depth: depth,
+		nodes: nodes,
+		built: true,
+	}, nil
+}
+
+// Leaf 返回环上位置h所在的叶子下标
+func (t *Tree) Leaf(h int) int {
+	return int(int64(t.rng.Offset(h)) * int64(t.leaves()) / int64(t.rng.Size()))
+}
+
+// Add 加入一条记录，h为key在环上的位置
+func (t *Tree) Add(h int, key string, version int64, deleted bool) {
+	entry := sha256.New()
+	entry.Write([]byte(key))
+	binary.Write(entry, binary.BigEndian, version)
+	if deleted {
+		entry.Write([]byte{1})
+	} else {
+		entry.Write([]byte{0})
+	}
+
+	leaf := t.nodes[t.leaves()-1+t.Leaf(h)]
+	for i, b := range entry.Sum(nil) {
+		leaf[i] ^= b
+	}
+	t.built = false
+}
+
+// Nodes 返回所有节点的哈希，用于发送给对端
+func (t *Tree) Nodes() [][]byte {
+	t.build()
+	return t.nodes
+}
+
+func (t *Tree) Root() []byte {
+	t.build()
+	return t.nodes[0]
+}
+
+// Diff 从根开始只比较不一致的子树，返回哈希不同的叶子下标，
+// 两棵树需覆盖相同的区间且深度相同
+func (t *Tree) Diff(other *Tree) []int {
+	t.build()
+	other.build()
+
+	var leaves []int
+	var walk func(i int)
+	walk = func(i int) {
+		if bytes.Equal(t.nodes[i], other.nodes[i]) {
+			return
+		}
+		if i >= t.leaves()-1 {
+			leaves = append(leaves, i-(t.leaves()-1))
+			return
+		}
+		walk(2*i + 1)
+		walk(2*i + 2)
+	}
+	walk(0)
+
+	return leaves
+}
+
+func (t *Tree) leaves() int {
+	return 1 << t.depth
+}
+
+// build 自底向上计算内部节点的哈希
+func (t *Tree) build() {
+	if t.built {
+		return
+	}
+	for i := t.leaves() - 2; i >= 0; i-- {
+		h := sha256.New()
+		h.Write(t.nodes[2*i+1])
+		h.Write(t.nodes[2*i+2])
+		t.nodes[i] = h.Sum(nil)
+	}
+	t.built = true
+}
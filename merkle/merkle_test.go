@@ -0,0 +1,114 @@
+package merkle
+
+import (
+	"fmt"
+	"testing"
+
+	"rushkv/hash"
+)
+
+const ringSize = 1 << 32
+
+func TestLeafAtRangeBoundaries(t *testing.T) {
+	tree := New(hash.Range{Start: 1000, End: 1400}, 2)
+	cases := map[int]int{
+		1001: 0, // 区间不包含Start
+		1100: 0,
+		1101: 1,
+		1300: 2,
+		1301: 3,
+		1400: 3,
+	}
+	for h, want := range cases {
+		if got := tree.Leaf(h); got != want {
+			t.Errorf("Leaf(%d) = %d, want %d", h, got, want)
+		}
+	}
+}
+
+func TestLeafInWrappingRange(t *testing.T) {
+	// 跨过环的零点
+	tree := New(hash.Range{Start: ringSize - 100, End: 100}, 2)
+	cases := map[int]int{
+		ringSize - 99: 0,
+		ringSize - 1:  1,
+		0:             1,
+		1:             2,
+		51:            3,
+		100:           3,
+	}
+	for h, want := range cases {
+		if got := tree.Leaf(h); got != want {
+			t.Errorf("Leaf(%d) = %d, want %d", h, got, want)
+		}
+	}
+}
+
+func TestDiffFindsDifferingLeaves(t *testing.T) {
+	r := hash.Range{Start: ringSize - 1000, End: 1000}
+	a := New(r, 3)
+	b := New(r, 3)
+	for i := 0; i < 2000; i += 10 {
+		h := (ringSize - 999 + i) % ringSize
+		a.Add(h, fmt.Sprint("k", i), int64(i), false)
+		b.Add(h, fmt.Sprint("k", i), int64(i), false)
+	}
+	if leaves := a.Diff(b); len(leaves) != 0 {
+		t.Fatalf("Diff of equal trees = %v, want none", leaves)
+	}
+
+	// 版本不同、墓碑和缺失的记录都会使所在叶子不同
+	h1, h2 := ringSize-999, 999
+	a.Add(h1, "x", 1, false)
+	b.Add(h1, "x", 2, false)
+	a.Add(h2, "y", 1, true)
+	b.Add(h2, "y", 1, false)
+
+	leaves := a.Diff(b)
+	want := []int{a.Leaf(h1), a.Leaf(h2)}
+	if fmt.Sprint(leaves) != fmt.Sprint(want) {
+		t.Errorf("Diff = %v, want %v", leaves, want)
+	}
+}
+
+func TestAddIsOrderIndependent(t *testing.T) {
+	r := hash.Range{Start: 0, End: 1 << 20}
+	a := New(r, 4)
+	b := New(r, 4)
+	for i := 0; i < 100; i++ {
+		a.Add(i*100+1, fmt.Sprint("k", i), 1, false)
+		b.Add((99-i)*100+1, fmt.Sprint("k", 99-i), 1, false)
+	}
+	if fmt.Sprint(a.Root()) != fmt.Sprint(b.Root()) {
+		t.Error("roots differ for the same records added in a different order")
+	}
+}
+
+func TestFromNodesRoundTrip(t *testing.T) {
+	r := hash.Range{Start: 10, End: 5000}
+	local := New(r, 3)
+	local.Add(100, "a", 1, false)
+	local.Add(4000, "b", 2, false)
+
+	// 模拟经过网络传输，不与本地的树共享内存
+	var nodes [][]byte
+	for _, node := range local.Nodes() {
+		nodes = append(nodes, append([]byte(nil), node...))
+	}
+	remote, err := FromNodes(r, 3, nodes)
+	if err != nil {
+		t.Fatalf("FromNodes: %v", err)
+	}
+	if leaves := local.Diff(remote); len(leaves) != 0 {
+		t.Errorf("Diff against the received tree = %v, want none", leaves)
+	}
+
+	local.Add(200, "c", 3, false)
+	if leaves := local.Diff(remote); len(leaves) != 1 || leaves[0] != local.Leaf(200) {
+		t.Errorf("Diff after a local change = %v, want [%d]", leaves, local.Leaf(200))
+	}
+
+	if _, err := FromNodes(r, 3, local.Nodes()[:5]); err == nil {
+		t.Error("FromNodes accepted the wrong number of nodes")
+	}
+}
@@ -24,10 +24,33 @@ const _ = grpc.SupportPackageIsVersion7
 type RushKVClient interface {
 	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	MultiGet(ctx context.Context, in *MultiGetRequest, opts ...grpc.CallOption) (*MultiGetResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	CompareAndSwap(ctx context.Context, in *CompareAndSwapRequest, opts ...grpc.CallOption) (*CompareAndSwapResponse, error)
+	Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error)
+	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
 	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
 	Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error)
 	GetClusterInfo(ctx context.Context, in *ClusterInfoRequest, opts ...grpc.CallOption) (*ClusterInfoResponse, error)
+	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (RushKV_ScanClient, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (RushKV_WatchClient, error)
+	ChangeFeed(ctx context.Context, in *ChangeFeedRequest, opts ...grpc.CallOption) (RushKV_ChangeFeedClient, error)
+	// 节点间内部接口
+	Replicate(ctx context.Context, in *ReplicateRequest, opts ...grpc.CallOption) (*ReplicateResponse, error)
+	ReadReplica(ctx context.Context, in *ReadReplicaRequest, opts ...grpc.CallOption) (*ReadReplicaResponse, error)
+	RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesResponse, error)
+	Handoff(ctx context.Context, opts ...grpc.CallOption) (RushKV_HandoffClient, error)
+	GetNodeStatus(ctx context.Context, in *NodeStatusRequest, opts ...grpc.CallOption) (*NodeStatus, error)
+	Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeResponse, error)
+	MerkleTrees(ctx context.Context, in *MerkleTreesRequest, opts ...grpc.CallOption) (*MerkleTreesResponse, error)
+	RangePairs(ctx context.Context, in *RangePairsRequest, opts ...grpc.CallOption) (*RangePairsResponse, error)
+	TxnPrepare(ctx context.Context, in *TxnPrepareRequest, opts ...grpc.CallOption) (*TxnPrepareResponse, error)
+	TxnFinish(ctx context.Context, in *TxnFinishRequest, opts ...grpc.CallOption) (*TxnFinishResponse, error)
+	// 管理接口
+	GetHints(ctx context.Context, in *HintsRequest, opts ...grpc.CallOption) (*HintsResponse, error)
+	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
 }
 
 type rushKVClient struct {
@@ -56,6 +79,15 @@ func (c *rushKVClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *rushKVClient) MultiGet(ctx context.Context, in *MultiGetRequest, opts ...grpc.CallOption) (*MultiGetResponse, error) {
+	out := new(MultiGetResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/MultiGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rushKVClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	out := new(DeleteResponse)
 	err := c.cc.Invoke(ctx, "/rushkv.RushKV/Delete", in, out, opts...)
@@ -65,6 +97,33 @@ func (c *rushKVClient) Delete(ctx context.Context, in *DeleteRequest, opts ...gr
 	return out, nil
 }
 
+func (c *rushKVClient) CompareAndSwap(ctx context.Context, in *CompareAndSwapRequest, opts ...grpc.CallOption) (*CompareAndSwapResponse, error) {
+	out := new(CompareAndSwapResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/CompareAndSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error) {
+	out := new(TxnResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/Txn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/Batch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rushKVClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
 	out := new(JoinResponse)
 	err := c.cc.Invoke(ctx, "/rushkv.RushKV/Join", in, out, opts...)
@@ -92,16 +151,286 @@ func (c *rushKVClient) GetClusterInfo(ctx context.Context, in *ClusterInfoReques
 	return out, nil
 }
 
+func (c *rushKVClient) Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (RushKV_ScanClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RushKV_ServiceDesc.Streams[0], "/rushkv.RushKV/Scan", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rushKVScanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RushKV_ScanClient interface {
+	Recv() (*KVPair, error)
+	grpc.ClientStream
+}
+
+type rushKVScanClient struct {
+	grpc.ClientStream
+}
+
+func (x *rushKVScanClient) Recv() (*KVPair, error) {
+	m := new(KVPair)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rushKVClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (RushKV_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RushKV_ServiceDesc.Streams[1], "/rushkv.RushKV/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rushKVWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RushKV_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type rushKVWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *rushKVWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rushKVClient) ChangeFeed(ctx context.Context, in *ChangeFeedRequest, opts ...grpc.CallOption) (RushKV_ChangeFeedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RushKV_ServiceDesc.Streams[2], "/rushkv.RushKV/ChangeFeed", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rushKVChangeFeedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RushKV_ChangeFeedClient interface {
+	Recv() (*ChangeEvent, error)
+	grpc.ClientStream
+}
+
+type rushKVChangeFeedClient struct {
+	grpc.ClientStream
+}
+
+func (x *rushKVChangeFeedClient) Recv() (*ChangeEvent, error) {
+	m := new(ChangeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rushKVClient) Replicate(ctx context.Context, in *ReplicateRequest, opts ...grpc.CallOption) (*ReplicateResponse, error) {
+	out := new(ReplicateResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/Replicate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) ReadReplica(ctx context.Context, in *ReadReplicaRequest, opts ...grpc.CallOption) (*ReadReplicaResponse, error) {
+	out := new(ReadReplicaResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/ReadReplica", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/RequestVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesResponse, error) {
+	out := new(AppendEntriesResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/AppendEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) Handoff(ctx context.Context, opts ...grpc.CallOption) (RushKV_HandoffClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RushKV_ServiceDesc.Streams[3], "/rushkv.RushKV/Handoff", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rushKVHandoffClient{stream}
+	return x, nil
+}
+
+type RushKV_HandoffClient interface {
+	Send(*HandoffRequest) error
+	CloseAndRecv() (*HandoffResponse, error)
+	grpc.ClientStream
+}
+
+type rushKVHandoffClient struct {
+	grpc.ClientStream
+}
+
+func (x *rushKVHandoffClient) Send(m *HandoffRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *rushKVHandoffClient) CloseAndRecv() (*HandoffResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(HandoffResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rushKVClient) GetNodeStatus(ctx context.Context, in *NodeStatusRequest, opts ...grpc.CallOption) (*NodeStatus, error) {
+	out := new(NodeStatus)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/GetNodeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeResponse, error) {
+	out := new(ProbeResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/Probe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) MerkleTrees(ctx context.Context, in *MerkleTreesRequest, opts ...grpc.CallOption) (*MerkleTreesResponse, error) {
+	out := new(MerkleTreesResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/MerkleTrees", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) RangePairs(ctx context.Context, in *RangePairsRequest, opts ...grpc.CallOption) (*RangePairsResponse, error) {
+	out := new(RangePairsResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/RangePairs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) TxnPrepare(ctx context.Context, in *TxnPrepareRequest, opts ...grpc.CallOption) (*TxnPrepareResponse, error) {
+	out := new(TxnPrepareResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/TxnPrepare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) TxnFinish(ctx context.Context, in *TxnFinishRequest, opts ...grpc.CallOption) (*TxnFinishResponse, error) {
+	out := new(TxnFinishResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/TxnFinish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) GetHints(ctx context.Context, in *HintsRequest, opts ...grpc.CallOption) (*HintsResponse, error) {
+	out := new(HintsResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/GetHints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rushKVClient) Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error) {
+	out := new(CompactResponse)
+	err := c.cc.Invoke(ctx, "/rushkv.RushKV/Compact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RushKVServer is the server API for RushKV service.
 // All implementations must embed UnimplementedRushKVServer
 // for forward compatibility
 type RushKVServer interface {
 	Put(context.Context, *PutRequest) (*PutResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
+	MultiGet(context.Context, *MultiGetRequest) (*MultiGetResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	CompareAndSwap(context.Context, *CompareAndSwapRequest) (*CompareAndSwapResponse, error)
+	Txn(context.Context, *TxnRequest) (*TxnResponse, error)
+	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
 	Join(context.Context, *JoinRequest) (*JoinResponse, error)
 	Leave(context.Context, *LeaveRequest) (*LeaveResponse, error)
 	GetClusterInfo(context.Context, *ClusterInfoRequest) (*ClusterInfoResponse, error)
+	Scan(*ScanRequest, RushKV_ScanServer) error
+	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	Watch(*WatchRequest, RushKV_WatchServer) error
+	ChangeFeed(*ChangeFeedRequest, RushKV_ChangeFeedServer) error
+	// 节点间内部接口
+	Replicate(context.Context, *ReplicateRequest) (*ReplicateResponse, error)
+	ReadReplica(context.Context, *ReadReplicaRequest) (*ReadReplicaResponse, error)
+	RequestVote(context.Context, *VoteRequest) (*VoteResponse, error)
+	AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesResponse, error)
+	Handoff(RushKV_HandoffServer) error
+	GetNodeStatus(context.Context, *NodeStatusRequest) (*NodeStatus, error)
+	Probe(context.Context, *ProbeRequest) (*ProbeResponse, error)
+	MerkleTrees(context.Context, *MerkleTreesRequest) (*MerkleTreesResponse, error)
+	RangePairs(context.Context, *RangePairsRequest) (*RangePairsResponse, error)
+	TxnPrepare(context.Context, *TxnPrepareRequest) (*TxnPrepareResponse, error)
+	TxnFinish(context.Context, *TxnFinishRequest) (*TxnFinishResponse, error)
+	// 管理接口
+	GetHints(context.Context, *HintsRequest) (*HintsResponse, error)
+	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
 	mustEmbedUnimplementedRushKVServer()
 }
 
@@ -115,9 +444,21 @@ func (UnimplementedRushKVServer) Put(context.Context, *PutRequest) (*PutResponse
 func (UnimplementedRushKVServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedRushKVServer) MultiGet(context.Context, *MultiGetRequest) (*MultiGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MultiGet not implemented")
+}
 func (UnimplementedRushKVServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedRushKVServer) CompareAndSwap(context.Context, *CompareAndSwapRequest) (*CompareAndSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareAndSwap not implemented")
+}
+func (UnimplementedRushKVServer) Txn(context.Context, *TxnRequest) (*TxnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Txn not implemented")
+}
+func (UnimplementedRushKVServer) Batch(context.Context, *BatchRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Batch not implemented")
+}
 func (UnimplementedRushKVServer) Join(context.Context, *JoinRequest) (*JoinResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
 }
@@ -127,6 +468,57 @@ func (UnimplementedRushKVServer) Leave(context.Context, *LeaveRequest) (*LeaveRe
 func (UnimplementedRushKVServer) GetClusterInfo(context.Context, *ClusterInfoRequest) (*ClusterInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetClusterInfo not implemented")
 }
+func (UnimplementedRushKVServer) Scan(*ScanRequest, RushKV_ScanServer) error {
+	return status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
+func (UnimplementedRushKVServer) History(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (UnimplementedRushKVServer) Watch(*WatchRequest, RushKV_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedRushKVServer) ChangeFeed(*ChangeFeedRequest, RushKV_ChangeFeedServer) error {
+	return status.Errorf(codes.Unimplemented, "method ChangeFeed not implemented")
+}
+func (UnimplementedRushKVServer) Replicate(context.Context, *ReplicateRequest) (*ReplicateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replicate not implemented")
+}
+func (UnimplementedRushKVServer) ReadReplica(context.Context, *ReadReplicaRequest) (*ReadReplicaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadReplica not implemented")
+}
+func (UnimplementedRushKVServer) RequestVote(context.Context, *VoteRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (UnimplementedRushKVServer) AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
+}
+func (UnimplementedRushKVServer) Handoff(RushKV_HandoffServer) error {
+	return status.Errorf(codes.Unimplemented, "method Handoff not implemented")
+}
+func (UnimplementedRushKVServer) GetNodeStatus(context.Context, *NodeStatusRequest) (*NodeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeStatus not implemented")
+}
+func (UnimplementedRushKVServer) Probe(context.Context, *ProbeRequest) (*ProbeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Probe not implemented")
+}
+func (UnimplementedRushKVServer) MerkleTrees(context.Context, *MerkleTreesRequest) (*MerkleTreesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MerkleTrees not implemented")
+}
+func (UnimplementedRushKVServer) RangePairs(context.Context, *RangePairsRequest) (*RangePairsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RangePairs not implemented")
+}
+func (UnimplementedRushKVServer) TxnPrepare(context.Context, *TxnPrepareRequest) (*TxnPrepareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxnPrepare not implemented")
+}
+func (UnimplementedRushKVServer) TxnFinish(context.Context, *TxnFinishRequest) (*TxnFinishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxnFinish not implemented")
+}
+func (UnimplementedRushKVServer) GetHints(context.Context, *HintsRequest) (*HintsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHints not implemented")
+}
+func (UnimplementedRushKVServer) Compact(context.Context, *CompactRequest) (*CompactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
+}
 func (UnimplementedRushKVServer) mustEmbedUnimplementedRushKVServer() {}
 
 // UnsafeRushKVServer may be embedded to opt out of forward compatibility for this service.
@@ -176,6 +568,24 @@ func _RushKV_Get_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RushKV_MultiGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).MultiGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/MultiGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).MultiGet(ctx, req.(*MultiGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RushKV_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -194,6 +604,60 @@ func _RushKV_Delete_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RushKV_CompareAndSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareAndSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).CompareAndSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/CompareAndSwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).CompareAndSwap(ctx, req.(*CompareAndSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_Txn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).Txn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/Txn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).Txn(ctx, req.(*TxnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/Batch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).Batch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RushKV_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(JoinRequest)
 	if err := dec(in); err != nil {
@@ -248,6 +712,329 @@ func _RushKV_GetClusterInfo_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RushKV_Scan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RushKVServer).Scan(m, &rushKVScanServer{stream})
+}
+
+type RushKV_ScanServer interface {
+	Send(*KVPair) error
+	grpc.ServerStream
+}
+
+type rushKVScanServer struct {
+	grpc.ServerStream
+}
+
+func (x *rushKVScanServer) Send(m *KVPair) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RushKV_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RushKVServer).Watch(m, &rushKVWatchServer{stream})
+}
+
+type RushKV_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type rushKVWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *rushKVWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RushKV_ChangeFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChangeFeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RushKVServer).ChangeFeed(m, &rushKVChangeFeedServer{stream})
+}
+
+type RushKV_ChangeFeedServer interface {
+	Send(*ChangeEvent) error
+	grpc.ServerStream
+}
+
+type rushKVChangeFeedServer struct {
+	grpc.ServerStream
+}
+
+func (x *rushKVChangeFeedServer) Send(m *ChangeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RushKV_Replicate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).Replicate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/Replicate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).Replicate(ctx, req.(*ReplicateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_ReadReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadReplicaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).ReadReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/ReadReplica",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).ReadReplica(ctx, req.(*ReadReplicaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/RequestVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).RequestVote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_AppendEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).AppendEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/AppendEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).AppendEntries(ctx, req.(*AppendEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_Handoff_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RushKVServer).Handoff(&rushKVHandoffServer{stream})
+}
+
+type RushKV_HandoffServer interface {
+	SendAndClose(*HandoffResponse) error
+	Recv() (*HandoffRequest, error)
+	grpc.ServerStream
+}
+
+type rushKVHandoffServer struct {
+	grpc.ServerStream
+}
+
+func (x *rushKVHandoffServer) SendAndClose(m *HandoffResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *rushKVHandoffServer) Recv() (*HandoffRequest, error) {
+	m := new(HandoffRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _RushKV_GetNodeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).GetNodeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/GetNodeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).GetNodeStatus(ctx, req.(*NodeStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_Probe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).Probe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/Probe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).Probe(ctx, req.(*ProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_MerkleTrees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerkleTreesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).MerkleTrees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/MerkleTrees",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).MerkleTrees(ctx, req.(*MerkleTreesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_RangePairs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RangePairsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).RangePairs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/RangePairs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).RangePairs(ctx, req.(*RangePairsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_TxnPrepare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnPrepareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).TxnPrepare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/TxnPrepare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).TxnPrepare(ctx, req.(*TxnPrepareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_TxnFinish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnFinishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).TxnFinish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/TxnFinish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).TxnFinish(ctx, req.(*TxnFinishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_GetHints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).GetHints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/GetHints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).GetHints(ctx, req.(*HintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RushKV_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RushKVServer).Compact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rushkv.RushKV/Compact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RushKVServer).Compact(ctx, req.(*CompactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RushKV_ServiceDesc is the grpc.ServiceDesc for RushKV service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -263,10 +1050,26 @@ var RushKV_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _RushKV_Get_Handler,
 		},
+		{
+			MethodName: "MultiGet",
+			Handler:    _RushKV_MultiGet_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _RushKV_Delete_Handler,
 		},
+		{
+			MethodName: "CompareAndSwap",
+			Handler:    _RushKV_CompareAndSwap_Handler,
+		},
+		{
+			MethodName: "Txn",
+			Handler:    _RushKV_Txn_Handler,
+		},
+		{
+			MethodName: "Batch",
+			Handler:    _RushKV_Batch_Handler,
+		},
 		{
 			MethodName: "Join",
 			Handler:    _RushKV_Join_Handler,
@@ -279,7 +1082,80 @@ var RushKV_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetClusterInfo",
 			Handler:    _RushKV_GetClusterInfo_Handler,
 		},
+		{
+			MethodName: "History",
+			Handler:    _RushKV_History_Handler,
+		},
+		{
+			MethodName: "Replicate",
+			Handler:    _RushKV_Replicate_Handler,
+		},
+		{
+			MethodName: "ReadReplica",
+			Handler:    _RushKV_ReadReplica_Handler,
+		},
+		{
+			MethodName: "RequestVote",
+			Handler:    _RushKV_RequestVote_Handler,
+		},
+		{
+			MethodName: "AppendEntries",
+			Handler:    _RushKV_AppendEntries_Handler,
+		},
+		{
+			MethodName: "GetNodeStatus",
+			Handler:    _RushKV_GetNodeStatus_Handler,
+		},
+		{
+			MethodName: "Probe",
+			Handler:    _RushKV_Probe_Handler,
+		},
+		{
+			MethodName: "MerkleTrees",
+			Handler:    _RushKV_MerkleTrees_Handler,
+		},
+		{
+			MethodName: "RangePairs",
+			Handler:    _RushKV_RangePairs_Handler,
+		},
+		{
+			MethodName: "TxnPrepare",
+			Handler:    _RushKV_TxnPrepare_Handler,
+		},
+		{
+			MethodName: "TxnFinish",
+			Handler:    _RushKV_TxnFinish_Handler,
+		},
+		{
+			MethodName: "GetHints",
+			Handler:    _RushKV_GetHints_Handler,
+		},
+		{
+			MethodName: "Compact",
+			Handler:    _RushKV_Compact_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Scan",
+			Handler:       _RushKV_Scan_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _RushKV_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ChangeFeed",
+			Handler:       _RushKV_ChangeFeed_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Handoff",
+			Handler:       _RushKV_Handoff_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/rushkv.proto",
 }
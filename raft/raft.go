@@ -0,0 +1,809 @@
+package raft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotLeader           = errors.New("not the leader")
+	ErrConfigChangePending = errors.New("another membership change is in progress")
+	ErrLeadershipLost      = errors.New("leadership lost before the entry was committed")
+	ErrStopped             = errors.New("raft node stopped")
+)
+
+// 单次AppendEntries携带的最大日志条数
+const maxAppendEntries = 64
+
+type EntryType int
+
+const (
+	EntryNoop   EntryType = iota // 新leader上任时追加的空日志
+	EntryConfig                  // 成员配置变更，Data为[]Server的JSON
+)
+
+type Entry struct {
+	Index uint64    `json:"index"`
+	Term  uint64    `json:"term"`
+	Type  EntryType `json:"type"`
+	Data  []byte    `json:"data"`
+}
+
+// Server 集群成员
+type Server struct {
+	ID      string `json:"id"`
+	Address string `json:"address"` // host:port
+}
+
+type VoteRequest struct {
+	Term         uint64
+	CandidateID  string
+	LastLogIndex uint64
+	LastLogTerm  uint64
+}
+
+type VoteResponse struct {
+	Term        uint64
+	VoteGranted bool
+}
+
+type AppendRequest struct {
+	Term         uint64
+	LeaderID     string
+	PrevLogIndex uint64
+	PrevLogTerm  uint64
+	Entries      []Entry
+	LeaderCommit uint64
+}
+
+type AppendResponse struct {
+	Term    uint64
+	Success bool
+	// 日志不匹配时，leader下一次应从该索引开始发送
+	ConflictIndex uint64
+}
+
+// Transport 节点间RPC
+type Transport interface {
+	RequestVote(ctx context.Context, target Server, req *VoteRequest) (*VoteResponse, error)
+	AppendEntries(ctx context.Context, target Server, req *AppendRequest) (*AppendResponse, error)
+}
+
+type Config struct {
+	ID string
+	// 选举超时下限，实际超时在[ElectionTimeout, 2*ElectionTimeout)之间随机选取
+	ElectionTimeout   time.Duration
+	HeartbeatInterval time.Duration
+}
+
+func DefaultConfig(id string) Config {
+	return Config{
+		ID:                id,
+		ElectionTimeout:   1 * time.Second,
+		HeartbeatInterval: 200 * time.Millisecond,
+	}
+}
+
+type role int
+
+const (
+	follower role = iota
+	candidate
+	leader
+)
+
+func (r role) String() string {
+	switch r {
+	case leader:
+		return "leader"
+	case candidate:
+		return "candidate"
+	default:
+		return "follower"
+	}
+}
+
+// Node 一个Raft节点。成员配置本身也通过日志复制，
+// 每个节点始终使用日志中最新的配置，每次只允许变更一个成员
+type Node struct {
+	config    Config
+	store     *Store
+	transport Transport
+	apply     func(Entry)
+
+	mutex       sync.Mutex
+	role        role
+	currentTerm uint64
+	votedFor    string
+	leaderID    string
+	log         []Entry // log[0]为哨兵，log[i].Index == i
+	commitIndex uint64
+	lastApplied uint64
+	servers     []Server
+	configIndex uint64 // servers所在的日志索引
+
+	nextIndex  map[string]uint64
+	matchIndex map[string]uint64
+	inflight   map[string]bool
+
+	lastContact     time.Time
+	electionTimeout time.Duration
+
+	commitNotify chan struct{} // commitIndex推进时关闭并替换
+	applyCh      chan struct{}
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+}
+
+// NewNode 从store恢复状态并创建节点，apply会按顺序收到每条已提交的日志
+func NewNode(config Config, store *Store, transport Transport, apply func(Entry)) (*Node, error) {
+	term, votedFor, err := store.State()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load raft state: %v", err)
+	}
+	entries, err := store.Entries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load raft log: %v", err)
+	}
+
+	n := &Node{
+		config:       config,
+		store:        store,
+		transport:    transport,
+		apply:        apply,
+		currentTerm:  term,
+		votedFor:     votedFor,
+		log:          append([]Entry{{}}, entries...),
+		nextIndex:    make(map[string]uint64),
+		matchIndex:   make(map[string]uint64),
+		inflight:     make(map[string]bool),
+		commitNotify: make(chan struct{}),
+		applyCh:      make(chan struct{}, 1),
+		stopCh:       make(chan struct{}),
+	}
+	for i, entry := range n.log {
+		if entry.Index != uint64(i) {
+			return nil, fmt.Errorf("raft log is not contiguous at index %d", i)
+		}
+	}
+	n.reloadConfig()
+	n.resetElectionTimer()
+
+	return n, nil
+}
+
+// Bootstrap 以给定成员初始化一个新集群，只能在日志为空时调用
+func (n *Node) Bootstrap(servers []Server) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.lastIndex() > 0 {
+		return fmt.Errorf("raft log is not empty")
+	}
+
+	data, err := json.Marshal(servers)
+	if err != nil {
+		return err
+	}
+
+	n.currentTerm = 1
+	if err := n.persistState(); err != nil {
+		return err
+	}
+	if err := n.appendStored([]Entry{{Index: 1, Term: 1, Type: EntryConfig, Data: data}}); err != nil {
+		return err
+	}
+
+	// 初始配置无需投票即视为已提交
+	n.commitIndex = 1
+	n.notifyCommit()
+	return nil
+}
+
+func (n *Node) Start() {
+	n.wg.Add(2)
+	go n.ticker()
+	go n.applier()
+}
+
+func (n *Node) Stop() {
+	close(n.stopCh)
+	n.wg.Wait()
+}
+
+func (n *Node) IsLeader() bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.role == leader
+}
+
+// Leader 返回当前已知的leader
+func (n *Node) Leader() (Server, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, server := range n.servers {
+		if server.ID == n.leaderID {
+			return server, true
+		}
+	}
+	return Server{}, false
+}
+
+// Configuration 返回日志中最新的成员配置及其所在的日志索引
+func (n *Node) Configuration() ([]Server, uint64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return append([]Server(nil), n.servers...), n.configIndex
+}
+
+// State 返回节点当前的角色
+func (n *Node) State() string {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.role.String()
+}
+
+func (n *Node) Term() uint64 {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.currentTerm
+}
+
+// AddServer 由leader追加包含新成员的配置，并等待其提交
+func (n *Node) AddServer(ctx context.Context, server Server) error {
+	n.mutex.Lock()
+	servers := make([]Server, 0, len(n.servers)+1)
+	for _, s := range n.servers {
+		if s.ID == server.ID {
+			if s.Address == server.Address {
+				n.mutex.Unlock()
+				return nil
+			}
+			continue
+		}
+		servers = append(servers, s)
+	}
+	servers = append(servers, server)
+
+	index, term, err := n.appendConfig(servers)
+	n.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	return n.waitCommit(ctx, index, term)
+}
+
+// RemoveServer 由leader追加移除成员后的配置，并等待其提交
+func (n *Node) RemoveServer(ctx context.Context, id string) error {
+	n.mutex.Lock()
+	servers := make([]Server, 0, len(n.servers))
+	for _, s := range n.servers {
+		if s.ID != id {
+			servers = append(servers, s)
+		}
+	}
+	if len(servers) == len(n.servers) {
+		n.mutex.Unlock()
+		return nil
+	}
+
+	index, term, err := n.appendConfig(servers)
+	n.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	return n.waitCommit(ctx, index, term)
+}
+
+func (n *Node) appendConfig(servers []Server) (uint64, uint64, error) {
+	if n.role != leader {
+		return 0, 0, ErrNotLeader
+	}
+	if n.configIndex > n.commitIndex {
+		return 0, 0, ErrConfigChangePending
+	}
+
+	data, err := json.Marshal(servers)
+	if err != nil {
+		return 0, 0, err
+	}
+	entry, err := n.appendLocal(EntryConfig, data)
+	if err != nil {
+		return 0, 0, err
+	}
+	return entry.Index, entry.Term, nil
+}
+
+// waitCommit 等待指定日志提交，若日志被新leader覆盖则返回ErrLeadershipLost
+func (n *Node) waitCommit(ctx context.Context, index, term uint64) error {
+	for {
+		n.mutex.Lock()
+		if n.commitIndex >= index {
+			ok := n.log[index].Term == term
+			n.mutex.Unlock()
+			if !ok {
+				return ErrLeadershipLost
+			}
+			return nil
+		}
+		ch := n.commitNotify
+		n.mutex.Unlock()
+
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-n.stopCh:
+			return ErrStopped
+		}
+	}
+}
+
+func (n *Node) HandleRequestVote(req *VoteRequest) *VoteResponse {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	resp := &VoteResponse{Term: n.currentTerm}
+	if req.Term < n.currentTerm {
+		return resp
+	}
+
+	// 仍能收到leader消息时拒绝投票，避免已被移除或短暂失联的节点扰乱集群
+	if req.Term > n.currentTerm && (n.role == leader ||
+		(n.leaderID != "" && time.Since(n.lastContact) < n.config.ElectionTimeout)) {
+		return resp
+	}
+
+	if req.Term > n.currentTerm {
+		n.stepDown(req.Term)
+	}
+	resp.Term = n.currentTerm
+
+	lastIndex, lastTerm := n.lastIndex(), n.lastTerm()
+	upToDate := req.LastLogTerm > lastTerm || (req.LastLogTerm == lastTerm && req.LastLogIndex >= lastIndex)
+	if (n.votedFor == "" || n.votedFor == req.CandidateID) && upToDate {
+		n.votedFor = req.CandidateID
+		if err := n.persistState(); err != nil {
+			log.Printf("raft: failed to persist vote: %v", err)
+			return resp
+		}
+		n.resetElectionTimer()
+		resp.VoteGranted = true
+	}
+
+	return resp
+}
+
+func (n *Node) HandleAppendEntries(req *AppendRequest) *AppendResponse {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	resp := &AppendResponse{Term: n.currentTerm}
+	if req.Term < n.currentTerm {
+		return resp
+	}
+	if req.Term > n.currentTerm || n.role != follower {
+		n.stepDown(req.Term)
+	}
+	resp.Term = n.currentTerm
+	n.leaderID = req.LeaderID
+	n.resetElectionTimer()
+
+	// 检查前一条日志是否匹配
+	lastIndex := n.lastIndex()
+	if req.PrevLogIndex > lastIndex {
+		resp.ConflictIndex = lastIndex + 1
+		return resp
+	}
+	if n.log[req.PrevLogIndex].Term != req.PrevLogTerm {
+		conflictTerm := n.log[req.PrevLogIndex].Term
+		index := req.PrevLogIndex
+		for index > 1 && n.log[index-1].Term == conflictTerm {
+			index--
+		}
+		resp.ConflictIndex = index
+		return resp
+	}
+
+	// 跳过已有的日志，从第一条冲突处截断后追加
+	for i, entry := range req.Entries {
+		if entry.Index <= n.lastIndex() {
+			if n.log[entry.Index].Term == entry.Term {
+				continue
+			}
+			if err := n.truncateFrom(entry.Index); err != nil {
+				log.Printf("raft: failed to truncate log: %v", err)
+				return resp
+			}
+		}
+		if err := n.appendStored(req.Entries[i:]); err != nil {
+			log.Printf("raft: failed to append log: %v", err)
+			return resp
+		}
+		break
+	}
+
+	if req.LeaderCommit > n.commitIndex {
+		lastNew := req.PrevLogIndex + uint64(len(req.Entries))
+		if req.LeaderCommit < lastNew {
+			lastNew = req.LeaderCommit
+		}
+		if lastNew > n.commitIndex {
+			n.commitIndex = lastNew
+			n.notifyCommit()
+		}
+	}
+
+	resp.Success = true
+	return resp
+}
+
+func (n *Node) ticker() {
+	defer n.wg.Done()
+
+	t := time.NewTicker(n.config.HeartbeatInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case <-t.C:
+			n.tick()
+		}
+	}
+}
+
+func (n *Node) tick() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.role == leader {
+		n.broadcastAppend()
+		return
+	}
+
+	// 不在配置中的节点（等待加入或已被移除）不发起选举
+	if !n.isVoter(n.config.ID) {
+		return
+	}
+	if time.Since(n.lastContact) >= n.electionTimeout {
+		n.startElection()
+	}
+}
+
+func (n *Node) startElection() {
+	n.role = candidate
+	n.currentTerm++
+	n.votedFor = n.config.ID
+	n.leaderID = ""
+	if err := n.persistState(); err != nil {
+		log.Printf("raft: failed to persist state: %v", err)
+		return
+	}
+	n.resetElectionTimer()
+
+	term := n.currentTerm
+	req := &VoteRequest{
+		Term:         term,
+		CandidateID:  n.config.ID,
+		LastLogIndex: n.lastIndex(),
+		LastLogTerm:  n.lastTerm(),
+	}
+
+	votes := 1
+	if votes >= n.quorum() {
+		n.becomeLeader()
+		return
+	}
+
+	for _, server := range n.servers {
+		if server.ID == n.config.ID {
+			continue
+		}
+		go func(server Server) {
+			ctx, cancel := context.WithTimeout(context.Background(), n.config.ElectionTimeout/2)
+			defer cancel()
+
+			resp, err := n.transport.RequestVote(ctx, server, req)
+			if err != nil {
+				return
+			}
+
+			n.mutex.Lock()
+			defer n.mutex.Unlock()
+
+			if resp.Term > n.currentTerm {
+				n.stepDown(resp.Term)
+				return
+			}
+			if n.role != candidate || n.currentTerm != term || !resp.VoteGranted {
+				return
+			}
+			votes++
+			if votes >= n.quorum() {
+				n.becomeLeader()
+			}
+		}(server)
+	}
+}
+
+func (n *Node) becomeLeader() {
+	n.role = leader
+	n.leaderID = n.config.ID
+	for _, server := range n.servers {
+		n.nextIndex[server.ID] = n.lastIndex() + 1
+		n.matchIndex[server.ID] = 0
+	}
+	log.Printf("raft: %s became leader for term %d", n.config.ID, n.currentTerm)
+
+	// 追加一条空日志，以便提交之前任期的日志
+	if _, err := n.appendLocal(EntryNoop, nil); err != nil {
+		log.Printf("raft: failed to append noop entry: %v", err)
+	}
+	n.broadcastAppend()
+}
+
+func (n *Node) stepDown(term uint64) {
+	if term > n.currentTerm {
+		n.currentTerm = term
+		n.votedFor = ""
+		n.leaderID = ""
+		if err := n.persistState(); err != nil {
+			log.Printf("raft: failed to persist state: %v", err)
+		}
+	}
+	if n.role == leader {
+		log.Printf("raft: %s stepped down in term %d", n.config.ID, n.currentTerm)
+		n.leaderID = ""
+	}
+	n.role = follower
+}
+
+func (n *Node) broadcastAppend() {
+	for _, server := range n.servers {
+		if server.ID == n.config.ID || n.inflight[server.ID] {
+			continue
+		}
+		n.inflight[server.ID] = true
+		go n.replicateTo(server)
+	}
+}
+
+// replicateTo 向单个follower发送一批日志，调用前需将inflight置为true
+func (n *Node) replicateTo(server Server) {
+	n.mutex.Lock()
+	if n.role != leader {
+		n.inflight[server.ID] = false
+		n.mutex.Unlock()
+		return
+	}
+
+	next := n.nextIndex[server.ID]
+	if next < 1 {
+		next = 1
+	}
+	if next > n.lastIndex()+1 {
+		next = n.lastIndex() + 1
+	}
+	end := next + maxAppendEntries
+	if end > n.lastIndex()+1 {
+		end = n.lastIndex() + 1
+	}
+
+	term := n.currentTerm
+	req := &AppendRequest{
+		Term:         term,
+		LeaderID:     n.config.ID,
+		PrevLogIndex: next - 1,
+		PrevLogTerm:  n.log[next-1].Term,
+		Entries:      append([]Entry(nil), n.log[next:end]...),
+		LeaderCommit: n.commitIndex,
+	}
+	n.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.config.ElectionTimeout/2)
+	resp, err := n.transport.AppendEntries(ctx, server, req)
+	cancel()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.inflight[server.ID] = false
+	if err != nil {
+		return
+	}
+	if resp.Term > n.currentTerm {
+		n.stepDown(resp.Term)
+		return
+	}
+	if n.role != leader || n.currentTerm != term {
+		return
+	}
+
+	if resp.Success {
+		match := req.PrevLogIndex + uint64(len(req.Entries))
+		if match > n.matchIndex[server.ID] {
+			n.matchIndex[server.ID] = match
+		}
+		n.nextIndex[server.ID] = match + 1
+		n.advanceCommit()
+	} else if resp.ConflictIndex > 0 {
+		n.nextIndex[server.ID] = resp.ConflictIndex
+	} else if next > 1 {
+		n.nextIndex[server.ID] = next - 1
+	}
+
+	// follower仍落后时立即继续发送
+	if n.nextIndex[server.ID] <= n.lastIndex() && n.isVoter(server.ID) {
+		n.inflight[server.ID] = true
+		go n.replicateTo(server)
+	}
+}
+
+// advanceCommit 推进leader的commitIndex，只直接提交当前任期的日志
+func (n *Node) advanceCommit() {
+	for index := n.lastIndex(); index > n.commitIndex; index-- {
+		if n.log[index].Term != n.currentTerm {
+			break
+		}
+
+		count := 0
+		for _, server := range n.servers {
+			if server.ID == n.config.ID || n.matchIndex[server.ID] >= index {
+				count++
+			}
+		}
+		if count >= n.quorum() {
+			n.commitIndex = index
+			n.notifyCommit()
+			break
+		}
+	}
+
+	// 已提交的配置中不再包含自己时退位
+	if n.role == leader && n.configIndex <= n.commitIndex && !n.isVoter(n.config.ID) {
+		n.stepDown(n.currentTerm)
+	}
+}
+
+func (n *Node) applier() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case <-n.applyCh:
+		}
+
+		for {
+			n.mutex.Lock()
+			if n.lastApplied >= n.commitIndex {
+				n.mutex.Unlock()
+				break
+			}
+			n.lastApplied++
+			entry := n.log[n.lastApplied]
+			n.mutex.Unlock()
+
+			n.apply(entry)
+		}
+	}
+}
+
+// appendLocal 由leader追加一条当前任期的日志
+func (n *Node) appendLocal(entryType EntryType, data []byte) (Entry, error) {
+	entry := Entry{
+		Index: n.lastIndex() + 1,
+		Term:  n.currentTerm,
+		Type:  entryType,
+		Data:  data,
+	}
+	if err := n.appendStored([]Entry{entry}); err != nil {
+		return Entry{}, err
+	}
+
+	n.matchIndex[n.config.ID] = entry.Index
+	n.advanceCommit()
+	n.broadcastAppend()
+	return entry, nil
+}
+
+// appendStored 持久化并追加日志，索引必须紧接在现有日志之后
+func (n *Node) appendStored(entries []Entry) error {
+	if err := n.store.Append(entries); err != nil {
+		return err
+	}
+	n.log = append(n.log, entries...)
+
+	for _, entry := range entries {
+		if entry.Type == EntryConfig {
+			n.reloadConfig()
+			break
+		}
+	}
+	return nil
+}
+
+func (n *Node) truncateFrom(index uint64) error {
+	if err := n.store.TruncateFrom(index); err != nil {
+		return err
+	}
+	n.log = n.log[:index]
+	if n.configIndex >= index {
+		n.reloadConfig()
+	}
+	return nil
+}
+
+// reloadConfig 从日志中找到最新的成员配置
+func (n *Node) reloadConfig() {
+	n.servers = nil
+	n.configIndex = 0
+	for i := len(n.log) - 1; i > 0; i-- {
+		if n.log[i].Type != EntryConfig {
+			continue
+		}
+		var servers []Server
+		if err := json.Unmarshal(n.log[i].Data, &servers); err != nil {
+			log.Printf("raft: invalid configuration at index %d: %v", i, err)
+			continue
+		}
+		n.servers = servers
+		n.configIndex = uint64(i)
+		break
+	}
+
+	for _, server := range n.servers {
+		if _, ok := n.nextIndex[server.ID]; !ok {
+			n.nextIndex[server.ID] = n.lastIndex() + 1
+		}
+	}
+}
+
+func (n *Node) notifyCommit() {
+	close(n.commitNotify)
+	n.commitNotify = make(chan struct{})
+	select {
+	case n.applyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (n *Node) persistState() error {
+	return n.store.SetState(n.currentTerm, n.votedFor)
+}
+
+func (n *Node) resetElectionTimer() {
+	n.lastContact = time.Now()
+	n.electionTimeout = n.config.ElectionTimeout + time.Duration(rand.Int63n(int64(n.config.ElectionTimeout)))
+}
+
+func (n *Node) isVoter(id string) bool {
+	for _, server := range n.servers {
+		if server.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Node) quorum() int {
+	return len(n.servers)/2 + 1
+}
+
+func (n *Node) lastIndex() uint64 {
+	return uint64(len(n.log) - 1)
+}
+
+func (n *Node) lastTerm() uint64 {
+	return n.log[len(n.log)-1].Term
+}
@@ -0,0 +1,391 @@
+package raft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testElectionTimeout = 50 * time.Millisecond
+
+var errUnreachable = errors.New("unreachable")
+
+// network 进程内的模拟网络，可以把节点隔离出去
+type network struct {
+	mutex    sync.Mutex
+	nodes    map[string]*Node
+	isolated map[string]bool
+}
+
+func newNetwork() *network {
+	return &network{
+		nodes:    make(map[string]*Node),
+		isolated: make(map[string]bool),
+	}
+}
+
+func (nw *network) target(from, to string) (*Node, error) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+	if nw.isolated[from] || nw.isolated[to] {
+		return nil, errUnreachable
+	}
+	node, ok := nw.nodes[to]
+	if !ok {
+		return nil, errUnreachable
+	}
+	return node, nil
+}
+
+func (nw *network) isolate(id string, isolated bool) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+	nw.isolated[id] = isolated
+}
+
+type memoryTransport struct {
+	network *network
+	from    string
+}
+
+func (tr *memoryTransport) RequestVote(ctx context.Context, target Server, req *VoteRequest) (*VoteResponse, error) {
+	node, err := tr.network.target(tr.from, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	return node.HandleRequestVote(req), nil
+}
+
+func (tr *memoryTransport) AppendEntries(ctx context.Context, target Server, req *AppendRequest) (*AppendResponse, error) {
+	node, err := tr.network.target(tr.from, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	return node.HandleAppendEntries(req), nil
+}
+
+func testServers(ids ...string) []Server {
+	servers := make([]Server, 0, len(ids))
+	for _, id := range ids {
+		servers = append(servers, Server{ID: id, Address: id + ":0"})
+	}
+	return servers
+}
+
+// newTestNode 在临时目录中创建节点，尚未启动
+func newTestNode(t *testing.T, nw *network, id string) *Node {
+	t.Helper()
+
+	store, err := OpenStore(filepath.Join(t.TempDir(), "raft.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	config := Config{
+		ID:                id,
+		ElectionTimeout:   testElectionTimeout,
+		HeartbeatInterval: testElectionTimeout / 5,
+	}
+	node, err := NewNode(config, store, &memoryTransport{network: nw, from: id}, func(Entry) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nw.mutex.Lock()
+	nw.nodes[id] = node
+	nw.mutex.Unlock()
+	return node
+}
+
+// startCluster 以相同的初始配置启动n个节点
+func startCluster(t *testing.T, nw *network, n int) []*Node {
+	t.Helper()
+
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("n%d", i+1)
+	}
+	nodes := make([]*Node, n)
+	for i, id := range ids {
+		nodes[i] = newTestNode(t, nw, id)
+		if err := nodes[i].Bootstrap(testServers(ids...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, node := range nodes {
+		node.Start()
+		t.Cleanup(node.Stop)
+	}
+	return nodes
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// waitLeader 等待nodes中恰好一个节点成为leader并返回它
+func waitLeader(t *testing.T, nodes []*Node) *Node {
+	t.Helper()
+	var found *Node
+	waitFor(t, "a leader", func() bool {
+		found = nil
+		for _, node := range nodes {
+			if node.IsLeader() {
+				if found != nil {
+					return false
+				}
+				found = node
+			}
+		}
+		return found != nil
+	})
+	return found
+}
+
+// waitReplicated 等待leader提交全部日志并复制到nodes中的每个节点
+func waitReplicated(t *testing.T, leader *Node, nodes []*Node) {
+	t.Helper()
+	waitFor(t, "the log to replicate", func() bool {
+		leader.mutex.Lock()
+		last, committed := leader.lastIndex(), leader.commitIndex == leader.lastIndex()
+		leader.mutex.Unlock()
+		if !committed {
+			return false
+		}
+		for _, node := range nodes {
+			node.mutex.Lock()
+			caughtUp := node.commitIndex >= last
+			node.mutex.Unlock()
+			if !caughtUp {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func except(nodes []*Node, excluded *Node) []*Node {
+	var rest []*Node
+	for _, node := range nodes {
+		if node != excluded {
+			rest = append(rest, node)
+		}
+	}
+	return rest
+}
+
+func TestOneLeaderPerTerm(t *testing.T) {
+	nw := newNetwork()
+	nodes := startCluster(t, nw, 5)
+
+	var mutex sync.Mutex
+	leaders := make(map[uint64]map[string]bool)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			for _, node := range nodes {
+				node.mutex.Lock()
+				isLeader, term := node.role == leader, node.currentTerm
+				node.mutex.Unlock()
+				if isLeader {
+					mutex.Lock()
+					if leaders[term] == nil {
+						leaders[term] = make(map[string]bool)
+					}
+					leaders[term][node.config.ID] = true
+					mutex.Unlock()
+				}
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	// 反复隔离当前leader，触发多轮选举
+	for i := 0; i < 3; i++ {
+		current := waitLeader(t, nodes)
+		nw.isolate(current.config.ID, true)
+		waitLeader(t, except(nodes, current))
+		nw.isolate(current.config.ID, false)
+	}
+	waitLeader(t, nodes)
+	close(stop)
+	wg.Wait()
+
+	if len(leaders) < 3 {
+		t.Errorf("observed leaders in %d terms, want at least 3", len(leaders))
+	}
+	for term, ids := range leaders {
+		if len(ids) > 1 {
+			t.Errorf("term %d has %d leaders: %v", term, len(ids), ids)
+		}
+	}
+}
+
+func TestFollowerTruncatesConflictingEntries(t *testing.T) {
+	nw := newNetwork()
+	node := newTestNode(t, nw, "n1")
+	if err := node.Bootstrap(testServers("n1", "n2", "n3")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 任期2的leader复制了两条未提交的日志
+	resp := node.HandleAppendEntries(&AppendRequest{
+		Term:         2,
+		LeaderID:     "n2",
+		PrevLogIndex: 1,
+		PrevLogTerm:  1,
+		Entries:      []Entry{{Index: 2, Term: 2}, {Index: 3, Term: 2}},
+	})
+	if !resp.Success {
+		t.Fatalf("append from term 2 = %+v", resp)
+	}
+
+	// 任期3的leader从索引3开始发送，前一条日志的任期不匹配
+	resp = node.HandleAppendEntries(&AppendRequest{
+		Term:         3,
+		LeaderID:     "n3",
+		PrevLogIndex: 3,
+		PrevLogTerm:  3,
+		Entries:      []Entry{{Index: 4, Term: 3}},
+	})
+	if resp.Success || resp.ConflictIndex != 2 {
+		t.Fatalf("mismatched append = %+v, want failure with conflict index 2", resp)
+	}
+
+	resp = node.HandleAppendEntries(&AppendRequest{
+		Term:         3,
+		LeaderID:     "n3",
+		PrevLogIndex: 1,
+		PrevLogTerm:  1,
+		Entries:      []Entry{{Index: 2, Term: 3}},
+		LeaderCommit: 2,
+	})
+	if !resp.Success {
+		t.Fatalf("append from term 3 = %+v", resp)
+	}
+	if last := node.lastIndex(); last != 2 || node.log[2].Term != 3 || node.commitIndex != 2 {
+		t.Errorf("log ends at %d with term %d, commit %d, want index 2 of term 3 committed", last, node.log[last].Term, node.commitIndex)
+	}
+
+	// 截断也写入了持久化的日志
+	entries, err := node.store.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Term != 3 {
+		t.Errorf("stored entries = %+v, want indexes 1-2 with index 2 from term 3", entries)
+	}
+}
+
+func TestPartitionedLeaderStepsDown(t *testing.T) {
+	nw := newNetwork()
+	nodes := startCluster(t, nw, 3)
+	old := waitLeader(t, nodes)
+	oldTerm := old.Term()
+	waitReplicated(t, old, nodes)
+
+	// 隔离后的leader追加的配置无法提交
+	nw.isolate(old.config.ID, true)
+	result := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		result <- old.AddServer(ctx, Server{ID: "ghost", Address: "ghost:0"})
+	}()
+	waitFor(t, "the uncommitted entry", func() bool {
+		servers, _ := old.Configuration()
+		return len(servers) == 4
+	})
+	_, uncommitted := old.Configuration()
+
+	rest := except(nodes, old)
+	current := waitLeader(t, rest)
+	waitFor(t, "the new leader to commit", func() bool {
+		current.mutex.Lock()
+		defer current.mutex.Unlock()
+		return current.commitIndex >= uncommitted && current.log[uncommitted].Term > oldTerm
+	})
+
+	nw.isolate(old.config.ID, false)
+	waitFor(t, "the old leader to step down and catch up", func() bool {
+		old.mutex.Lock()
+		defer old.mutex.Unlock()
+		return old.role == follower && old.currentTerm > oldTerm && old.commitIndex >= uncommitted
+	})
+
+	if err := <-result; err != ErrLeadershipLost {
+		t.Errorf("AddServer on the old leader = %v, want ErrLeadershipLost", err)
+	}
+	if servers, _ := old.Configuration(); len(servers) != 3 {
+		t.Errorf("old leader configuration = %+v, want the uncommitted member discarded", servers)
+	}
+	old.mutex.Lock()
+	term := old.log[uncommitted].Term
+	old.mutex.Unlock()
+	if term == oldTerm {
+		t.Errorf("entry %d still from term %d, want it replaced", uncommitted, oldTerm)
+	}
+}
+
+func TestCommittedMembershipSurvivesLeaderChange(t *testing.T) {
+	nw := newNetwork()
+	nodes := startCluster(t, nw, 3)
+	joining := newTestNode(t, nw, "n4")
+	joining.Start()
+	t.Cleanup(joining.Stop)
+
+	first := waitLeader(t, nodes)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := first.AddServer(ctx, Server{ID: "n4", Address: "n4:0"}); err != nil {
+		t.Fatalf("AddServer: %v", err)
+	}
+
+	// 已提交的配置在新leader上仍然有效
+	nw.isolate(first.config.ID, true)
+	members := append(except(nodes, first), joining)
+	second := waitLeader(t, members)
+	if second.Term() <= first.Term() {
+		t.Fatalf("new leader term %d, want above %d", second.Term(), first.Term())
+	}
+	waitReplicated(t, second, members)
+	for _, node := range members {
+		servers, _ := node.Configuration()
+		if len(servers) != 4 {
+			t.Errorf("%s configuration = %+v, want 4 members", node.config.ID, servers)
+		}
+	}
+
+	// 新配置下3个可达节点构成多数，可以继续变更成员
+	if err := second.RemoveServer(ctx, first.config.ID); err != nil {
+		t.Fatalf("RemoveServer: %v", err)
+	}
+	for _, node := range members {
+		node := node
+		waitFor(t, node.config.ID+" to apply the removal", func() bool {
+			servers, index := node.Configuration()
+			node.mutex.Lock()
+			defer node.mutex.Unlock()
+			return len(servers) == 3 && node.commitIndex >= index
+		})
+	}
+}
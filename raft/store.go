@@ -0,0 +1,140 @@
+package raft
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+var (
+	metaBucket = []byte("meta")
+	logBucket  = []byte("log")
+)
+
+// Store 持久化Raft的任期、投票和日志
+type Store struct {
+	db *bolt.DB
+}
+
+type persistentState struct {
+	Term     uint64 `json:"term"`
+	VotedFor string `json:"voted_for"`
+}
+
+func OpenStore(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %v", err)
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{
+		Timeout: 1 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open raft store: %v", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(metaBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(logBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create bucket: %v", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// State 返回持久化的任期和投票对象
+func (s *Store) State() (uint64, string, error) {
+	var state persistentState
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(metaBucket).Get([]byte("state"))
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, &state)
+	})
+	return state.Term, state.VotedFor, err
+}
+
+func (s *Store) SetState(term uint64, votedFor string) error {
+	data, err := json.Marshal(persistentState{Term: term, VotedFor: votedFor})
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(metaBucket).Put([]byte("state"), data)
+	})
+}
+
+// Entries 按索引顺序返回全部日志
+func (s *Store) Entries() ([]Entry, error) {
+	var entries []Entry
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(logBucket).ForEach(func(k, v []byte) error {
+			var entry Entry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+			return nil
+		})
+	})
+	return entries, err
+}
+
+// Append 追加日志，已存在的索引会被覆盖
+func (s *Store) Append(entries []Entry) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(logBucket)
+		for _, entry := range entries {
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(indexKey(entry.Index), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// TruncateFrom 删除索引不小于index的所有日志
+func (s *Store) TruncateFrom(index uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(logBucket)
+
+		// 先收集再删除，边遍历边删除会让游标跳过元素
+		var keys [][]byte
+		c := bucket.Cursor()
+		for k, _ := c.Seek(indexKey(index)); k != nil; k, _ = c.Next() {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func indexKey(index uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, index)
+	return key
+}
@@ -9,14 +9,14 @@ NODE1_PID=$!
 
 sleep 2
 
-# 启动节点2
-./rushkv -id=node2 -addr=localhost -port=8081 -data=./data/node2 &
+# 启动节点2，通过节点1加入集群
+./rushkv -id=node2 -addr=localhost -port=8081 -data=./data/node2 -join=localhost:8080 &
 NODE2_PID=$!
 
 sleep 2
 
 # 启动节点3
-./rushkv -id=node3 -addr=localhost -port=8082 -data=./data/node3 &
+./rushkv -id=node3 -addr=localhost -port=8082 -data=./data/node3 -join=localhost:8080,localhost:8081 &
 NODE3_PID=$!
 
 echo "Cluster started with PIDs: $NODE1_PID, $NODE2_PID, $NODE3_PID"
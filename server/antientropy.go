@@ -0,0 +1,415 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "sync"
+    "time"
+    
+    "rushkv/hash"
+    "rushkv/merkle"
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+const (
+    // Merkle树的深度，每个区间分为2^merkleDepth个叶子
+    merkleDepth = 6
+    // 对端请求允许的最大深度
+    maxMerkleDepth = 16
+)
+
+// antiEntropy 定期与其他副本比较Merkle树，拉取不一致区间内较新的记录
+type antiEntropy struct {
+    limiter *rateLimiter
+    
+    mutex           sync.Mutex
+    active          bool
+    lastFullSync    time.Time
+    lastRound       time.Time
+    rangesCompared  int
+    rangesDiffering int
+    keysRepaired    int64
+    lastErr         string
+}
+
+func newAntiEntropy(rate int) *antiEntropy {
+    return &antiEntropy{
+        limiter: &rateLimiter{rate: rate},
+    }
+}
+
+func (s *RushKVServer) antiEntropyLoop() {
+    if s.config.AntiEntropyInterval <= 0 {
+        return
+    }
+    
+    ticker := time.NewTicker(s.config.AntiEntropyInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-s.stopCh:
+            return
+        case <-ticker.C:
+            s.antiEntropyRound()
+        }
+    }
+}
+
+// antiEntropyRound 与每个共享区间的副本各比较一次，全部成功时记为一次完整同步
+func (s *RushKVServer) antiEntropyRound() {
+    ae := s.antiEntropy
+    ae.mutex.Lock()
+    ae.active = true
+    ae.lastRound = time.Now()
+    ae.rangesCompared = 0
+    ae.rangesDiffering = 0
+    ae.mutex.Unlock()
+    
+    err := s.syncReplicas()
+    
+    ae.mutex.Lock()
+    ae.active = false
+    if err != nil {
+        ae.lastErr = err.Error()
+        log.Printf("Anti-entropy round failed: %v", err)
+    } else {
+        ae.lastErr = ""
+        ae.lastFullSync = time.Now()
+    }
+    ae.mutex.Unlock()
+}
+
+func (s *RushKVServer) syncReplicas() error {
+    s.mutex.RLock()
+    ring := s.hash.Clone()
+    s.mutex.RUnlock()
+    
+    ranges := ring.Ranges(s.config.ReplicationFactor)
+    
+    // 按对端分组本节点参与的区间
+    shared := make(map[string][]int)
+    for i, rr := range ranges {
+        if !containsNode(rr.Replicas, s.nodeID) {
+            continue
+        }
+        for _, nodeID := range rr.Replicas {
+            if nodeID != s.nodeID {
+                shared[nodeID] = append(shared[nodeID], i)
+            }
+        }
+    }
+    if len(shared) == 0 {
+        return nil
+    }
+    
+    trees, err := s.buildTrees(ranges, ownedBy(ranges, s.nodeID))
+    if err != nil {
+        return err
+    }
+    
+    var lastErr error
+    for nodeID, indexes := range shared {
+        if err := s.syncWith(nodeID, ranges, indexes, trees); err != nil {
+            lastErr = fmt.Errorf("sync with node %s: %v", nodeID, err)
+        }
+    }
+    return lastErr
+}
+
+// syncWith 比较与nodeID共享的区间，从对端拉取叶子哈希不同的记录
+func (s *RushKVServer) syncWith(nodeID string, ranges []hash.ReplicaRange, indexes []int, trees map[int]*merkle.Tree) error {
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return err
+    }
+    
+    // 对端同样限速扫描，一轮同步应在下一轮开始前完成
+    ctx, cancel := context.WithTimeout(context.Background(), s.config.AntiEntropyInterval)
+    defer cancel()
+    
+    req := &proto.MerkleTreesRequest{Depth: merkleDepth}
+    for _, i := range indexes {
+        req.Ranges = append(req.Ranges, toKeyRange(ranges[i].Range))
+    }
+    resp, err := peer.MerkleTrees(ctx, req)
+    if err != nil {
+        return err
+    }
+    if !resp.Success {
+        return fmt.Errorf("%s", resp.Error)
+    }
+    if len(resp.Trees) != len(indexes) {
+        return fmt.Errorf("expected %d trees, got %d", len(indexes), len(resp.Trees))
+    }
+    
+    ae := s.antiEntropy
+    for j, i := range indexes {
+        remote, err := merkle.FromNodes(ranges[i].Range, merkleDepth, resp.Trees[j].Nodes)
+        if err != nil {
+            return err
+        }
+        leaves := trees[i].Diff(remote)
+        
+        ae.mutex.Lock()
+        ae.rangesCompared++
+        if len(leaves) > 0 {
+            ae.rangesDiffering++
+        }
+        ae.mutex.Unlock()
+        
+        if len(leaves) == 0 {
+            continue
+        }
+        if err := s.pullLeaves(ctx, peer, ranges[i].Range, leaves); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// pullLeaves 拉取对端指定叶子内的记录，按版本合并到本地
+func (s *RushKVServer) pullLeaves(ctx context.Context, peer proto.RushKVClient, r hash.Range, leaves []int) error {
+    req := &proto.RangePairsRequest{
+        Range: toKeyRange(r),
+        Depth: merkleDepth,
+    }
+    for _, leaf := range leaves {
+        req.Leaves = append(req.Leaves, int32(leaf))
+    }
+    
+    resp, err := peer.RangePairs(ctx, req)
+    if err != nil {
+        return err
+    }
+    if !resp.Success {
+        return fmt.Errorf("%s", resp.Error)
+    }
+    
+    if !s.antiEntropy.limiter.wait(len(resp.Pairs), s.stopCh) {
+        return fmt.Errorf("server stopped")
+    }
+    
+    repaired := 0
+    for _, pair := range resp.Pairs {
+        applied, err := s.applyPair(fromProtoPair(pair))
+        if err != nil {
+            return err
+        }
+        if applied {
+            repaired++
+        }
+    }
+    
+    ae := s.antiEntropy
+    ae.mutex.Lock()
+    ae.keysRepaired += int64(repaired)
+    ae.mutex.Unlock()
+    return nil
+}
+
+// buildTrees 扫描一遍本地数据，为指定的区间构建Merkle树
+func (s *RushKVServer) buildTrees(ranges []hash.ReplicaRange, indexes []int) (map[int]*merkle.Tree, error) {
+    trees := make(map[int]*merkle.Tree, len(indexes))
+    for _, i := range indexes {
+        trees[i] = merkle.New(ranges[i].Range, merkleDepth)
+    }
+    
+    err := s.scanPairs(s.stopCh, func(h int, pair *storage.KVPair) {
+        if tree, ok := trees[hash.RangeIndex(ranges, h)]; ok {
+            tree.Add(h, pair.Key, pair.Version, pair.Deleted)
+        }
+    })
+    return trees, err
+}
+
+// scanPairs 按限速分批遍历本地所有记录，done关闭时中止。
+// 超过宽限期的墓碑和过期记录由各副本自行清理，不参与比较，以免被清理后又从其他副本拉回。
+// 宽限期内的过期记录仍参与比较，防止其他副本上更旧的值被同步回来
+func (s *RushKVServer) scanPairs(done <-chan struct{}, fn func(h int, pair *storage.KVPair)) error {
+    after := ""
+    for {
+        pairs, err := s.storage.ListPairs(after, rebalanceBatchSize)
+        if err != nil {
+            return err
+        }
+        if len(pairs) == 0 {
+            return nil
+        }
+        if !s.antiEntropy.limiter.wait(len(pairs), done) {
+            return fmt.Errorf("scan aborted")
+        }
+        
+        now := time.Now()
+        for _, pair := range pairs {
+            if s.collectable(pair, now) {
+                continue
+            }
+            fn(s.hash.Hash(pair.Key), pair)
+        }
+        after = pairs[len(pairs)-1].Key
+    }
+}
+
+func (s *RushKVServer) MerkleTrees(ctx context.Context, req *proto.MerkleTreesRequest) (*proto.MerkleTreesResponse, error) {
+    if req.Depth < 0 || req.Depth > maxMerkleDepth {
+        return &proto.MerkleTreesResponse{
+            Success: false,
+            Error:   fmt.Sprintf("invalid tree depth %d", req.Depth),
+        }, nil
+    }
+    
+    ranges := make([]hash.Range, 0, len(req.Ranges))
+    trees := make([]*merkle.Tree, 0, len(req.Ranges))
+    for _, kr := range req.Ranges {
+        r := fromKeyRange(kr)
+        ranges = append(ranges, r)
+        trees = append(trees, merkle.New(r, int(req.Depth)))
+    }
+    
+    err := s.scanPairs(ctx.Done(), func(h int, pair *storage.KVPair) {
+        for i, r := range ranges {
+            if r.Contains(h) {
+                trees[i].Add(h, pair.Key, pair.Version, pair.Deleted)
+                return
+            }
+        }
+    })
+    if err != nil {
+        return &proto.MerkleTreesResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    
+    resp := &proto.MerkleTreesResponse{Success: true}
+    for _, tree := range trees {
+        resp.Trees = append(resp.Trees, &proto.MerkleTree{Nodes: tree.Nodes()})
+    }
+    return resp, nil
+}
+
+func (s *RushKVServer) RangePairs(ctx context.Context, req *proto.RangePairsRequest) (*proto.RangePairsResponse, error) {
+    if req.Range == nil {
+        return &proto.RangePairsResponse{
+            Success: false,
+            Error:   "missing range",
+        }, nil
+    }
+    if req.Depth < 0 || req.Depth > maxMerkleDepth {
+        return &proto.RangePairsResponse{
+            Success: false,
+            Error:   fmt.Sprintf("invalid tree depth %d", req.Depth),
+        }, nil
+    }
+    
+    r := fromKeyRange(req.Range)
+    tree := merkle.New(r, int(req.Depth))
+    leaves := make(map[int]bool, len(req.Leaves))
+    for _, leaf := range req.Leaves {
+        leaves[int(leaf)] = true
+    }
+    
+    var pairs []*proto.KVPair
+    err := s.scanPairs(ctx.Done(), func(h int, pair *storage.KVPair) {
+        if r.Contains(h) && leaves[tree.Leaf(h)] {
+            pairs = append(pairs, toProtoPair(pair))
+        }
+    })
+    if err != nil {
+        return &proto.RangePairsResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    
+    return &proto.RangePairsResponse{
+        Success: true,
+        Pairs:   pairs,
+    }, nil
+}
+
+// antiEntropyStatus 返回本节点的反熵同步进度
+func (s *RushKVServer) antiEntropyStatus() *proto.AntiEntropyStatus {
+    ae := s.antiEntropy
+    ae.mutex.Lock()
+    defer ae.mutex.Unlock()
+    
+    status := &proto.AntiEntropyStatus{
+        Active:          ae.active,
+        RangesCompared:  int32(ae.rangesCompared),
+        RangesDiffering: int32(ae.rangesDiffering),
+        KeysRepaired:    ae.keysRepaired,
+        LastError:       ae.lastErr,
+    }
+    if !ae.lastFullSync.IsZero() {
+        status.LastFullSync = ae.lastFullSync.Unix()
+    }
+    if !ae.lastRound.IsZero() {
+        status.LastRound = ae.lastRound.Unix()
+    }
+    return status
+}
+
+// ownedBy 返回nodeID负责的区间下标
+func ownedBy(ranges []hash.ReplicaRange, nodeID string) []int {
+    var indexes []int
+    for i, rr := range ranges {
+        if containsNode(rr.Replicas, nodeID) {
+            indexes = append(indexes, i)
+        }
+    }
+    return indexes
+}
+
+func toKeyRange(r hash.Range) *proto.KeyRange {
+    return &proto.KeyRange{
+        Start: uint32(r.Start),
+        End:   uint32(r.End),
+    }
+}
+
+func fromKeyRange(kr *proto.KeyRange) hash.Range {
+    return hash.Range{
+        Start: int(kr.Start),
+        End:   int(kr.End),
+    }
+}
+
+// rateLimiter 按每秒处理的记录数限速，rate不大于0时不限速
+type rateLimiter struct {
+    rate int
+    
+    mutex sync.Mutex
+    next  time.Time
+}
+
+// wait 为n条记录预留配额并等待，stop关闭时返回false
+func (l *rateLimiter) wait(n int, stop <-chan struct{}) bool {
+    if l.rate <= 0 {
+        return true
+    }
+    
+    l.mutex.Lock()
+    now := time.Now()
+    if l.next.Before(now) {
+        l.next = now
+    }
+    delay := l.next.Sub(now)
+    l.next = l.next.Add(time.Duration(n) * time.Second / time.Duration(l.rate))
+    l.mutex.Unlock()
+    
+    if delay <= 0 {
+        return true
+    }
+    timer := time.NewTimer(delay)
+    defer timer.Stop()
+    select {
+    case <-stop:
+        return false
+    case <-timer.C:
+        return true
+    }
+}
\ No newline at end of file
@@ -0,0 +1,54 @@
+package server
+
+import (
+    "testing"
+    "time"
+    
+    "rushkv/storage"
+)
+
+func TestAntiEntropyRepairsDivergentReplica(t *testing.T) {
+    servers := newTestCluster(t, 2, func(config *Config) {
+        config.ReplicationFactor = 2
+        config.AntiEntropyRate = 0
+    })
+    node1, node2 := servers[0], servers[1]
+    
+    now := time.Now()
+    stale := &storage.KVPair{Key: "k", Value: []byte("old"), Version: 1, Timestamp: now}
+    fresh := &storage.KVPair{Key: "k", Value: []byte("new"), Version: 2, Timestamp: now}
+    missing := &storage.KVPair{Key: "only-on-node2", Value: []byte("v"), Version: 3, Timestamp: now}
+    same := &storage.KVPair{Key: "same", Value: []byte("v"), Version: 4, Timestamp: now}
+    if _, err := node1.applyPairs([]*storage.KVPair{stale, same}); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := node2.applyPairs([]*storage.KVPair{fresh, missing, same}); err != nil {
+        t.Fatal(err)
+    }
+    
+    node1.antiEntropyRound()
+    
+    status := node1.antiEntropyStatus()
+    if status.LastError != "" {
+        t.Fatalf("anti-entropy round failed: %s", status.LastError)
+    }
+    if status.RangesDiffering == 0 || status.KeysRepaired != 2 {
+        t.Errorf("status = %+v, want differing ranges and 2 repaired keys", status)
+    }
+    for _, want := range []*storage.KVPair{fresh, missing, same} {
+        pair, err := node1.storage.GetPair(want.Key)
+        if err != nil || pair == nil || pair.Version != want.Version || string(pair.Value) != string(want.Value) {
+            t.Errorf("node1 %s = %+v, %v, want version %d", want.Key, pair, err, want.Version)
+        }
+    }
+    
+    // 同步后再比较没有不一致的区间，较旧的版本也不会被推回node2
+    node1.antiEntropyRound()
+    node2.antiEntropyRound()
+    if status := node2.antiEntropyStatus(); status.RangesDiffering != 0 || status.KeysRepaired != 0 {
+        t.Errorf("node2 status after sync = %+v, want nothing to repair", status)
+    }
+    if pair, _ := node2.storage.GetPair("k"); pair == nil || pair.Version != 2 {
+        t.Errorf("node2 k = %+v, want version 2", pair)
+    }
+}
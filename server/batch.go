@@ -0,0 +1,196 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+    
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+func (s *RushKVServer) Batch(ctx context.Context, req *proto.BatchRequest) (*proto.BatchResponse, error) {
+    if err := validateBatch(req); err != nil {
+        return &proto.BatchResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    
+    return &proto.BatchResponse{
+        Success: true,
+        Results: s.batch(ctx, req),
+    }, nil
+}
+
+// batch 按协调节点对操作分组执行。转发因本节点集群版本落后被拒绝的操作，在追赶到新版本后按新环重新分组执行一次
+func (s *RushKVServer) batch(ctx context.Context, req *proto.BatchRequest) []*proto.BatchOpResult {
+    results := make([]*proto.BatchOpResult, len(req.Ops))
+    pending := make([]int, len(req.Ops))
+    for i := range pending {
+        pending[i] = i
+    }
+    s.withRingRetry(func() bool {
+        pending = s.batchRound(ctx, req, pending, results)
+        return len(pending) == 0
+    })
+    return results
+}
+
+// batchRound 按协调节点对pending中的操作分组，本节点负责的组在本地写入，其余组转发给对应节点，各组并发执行。
+// 返回因集群版本落后被拒绝的操作
+func (s *RushKVServer) batchRound(ctx context.Context, req *proto.BatchRequest, pending []int, results []*proto.BatchOpResult) []int {
+    groups := make(map[string][]int)
+    for _, i := range pending {
+        nodeID := s.casCoordinator(s.replicasFor(req.Ops[i].Key))
+        groups[nodeID] = append(groups[nodeID], i)
+    }
+    
+    var mutex sync.Mutex
+    var rejected []int
+    var wg sync.WaitGroup
+    for nodeID, indexes := range groups {
+        ops := make([]*proto.TxnOp, 0, len(indexes))
+        for _, i := range indexes {
+            ops = append(ops, req.Ops[i])
+        }
+        
+        wg.Add(1)
+        go func(nodeID string, indexes []int, ops []*proto.TxnOp) {
+            defer wg.Done()
+            var groupResults []*proto.BatchOpResult
+            stale := false
+            switch {
+            case nodeID == "":
+                groupResults = failedBatch(ops, "no available nodes")
+            case nodeID == s.nodeID || req.Hops >= maxForwardHops:
+                // 转发次数用尽时由本节点协调，写入仍然发往key的副本节点
+                groupResults = s.localBatch(ctx, ops, req.Consistency)
+            default:
+                groupResults, stale = s.forwardBatch(ctx, nodeID, ops, req)
+            }
+            for j, i := range indexes {
+                results[i] = groupResults[j]
+            }
+            if stale {
+                mutex.Lock()
+                rejected = append(rejected, indexes...)
+                mutex.Unlock()
+            }
+        }(nodeID, indexes, ops)
+    }
+    wg.Wait()
+    
+    return rejected
+}
+
+// localBatch 生成各操作的记录，按副本节点分组后每个副本在一个存储事务中写入。
+// 删除不存在的key不写墓碑，该操作返回错误
+func (s *RushKVServer) localBatch(ctx context.Context, ops []*proto.TxnOp, level proto.ConsistencyLevel) []*proto.BatchOpResult {
+    var deleted []string
+    for _, op := range ops {
+        if op.Type == proto.TxnOpType_DELETE {
+            deleted = append(deleted, op.Key)
+        }
+    }
+    current := make(map[string]*storage.KVPair)
+    if len(deleted) > 0 {
+        var err error
+        if current, err = s.readCurrent(ctx, deleted, level); err != nil {
+            return failedBatch(ops, err.Error())
+        }
+    }
+    
+    now := time.Now()
+    results := make([]*proto.BatchOpResult, len(ops))
+    var pairs []*storage.KVPair
+    var written []int
+    for i, op := range ops {
+        results[i] = &proto.BatchOpResult{Key: op.Key}
+        
+        var pair *storage.KVPair
+        if op.Type == proto.TxnOpType_PUT {
+            pair = s.newPair(op.Key, op.Value)
+            if op.TtlSeconds > 0 {
+                pair.ExpiresAt = pair.Timestamp.Add(time.Duration(op.TtlSeconds) * time.Second)
+            }
+        } else {
+            if existing := current[op.Key]; existing == nil || existing.Deleted || existing.Expired(now) {
+                results[i].Error = "key not found"
+                continue
+            }
+            pair = s.newTombstone(op.Key)
+        }
+        pairs = append(pairs, pair)
+        written = append(written, i)
+    }
+    if len(pairs) == 0 {
+        return results
+    }
+    
+    err := s.replicatePairs(ctx, pairs, level)
+    for j, i := range written {
+        if err != nil {
+            results[i].Error = err.Error()
+            continue
+        }
+        results[i].Success = true
+        results[i].Version = pairs[j].Version
+    }
+    return results
+}
+
+// forwardBatch 将一组操作转发给负责它们的协调节点，对方因本节点集群版本落后拒绝时stale为true
+func (s *RushKVServer) forwardBatch(ctx context.Context, nodeID string, ops []*proto.TxnOp, req *proto.BatchRequest) ([]*proto.BatchOpResult, bool) {
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return failedBatch(ops, err.Error()), false
+    }
+    resp, err := peer.Batch(ctx, &proto.BatchRequest{
+        Ops:         ops,
+        Hops:        req.Hops + 1,
+        Consistency: req.Consistency,
+    })
+    if err != nil {
+        return failedBatch(ops, fmt.Sprintf("failed to forward to node %s: %v", nodeID, err)), status.Code(err) == codes.FailedPrecondition
+    }
+    if !resp.Success {
+        return failedBatch(ops, resp.Error), false
+    }
+    if len(resp.Results) != len(ops) {
+        return failedBatch(ops, fmt.Sprintf("node %s returned %d results for %d operations", nodeID, len(resp.Results), len(ops))), false
+    }
+    return resp.Results, false
+}
+
+// failedBatch 返回所有操作都以相同错误失败的结果
+func failedBatch(ops []*proto.TxnOp, err string) []*proto.BatchOpResult {
+    results := make([]*proto.BatchOpResult, 0, len(ops))
+    for _, op := range ops {
+        results = append(results, &proto.BatchOpResult{
+            Key:   op.Key,
+            Error: err,
+        })
+    }
+    return results
+}
+
+func validateBatch(req *proto.BatchRequest) error {
+    seen := make(map[string]bool, len(req.Ops))
+    for _, op := range req.Ops {
+        if op.Key == "" {
+            return fmt.Errorf("operation with empty key")
+        }
+        if op.Type != proto.TxnOpType_PUT && op.Type != proto.TxnOpType_DELETE {
+            return fmt.Errorf("unsupported operation type %s, batch only accepts PUT and DELETE", op.Type)
+        }
+        if seen[op.Key] {
+            return fmt.Errorf("duplicate key %s in batch", op.Key)
+        }
+        seen[op.Key] = true
+    }
+    return nil
+}
\ No newline at end of file
@@ -0,0 +1,155 @@
+package server
+
+import (
+    "bytes"
+    "context"
+    "fmt"
+    "time"
+    
+    protobuf "google.golang.org/protobuf/proto"
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+// 条件写入按key分段加锁的段数
+const casLockStripes = 64
+
+func (s *RushKVServer) CompareAndSwap(ctx context.Context, req *proto.CompareAndSwapRequest) (*proto.CompareAndSwapResponse, error) {
+    var resp *proto.CompareAndSwapResponse
+    s.withRingRetry(func() bool {
+        resp = s.compareAndSwap(ctx, req)
+        return resp.Success || resp.Conflict
+    })
+    return resp, nil
+}
+
+// compareAndSwap 条件写入由key的第一个存活副本协调，协调节点对key加锁，
+// 读取当前版本并判断条件后写入比当前版本更大的新版本
+func (s *RushKVServer) compareAndSwap(ctx context.Context, req *proto.CompareAndSwapRequest) *proto.CompareAndSwapResponse {
+    replicas := s.replicasFor(req.Key)
+    if coordinator := s.casCoordinator(replicas); coordinator != s.nodeID {
+        return s.forwardCompareAndSwap(ctx, req, coordinator)
+    }
+    
+    lock := &s.casLocks[uint32(s.hash.Hash(req.Key))%casLockStripes]
+    lock.Lock()
+    defer lock.Unlock()
+    
+    required := s.requiredAcks(req.Consistency, len(replicas))
+    current, _, err := s.readReplicas(ctx, req.Key, replicas, required)
+    if err != nil {
+        return &proto.CompareAndSwapResponse{
+            Success: false,
+            Error:   err.Error(),
+        }
+    }
+    if current == nil {
+        current = s.readHandoff(ctx, req.Key, replicas)
+    }
+    
+    live := current
+    if live != nil && (live.Deleted || live.Expired(time.Now())) {
+        live = nil
+    }
+    if !conditionMatches(req.Condition, req.ExpectedVersion, req.ExpectedValue, live) || (req.Delete && live == nil) {
+        resp := &proto.CompareAndSwapResponse{
+            Success:  false,
+            Error:    "condition not met",
+            Conflict: true,
+        }
+        if live != nil {
+            resp.Version = live.Version
+        }
+        return resp
+    }
+    
+    var pair *storage.KVPair
+    if req.Delete {
+        pair = s.newTombstone(req.Key)
+    } else {
+        pair = s.newPair(req.Key, req.Value)
+        if req.TtlSeconds > 0 {
+            pair.ExpiresAt = pair.Timestamp.Add(time.Duration(req.TtlSeconds) * time.Second)
+        }
+    }
+    // 新版本必须大于当前版本（包括墓碑），否则可能被旧记录覆盖
+    if current != nil && pair.Version <= current.Version {
+        pair.Version = current.Version + 1
+    }
+    
+    if err := s.replicateWrite(ctx, pair, replicas, required); err != nil {
+        return &proto.CompareAndSwapResponse{
+            Success: false,
+            Error:   err.Error(),
+        }
+    }
+    
+    return &proto.CompareAndSwapResponse{
+        Success: true,
+        Version: pair.Version,
+    }
+}
+
+// conditionMatches 判断条件是否满足，live为nil表示key不存在
+func conditionMatches(condition proto.CasCondition, expectedVersion int64, expectedValue []byte, live *storage.KVPair) bool {
+    switch condition {
+    case proto.CasCondition_ABSENT:
+        return live == nil
+    case proto.CasCondition_VALUE_EQUALS:
+        return live != nil && bytes.Equal(live.Value, expectedValue)
+    default:
+        if live == nil {
+            return expectedVersion == 0
+        }
+        return live.Version == expectedVersion
+    }
+}
+
+// casCoordinator 选出key的第一个存活副本作为条件写入的协调节点，
+// 同一key的条件写入都在同一节点上串行执行
+func (s *RushKVServer) casCoordinator(replicas []string) string {
+    for _, nodeID := range replicas {
+        if nodeID == s.nodeID || s.nodeState(nodeID) == proto.NodeState_ALIVE {
+            return nodeID
+        }
+    }
+    if len(replicas) == 0 {
+        return ""
+    }
+    return replicas[0]
+}
+
+// forwardCompareAndSwap 将条件写入转发给协调节点
+func (s *RushKVServer) forwardCompareAndSwap(ctx context.Context, req *proto.CompareAndSwapRequest, coordinator string) *proto.CompareAndSwapResponse {
+    if coordinator == "" {
+        return &proto.CompareAndSwapResponse{
+            Success: false,
+            Error:   "no available nodes",
+        }
+    }
+    if req.Hops >= maxForwardHops {
+        return &proto.CompareAndSwapResponse{
+            Success: false,
+            Error:   fmt.Sprintf("key should be coordinated by node %s", coordinator),
+        }
+    }
+    
+    forwarded := protobuf.Clone(req).(*proto.CompareAndSwapRequest)
+    forwarded.Hops++
+    
+    peer, err := s.peerClient(coordinator)
+    if err != nil {
+        return &proto.CompareAndSwapResponse{
+            Success: false,
+            Error:   err.Error(),
+        }
+    }
+    resp, err := peer.CompareAndSwap(ctx, forwarded)
+    if err != nil {
+        return &proto.CompareAndSwapResponse{
+            Success: false,
+            Error:   fmt.Sprintf("failed to forward to node %s: %v", coordinator, err),
+        }
+    }
+    return resp
+}
\ No newline at end of file
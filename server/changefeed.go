@@ -0,0 +1,118 @@
+package server
+
+import (
+    "context"
+    "log"
+    "time"
+    
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+const (
+    // 每次从变更日志读取的变更数
+    changeFeedBatchSize = 1000
+    // 没有收到通知时也定期检查变更日志，避免遗漏
+    changeFeedPollInterval = time.Second
+    // 按保留时间和数量清理变更日志的间隔
+    changeLogTrimInterval = time.Minute
+)
+
+// ChangeFeed 按序号流式返回本节点持久变更日志中的变更，读到末尾后继续等待新的变更。
+// 每个节点的变更日志独立编号，包含本节点作为副本写入的全部记录
+func (s *RushKVServer) ChangeFeed(req *proto.ChangeFeedRequest, stream proto.RushKV_ChangeFeedServer) error {
+    return s.followChangeFeed(stream.Context(), req.FromSeq, func(changes []*storage.Change) error {
+        for _, change := range changes {
+            if err := stream.Send(s.changeEvent(change)); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+}
+
+// followChangeFeed 从序号from开始分批读取本地变更日志并对每批调用fn，from为0时从保留的最早变更开始。
+// from之前的变更已被清理时返回OutOfRange
+func (s *RushKVServer) followChangeFeed(ctx context.Context, from uint64, fn func([]*storage.Change) error) error {
+    ticker := time.NewTicker(changeFeedPollInterval)
+    defer ticker.Stop()
+    
+    for {
+        // 先取得通知再读取，读取之后写入的变更一定会唤醒等待
+        changed := s.changes.changed()
+        changes, err := s.storage.Changes(from, changeFeedBatchSize)
+        if err != nil {
+            return status.Errorf(codes.Internal, "failed to read change log: %v", err)
+        }
+        
+        if len(changes) == 0 || changes[0].Seq != from {
+            first, _, err := s.storage.ChangeBounds()
+            if err != nil {
+                return status.Errorf(codes.Internal, "failed to read change log: %v", err)
+            }
+            if from == 0 {
+                from = first
+            } else if from < first {
+                return status.Errorf(codes.OutOfRange, "changes before sequence %d are no longer retained", first)
+            }
+        }
+        
+        if len(changes) > 0 {
+            if err := fn(changes); err != nil {
+                return err
+            }
+            from = changes[len(changes)-1].Seq + 1
+            if len(changes) == changeFeedBatchSize {
+                continue
+            }
+        }
+        
+        select {
+        case <-changed:
+        case <-ticker.C:
+        case <-s.stopCh:
+            return status.Error(codes.Unavailable, "server stopping")
+        case <-ctx.Done():
+            return ctx.Err()
+        }
+    }
+}
+
+// changeEvent 两种变更类型的取值相同
+func (s *RushKVServer) changeEvent(change *storage.Change) *proto.ChangeEvent {
+    return &proto.ChangeEvent{
+        Seq:       change.Seq,
+        Type:      proto.ChangeType(change.Type),
+        Pair:      toProtoPair(change.Pair),
+        Timestamp: change.Time.UnixNano(),
+        NodeId:    s.nodeID,
+    }
+}
+
+// trimChangeLog 定期按保留时间和数量清理本地变更日志
+func (s *RushKVServer) trimChangeLog() {
+    ticker := time.NewTicker(changeLogTrimInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-s.stopCh:
+            return
+        case <-ticker.C:
+        }
+        
+        var before time.Time
+        if s.config.ChangeLogRetention > 0 {
+            before = time.Now().Add(-s.config.ChangeLogRetention)
+        }
+        trimmed, err := s.storage.TrimChanges(before, s.config.ChangeLogMaxEntries)
+        if err != nil {
+            log.Printf("Failed to trim change log: %v", err)
+        }
+        if trimmed > 0 {
+            log.Printf("Trimmed %d entries from the change log", trimmed)
+        }
+    }
+}
\ No newline at end of file
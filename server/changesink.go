@@ -0,0 +1,257 @@
+package server
+
+import (
+    "bufio"
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "os"
+    "path/filepath"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+    
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "rushkv/storage"
+)
+
+const (
+    // 正在写入的导出文件，轮转后重命名为changes-<最后一个序号>.ndjson
+    changeSinkFile = "changes.ndjson"
+    // 已写入导出文件的最大序号，重启后从下一个序号继续
+    changeSinkOffsetFile = "offset"
+    // 导出失败后重试的间隔
+    changeSinkRetryInterval = 5 * time.Second
+)
+
+// changeRecord 导出文件中的一行，value按JSON的惯例以base64编码
+type changeRecord struct {
+    Seq       uint64     `json:"seq"`
+    NodeID    string     `json:"node_id"`
+    Type      string     `json:"type"`
+    Key       string     `json:"key"`
+    Value     []byte     `json:"value,omitempty"`
+    Version   int64      `json:"version"`
+    Timestamp time.Time  `json:"timestamp"`
+    ExpiresAt *time.Time `json:"expires_at,omitempty"`
+    // 变更写入本节点的时间
+    Time time.Time `json:"time"`
+}
+
+// changeSink 把变更日志追加到本地文件。每批变更落盘后才更新offset，
+// 崩溃后重启可能重复导出最后一批变更，下游应按seq去重
+type changeSink struct {
+    dir      string
+    nodeID   string
+    maxBytes int64
+    maxFiles int
+    
+    file   *os.File
+    writer *bufio.Writer
+    size   int64
+    // 已落盘的最大序号，以及当前文件中最后一个变更的序号
+    offset uint64
+    last   uint64
+}
+
+func openChangeSink(dir, nodeID string, maxBytes int64, maxFiles int) (*changeSink, error) {
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return nil, fmt.Errorf("failed to create change sink directory: %v", err)
+    }
+    
+    sink := &changeSink{
+        dir:      dir,
+        nodeID:   nodeID,
+        maxBytes: maxBytes,
+        maxFiles: maxFiles,
+    }
+    data, err := os.ReadFile(filepath.Join(dir, changeSinkOffsetFile))
+    switch {
+    case err == nil:
+        if sink.offset, err = strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err != nil {
+            return nil, fmt.Errorf("invalid change sink offset: %v", err)
+        }
+    case !os.IsNotExist(err):
+        return nil, err
+    }
+    sink.last = sink.offset
+    
+    if err := sink.open(); err != nil {
+        return nil, err
+    }
+    return sink, nil
+}
+
+func (cs *changeSink) open() error {
+    file, err := os.OpenFile(filepath.Join(cs.dir, changeSinkFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+    if err != nil {
+        return fmt.Errorf("failed to open change sink file: %v", err)
+    }
+    info, err := file.Stat()
+    if err != nil {
+        file.Close()
+        return err
+    }
+    cs.file = file
+    cs.writer = bufio.NewWriter(file)
+    cs.size = info.Size()
+    return nil
+}
+
+// write 追加一批变更，文件超过上限时先轮转，全部落盘后保存offset
+func (cs *changeSink) write(changes []*storage.Change) error {
+    for _, change := range changes {
+        line, err := json.Marshal(cs.record(change))
+        if err != nil {
+            return err
+        }
+        line = append(line, '\n')
+        
+        if cs.size > 0 && cs.size+int64(len(line)) > cs.maxBytes {
+            if err := cs.rotate(); err != nil {
+                return err
+            }
+        }
+        if _, err := cs.writer.Write(line); err != nil {
+            return err
+        }
+        cs.size += int64(len(line))
+        cs.last = change.Seq
+    }
+    
+    if err := cs.sync(); err != nil {
+        return err
+    }
+    return cs.saveOffset(cs.last)
+}
+
+func (cs *changeSink) record(change *storage.Change) *changeRecord {
+    record := &changeRecord{
+        Seq:       change.Seq,
+        NodeID:    cs.nodeID,
+        Type:      change.Type.String(),
+        Key:       change.Pair.Key,
+        Value:     change.Pair.Value,
+        Version:   change.Pair.Version,
+        Timestamp: change.Pair.Timestamp,
+        Time:      change.Time,
+    }
+    if !change.Pair.ExpiresAt.IsZero() {
+        record.ExpiresAt = &change.Pair.ExpiresAt
+    }
+    return record
+}
+
+func (cs *changeSink) sync() error {
+    if err := cs.writer.Flush(); err != nil {
+        return err
+    }
+    return cs.file.Sync()
+}
+
+// rotate 把当前文件以其中最后一个序号命名，然后删除超出数量的最旧文件
+func (cs *changeSink) rotate() error {
+    if err := cs.sync(); err != nil {
+        return err
+    }
+    if err := cs.file.Close(); err != nil {
+        return err
+    }
+    rotated := filepath.Join(cs.dir, fmt.Sprintf("changes-%020d.ndjson", cs.last))
+    if err := os.Rename(filepath.Join(cs.dir, changeSinkFile), rotated); err != nil {
+        return err
+    }
+    if err := cs.open(); err != nil {
+        return err
+    }
+    
+    // 文件名中的序号定长，按名字排序即按时间排序
+    files, err := filepath.Glob(filepath.Join(cs.dir, "changes-*.ndjson"))
+    if err != nil {
+        return err
+    }
+    sort.Strings(files)
+    for i := 0; i < len(files)-cs.maxFiles; i++ {
+        if err := os.Remove(files[i]); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// saveOffset 先写临时文件再重命名，offset文件总是完整的
+func (cs *changeSink) saveOffset(seq uint64) error {
+    if seq == cs.offset {
+        return nil
+    }
+    path := filepath.Join(cs.dir, changeSinkOffsetFile)
+    tmp := path + ".tmp"
+    if err := os.WriteFile(tmp, []byte(strconv.FormatUint(seq, 10)+"\n"), 0644); err != nil {
+        return err
+    }
+    if err := os.Rename(tmp, path); err != nil {
+        return err
+    }
+    cs.offset = seq
+    return nil
+}
+
+func (cs *changeSink) close() error {
+    if err := cs.sync(); err != nil {
+        cs.file.Close()
+        return err
+    }
+    return cs.file.Close()
+}
+
+// exportChanges 持续把本地变更日志导出到ChangeSinkDir。导出落后于变更日志的清理时
+// 跳过已被清理的变更并记录警告
+func (s *RushKVServer) exportChanges() {
+    defer close(s.changeSinkDone)
+    
+    sink, err := openChangeSink(s.config.ChangeSinkDir, s.nodeID, s.config.ChangeSinkMaxBytes, s.config.ChangeSinkMaxFiles)
+    if err != nil {
+        log.Printf("Failed to start change sink: %v", err)
+        return
+    }
+    defer func() {
+        if err := sink.close(); err != nil {
+            log.Printf("Failed to close change sink: %v", err)
+        }
+    }()
+    log.Printf("Exporting change log to %s from sequence %d", s.config.ChangeSinkDir, sink.offset+1)
+    
+    for {
+        // 第一次导出时从保留的最早变更开始
+        from := sink.offset + 1
+        if sink.offset == 0 {
+            from = 0
+        }
+        err := s.followChangeFeed(context.Background(), from, sink.write)
+        
+        select {
+        case <-s.stopCh:
+            return
+        default:
+        }
+        if status.Code(err) == codes.OutOfRange {
+            log.Printf("Warning: change sink fell behind the change log retention, skipping to the oldest retained change: %v", err)
+            var first uint64
+            if first, _, err = s.storage.ChangeBounds(); err == nil {
+                if err = sink.saveOffset(first - 1); err == nil {
+                    continue
+                }
+            }
+        }
+        log.Printf("Change sink failed: %v", err)
+        
+        select {
+        case <-s.stopCh:
+            return
+        case <-time.After(changeSinkRetryInterval):
+        }
+    }
+}
\ No newline at end of file
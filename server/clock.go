@@ -0,0 +1,129 @@
+package server
+
+import (
+    "context"
+    "log"
+    "strconv"
+    "sync"
+    "time"
+    
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "rushkv/storage"
+)
+
+// 节点间RPC的请求和响应都通过metadata携带发送方的混合逻辑时钟
+const clockHeader = "rushkv-clock"
+
+// 时钟偏差警告的最小间隔，避免每个RPC都打印
+const clockWarnInterval = time.Minute
+
+// newPair 创建一条以混合逻辑时钟为版本的记录，Timestamp仍为本地物理时间
+func (s *RushKVServer) newPair(key string, value []byte) *storage.KVPair {
+    return storage.NewKVPair(key, value, s.clock.Now())
+}
+
+// newTombstone 创建一条以混合逻辑时钟为版本的删除标记
+func (s *RushKVServer) newTombstone(key string) *storage.KVPair {
+    return storage.NewTombstone(key, s.clock.Now())
+}
+
+// clockMetadata 返回携带本节点时钟的metadata
+func (s *RushKVServer) clockMetadata() metadata.MD {
+    return metadata.Pairs(clockHeader, strconv.FormatInt(s.clock.Last(), 10))
+}
+
+// observeClock 用md中其他节点的时钟推进本地时钟，对方时钟领先过多时返回错误
+func (s *RushKVServer) observeClock(md metadata.MD) error {
+    values := md.Get(clockHeader)
+    if len(values) == 0 {
+        return nil
+    }
+    remote, err := strconv.ParseInt(values[0], 10, 64)
+    if err != nil {
+        return nil
+    }
+    return s.clock.Update(remote)
+}
+
+// warnClock 限频打印时钟偏差警告
+func (s *RushKVServer) warnClock(err error) {
+    now := time.Now().UnixNano()
+    last := s.clockWarned.Load()
+    if now-last < int64(clockWarnInterval) || !s.clockWarned.CompareAndSwap(last, now) {
+        return
+    }
+    log.Printf("Warning: %v", err)
+}
+
+// clockInterceptor 接受请求方的时钟，拒绝时钟领先过多的节点的请求。
+// 响应头中的时钟在处理完成后取得，不小于处理期间产生的版本
+func (s *RushKVServer) clockInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    if md, ok := metadata.FromIncomingContext(ctx); ok {
+        if err := s.observeClock(md); err != nil {
+            s.warnClock(err)
+            return nil, status.Error(codes.FailedPrecondition, err.Error())
+        }
+    }
+    
+    resp, err := handler(ctx, req)
+    grpc.SetHeader(ctx, s.clockMetadata())
+    return resp, err
+}
+
+// clockStreamInterceptor 流式RPC的clockInterceptor，响应头在第一条消息之前发送
+func (s *RushKVServer) clockStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+    if md, ok := metadata.FromIncomingContext(ss.Context()); ok {
+        if err := s.observeClock(md); err != nil {
+            s.warnClock(err)
+            return status.Error(codes.FailedPrecondition, err.Error())
+        }
+    }
+    
+    ss.SetHeader(s.clockMetadata())
+    return handler(srv, ss)
+}
+
+// peerClockInterceptor 为发往其他节点的请求附加本节点的时钟，并接受响应中对方的时钟
+func (s *RushKVServer) peerClockInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+    ctx = metadata.AppendToOutgoingContext(ctx, clockHeader, strconv.FormatInt(s.clock.Last(), 10))
+    
+    var header metadata.MD
+    err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+    if clockErr := s.observeClock(header); clockErr != nil {
+        s.warnClock(clockErr)
+    }
+    return err
+}
+
+// peerClockStreamInterceptor 流式RPC的peerClockInterceptor，收到第一条消息后读取对方的时钟
+func (s *RushKVServer) peerClockStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+    ctx = metadata.AppendToOutgoingContext(ctx, clockHeader, strconv.FormatInt(s.clock.Last(), 10))
+    
+    stream, err := streamer(ctx, desc, cc, method, opts...)
+    if err != nil {
+        return nil, err
+    }
+    return &clockClientStream{ClientStream: stream, s: s}, nil
+}
+
+// clockClientStream 第一次接收消息后用响应头中的时钟推进本地时钟
+type clockClientStream struct {
+    grpc.ClientStream
+    s    *RushKVServer
+    once sync.Once
+}
+
+func (cs *clockClientStream) RecvMsg(m interface{}) error {
+    err := cs.ClientStream.RecvMsg(m)
+    cs.once.Do(func() {
+        if header, err := cs.Header(); err == nil {
+            if err := cs.s.observeClock(header); err != nil {
+                cs.s.warnClock(err)
+            }
+        }
+    })
+    return err
+}
\ No newline at end of file
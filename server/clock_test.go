@@ -0,0 +1,74 @@
+package server
+
+import (
+    "context"
+    "strconv"
+    "testing"
+    "time"
+    
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "rushkv/hlc"
+    "rushkv/proto"
+)
+
+// replicateFrom 模拟时钟为remote的节点发来的副本写入，经过clockInterceptor
+func replicateFrom(s *RushKVServer, remote int64, pair *proto.KVPair) error {
+    ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(clockHeader, strconv.FormatInt(remote, 10)))
+    info := &grpc.UnaryServerInfo{FullMethod: "/rushkv.RushKV/Replicate"}
+    _, err := s.clockInterceptor(ctx, &proto.ReplicateRequest{Pair: pair}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+        return s.Replicate(ctx, req.(*proto.ReplicateRequest))
+    })
+    return err
+}
+
+func TestLaggingCoordinatorWritesNewerVersion(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 1, nil, "node1")
+    // 本节点的物理时钟比写入上一个版本的节点慢，但在允许的偏差之内
+    lag := s.config.MaxClockOffset - 100*time.Millisecond
+    s.clock = hlc.NewWithPhysical(func() int64 { return time.Now().UnixNano() - int64(lag) }, s.config.MaxClockOffset)
+    
+    previous := time.Now().UnixNano()
+    err := replicateFrom(s, previous, &proto.KVPair{
+        Key:       "k",
+        Value:     []byte("old"),
+        Version:   previous,
+        Timestamp: previous,
+    })
+    if err != nil {
+        t.Fatalf("Replicate: %v", err)
+    }
+    
+    resp, err := s.Put(context.Background(), &proto.PutRequest{Key: "k", Value: []byte("new")})
+    if err != nil || !resp.Success {
+        t.Fatalf("Put = %+v, %v", resp, err)
+    }
+    
+    pair, err := s.storage.GetPair("k")
+    if err != nil || pair == nil {
+        t.Fatalf("GetPair = %+v, %v", pair, err)
+    }
+    if pair.Version <= previous || string(pair.Value) != "new" {
+        t.Errorf("after a write by the lagging node: %q at version %d, want new above %d", pair.Value, pair.Version, previous)
+    }
+}
+
+func TestClockBeyondMaxOffsetRejected(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 1, nil, "node1")
+    
+    ahead := time.Now().Add(2 * s.config.MaxClockOffset).UnixNano()
+    err := replicateFrom(s, ahead, &proto.KVPair{Key: "k", Value: []byte("v"), Version: ahead, Timestamp: ahead})
+    if status.Code(err) != codes.FailedPrecondition {
+        t.Fatalf("Replicate from a node far ahead = %v, want FailedPrecondition", err)
+    }
+    if pair, _ := s.storage.GetPair("k"); pair != nil {
+        t.Errorf("write from a node far ahead was applied: %+v", pair)
+    }
+    if s.clock.Last() >= ahead {
+        t.Errorf("clock adopted the rejected remote time")
+    }
+}
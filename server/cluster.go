@@ -0,0 +1,330 @@
+package server
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "net"
+    "strconv"
+    "time"
+    
+    "google.golang.org/grpc"
+    "rushkv/proto"
+    "rushkv/raft"
+)
+
+const (
+    // 加入集群失败后的重试间隔
+    joinRetryInterval = 2 * time.Second
+    // Join/Leave需要等待成员变更提交
+    joinTimeout = 10 * time.Second
+)
+
+// raftTransport 通过gRPC在节点间发送Raft消息
+type raftTransport struct {
+    s *RushKVServer
+}
+
+func (t *raftTransport) RequestVote(ctx context.Context, target raft.Server, req *raft.VoteRequest) (*raft.VoteResponse, error) {
+    peer, err := t.s.dialPeer(target.ID, target.Address)
+    if err != nil {
+        return nil, err
+    }
+    
+    resp, err := peer.RequestVote(ctx, &proto.VoteRequest{
+        Term:         req.Term,
+        CandidateId:  req.CandidateID,
+        LastLogIndex: req.LastLogIndex,
+        LastLogTerm:  req.LastLogTerm,
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    return &raft.VoteResponse{
+        Term:        resp.Term,
+        VoteGranted: resp.VoteGranted,
+    }, nil
+}
+
+func (t *raftTransport) AppendEntries(ctx context.Context, target raft.Server, req *raft.AppendRequest) (*raft.AppendResponse, error) {
+    peer, err := t.s.dialPeer(target.ID, target.Address)
+    if err != nil {
+        return nil, err
+    }
+    
+    entries := make([]*proto.RaftEntry, 0, len(req.Entries))
+    for _, entry := range req.Entries {
+        entries = append(entries, &proto.RaftEntry{
+            Index: entry.Index,
+            Term:  entry.Term,
+            Type:  int32(entry.Type),
+            Data:  entry.Data,
+        })
+    }
+    
+    resp, err := peer.AppendEntries(ctx, &proto.AppendEntriesRequest{
+        Term:         req.Term,
+        LeaderId:     req.LeaderID,
+        PrevLogIndex: req.PrevLogIndex,
+        PrevLogTerm:  req.PrevLogTerm,
+        Entries:      entries,
+        LeaderCommit: req.LeaderCommit,
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    return &raft.AppendResponse{
+        Term:          resp.Term,
+        Success:       resp.Success,
+        ConflictIndex: resp.ConflictIndex,
+    }, nil
+}
+
+func (s *RushKVServer) RequestVote(ctx context.Context, req *proto.VoteRequest) (*proto.VoteResponse, error) {
+    resp := s.raft.HandleRequestVote(&raft.VoteRequest{
+        Term:         req.Term,
+        CandidateID:  req.CandidateId,
+        LastLogIndex: req.LastLogIndex,
+        LastLogTerm:  req.LastLogTerm,
+    })
+    
+    return &proto.VoteResponse{
+        Term:        resp.Term,
+        VoteGranted: resp.VoteGranted,
+    }, nil
+}
+
+func (s *RushKVServer) AppendEntries(ctx context.Context, req *proto.AppendEntriesRequest) (*proto.AppendEntriesResponse, error) {
+    entries := make([]raft.Entry, 0, len(req.Entries))
+    for _, entry := range req.Entries {
+        entries = append(entries, raft.Entry{
+            Index: entry.Index,
+            Term:  entry.Term,
+            Type:  raft.EntryType(entry.Type),
+            Data:  entry.Data,
+        })
+    }
+    
+    resp := s.raft.HandleAppendEntries(&raft.AppendRequest{
+        Term:         req.Term,
+        LeaderID:     req.LeaderId,
+        PrevLogIndex: req.PrevLogIndex,
+        PrevLogTerm:  req.PrevLogTerm,
+        Entries:      entries,
+        LeaderCommit: req.LeaderCommit,
+    })
+    
+    return &proto.AppendEntriesResponse{
+        Term:          resp.Term,
+        Success:       resp.Success,
+        ConflictIndex: resp.ConflictIndex,
+    }, nil
+}
+
+// applyEntry Raft状态机，应用已提交的日志
+func (s *RushKVServer) applyEntry(entry raft.Entry) {
+    if entry.Type != raft.EntryConfig {
+        return
+    }
+    
+    var servers []raft.Server
+    if err := json.Unmarshal(entry.Data, &servers); err != nil {
+        log.Printf("Invalid membership entry at index %d: %v", entry.Index, err)
+        return
+    }
+    s.applyMembership(servers, entry.Index)
+}
+
+// applyMembership 按成员配置更新节点列表和哈希环，index不大于已应用配置时忽略
+func (s *RushKVServer) applyMembership(servers []raft.Server, index uint64) {
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+    
+    if index <= s.membershipIndex {
+        return
+    }
+    oldRing := s.hash.Clone()
+    changed := false
+    s.membershipIndex = index
+    close(s.membershipChanged)
+    s.membershipChanged = make(chan struct{})
+    
+    members := make(map[string]bool)
+    for _, server := range servers {
+        host, portStr, err := net.SplitHostPort(server.Address)
+        if err != nil {
+            log.Printf("Invalid address %s for node %s: %v", server.Address, server.ID, err)
+            continue
+        }
+        port, _ := strconv.Atoi(portStr)
+        members[server.ID] = true
+        
+        existing, ok := s.nodes[server.ID]
+        if ok && existing.Address == host && existing.Port == int32(port) {
+            continue
+        }
+        if ok {
+            // 节点以新地址重新加入，丢弃旧连接
+            s.closePeer(server.ID)
+        } else {
+            s.hash.AddNode(server.ID)
+            changed = true
+            log.Printf("Node %s joined the cluster", server.ID)
+        }
+        s.nodes[server.ID] = &proto.NodeInfo{
+            Id:      server.ID,
+            Address: host,
+            Port:    int32(port),
+        }
+    }
+    
+    for nodeID := range s.nodes {
+        if !members[nodeID] {
+            delete(s.nodes, nodeID)
+            s.hash.RemoveNode(nodeID)
+            s.closePeer(nodeID)
+            changed = true
+            log.Printf("Node %s left the cluster", nodeID)
+        }
+    }
+    
+    ids := make([]string, 0, len(s.nodes))
+    for nodeID := range s.nodes {
+        ids = append(ids, nodeID)
+    }
+    s.detector.SetMembers(ids)
+    
+    // 启动时从空哈希环恢复不需要迁移
+    if changed && len(oldRing.GetNodes()) > 0 {
+        s.scheduleRebalance(oldRing, s.hash.Clone())
+    }
+}
+
+// leaderClient 返回到当前leader的客户端，用于转发成员变更
+func (s *RushKVServer) leaderClient() (proto.RushKVClient, error) {
+    leader, ok := s.raft.Leader()
+    if !ok || leader.ID == s.nodeID {
+        return nil, fmt.Errorf("no leader elected")
+    }
+    return s.dialPeer(leader.ID, leader.Address)
+}
+
+// joinCluster 依次通过种子节点加入集群，全部失败时间隔一段时间重试，直到成功或服务器停止
+func (s *RushKVServer) joinCluster() {
+    for {
+        for _, seed := range s.config.Seeds {
+            if seed == s.raftAddress() {
+                continue
+            }
+            if err := s.joinVia(seed); err != nil {
+                log.Printf("Failed to join cluster via %s: %v", seed, err)
+                continue
+            }
+            log.Printf("Node %s joined the cluster via %s", s.nodeID, seed)
+            return
+        }
+        
+        select {
+        case <-s.stopCh:
+            return
+        case <-time.After(joinRetryInterval):
+        }
+    }
+}
+
+// joinVia 向种子节点发送Join请求（会被转发给leader），并用返回的成员列表构建哈希环
+func (s *RushKVServer) joinVia(seed string) error {
+    conn, err := grpc.Dial(seed, grpc.WithInsecure(), grpc.WithUnaryInterceptor(s.peerClockInterceptor))
+    if err != nil {
+        return err
+    }
+    defer conn.Close()
+    
+    ctx, cancel := context.WithTimeout(context.Background(), joinTimeout)
+    defer cancel()
+    
+    resp, err := proto.NewRushKVClient(conn).Join(ctx, &proto.JoinRequest{
+        NodeId:  s.nodeID,
+        Address: s.address,
+        Port:    int32(s.port),
+    })
+    if err != nil {
+        return err
+    }
+    if !resp.Success {
+        return fmt.Errorf("%s", resp.Error)
+    }
+    
+    if int(resp.ReplicationFactor) != s.config.ReplicationFactor {
+        log.Printf("Warning: cluster uses replication factor %d but this node is configured with %d",
+            resp.ReplicationFactor, s.config.ReplicationFactor)
+    }
+    s.applyMembership(raftServers(resp.Nodes), resp.Version)
+    return nil
+}
+
+// leaveCluster 正常停止时退出集群。集群只剩本节点时保留成员信息，以便重启后继续服务
+func (s *RushKVServer) leaveCluster() {
+    servers, _ := s.raft.Configuration()
+    if len(servers) <= 1 {
+        return
+    }
+    member := false
+    for _, server := range servers {
+        if server.ID == s.nodeID {
+            member = true
+        }
+    }
+    if !member {
+        return
+    }
+    
+    ctx, cancel := context.WithTimeout(context.Background(), joinTimeout)
+    defer cancel()
+    
+    resp, err := s.Leave(ctx, &proto.LeaveRequest{NodeId: s.nodeID})
+    if err != nil {
+        log.Printf("Failed to leave cluster: %v", err)
+    } else if !resp.Success {
+        log.Printf("Failed to leave cluster: %s", resp.Error)
+    } else {
+        s.handoffOnLeave()
+    }
+}
+
+// nodeInfos 将成员配置转换为节点信息
+func nodeInfos(servers []raft.Server) []*proto.NodeInfo {
+    nodes := make([]*proto.NodeInfo, 0, len(servers))
+    for _, server := range servers {
+        host, portStr, err := net.SplitHostPort(server.Address)
+        if err != nil {
+            continue
+        }
+        port, _ := strconv.Atoi(portStr)
+        nodes = append(nodes, &proto.NodeInfo{
+            Id:      server.ID,
+            Address: host,
+            Port:    int32(port),
+        })
+    }
+    return nodes
+}
+
+// raftServers 将节点信息转换为成员配置
+func raftServers(nodes []*proto.NodeInfo) []raft.Server {
+    servers := make([]raft.Server, 0, len(nodes))
+    for _, node := range nodes {
+        servers = append(servers, raft.Server{
+            ID:      node.Id,
+            Address: net.JoinHostPort(node.Address, strconv.Itoa(int(node.Port))),
+        })
+    }
+    return servers
+}
+
+func (s *RushKVServer) raftAddress() string {
+    return net.JoinHostPort(s.address, strconv.Itoa(s.port))
+}
\ No newline at end of file
@@ -0,0 +1,117 @@
+package server
+
+import (
+    "fmt"
+    "strings"
+    "time"
+    
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+// ReadRepairMode 读请求发现副本版本不一致时的修复方式
+type ReadRepairMode int
+
+const (
+    ReadRepairAsync ReadRepairMode = iota // 后台修复，不增加读延迟
+    ReadRepairSync                        // 修复完成后再返回
+    ReadRepairOff
+)
+
+// ParseReadRepairMode 解析读修复方式（async、sync、off）
+func ParseReadRepairMode(mode string) (ReadRepairMode, error) {
+    switch strings.ToLower(mode) {
+    case "async":
+        return ReadRepairAsync, nil
+    case "sync":
+        return ReadRepairSync, nil
+    case "off":
+        return ReadRepairOff, nil
+    default:
+        return ReadRepairAsync, fmt.Errorf("unknown read repair mode: %s", mode)
+    }
+}
+
+// Config 服务器配置
+type Config struct {
+    NodeID   string
+    Address  string
+    Port     int
+    DataPath string
+    // 存储引擎类型，见storage.EngineBolt、storage.EngineLSM和storage.EngineMemory
+    Engine string
+    
+    // 每个key保存的副本数（包括主节点），整个集群应使用相同的值
+    ReplicationFactor int
+    // 请求未指定一致性级别时使用的默认级别
+    DefaultConsistency proto.ConsistencyLevel
+    // 读请求发现副本版本落后时的修复方式
+    ReadRepair ReadRepairMode
+    
+    // 没有已保存的集群状态时是否以自己为唯一成员初始化新集群，
+    // 需要加入已有集群的节点应设为false
+    Bootstrap bool
+    // 种子节点地址（host:port），非空时启动后通过种子节点加入已有集群
+    Seeds []string
+    
+    // 节点被故障检测判定死亡后，经过该时间仍未恢复则由leader将其移出集群
+    DeadNodeTimeout time.Duration
+    
+    // 为不可用副本保存的提示超过该时间后丢弃
+    HintTTL time.Duration
+    // 为每个节点最多保存的提示数，超出后写入该副本失败
+    MaxHintsPerNode int
+    
+    // 与其他副本比较Merkle树的间隔，为0时关闭反熵同步
+    AntiEntropyInterval time.Duration
+    // 反熵同步每秒最多扫描和拉取的记录数，为0时不限速
+    AntiEntropyRate int
+    
+    // 每个key保留的版本数（包括当前版本），为1时不保留历史版本
+    HistoryVersions int
+    // 墓碑和过期记录保留的时间，之后被物理删除，为0时不清理。应长于HintTTL，
+    // 且离线超过该时间的节点需要清空数据后重新加入
+    TombstoneGrace time.Duration
+    
+    // 每个节点为Watch保留的最近变更数，重连的监听者从中补发断开期间的变更
+    WatchLogSize int
+    
+    // 持久变更日志保留的时间和最多保留的变更数，为0时不按该条件清理
+    ChangeLogRetention  time.Duration
+    ChangeLogMaxEntries int
+    // 非空时把本地变更日志以每行一个JSON对象的格式导出到该目录，供下游ETL读取
+    ChangeSinkDir string
+    // 导出文件超过该大小后轮转，最多保留ChangeSinkMaxFiles个轮转后的文件
+    ChangeSinkMaxBytes int64
+    ChangeSinkMaxFiles int
+    
+    // 其他节点的混合逻辑时钟最多领先本地物理时钟的量，超出时拒绝该节点的请求，为0时不检查
+    MaxClockOffset time.Duration
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+    return &Config{
+        NodeID:              "node1",
+        Address:             "localhost",
+        Port:                8080,
+        DataPath:            "./data",
+        Engine:              storage.EngineBolt,
+        ReplicationFactor:   1,
+        DefaultConsistency:  proto.ConsistencyLevel_QUORUM,
+        Bootstrap:           true,
+        DeadNodeTimeout:     30 * time.Second,
+        HintTTL:             3 * time.Hour,
+        MaxHintsPerNode:     10000,
+        AntiEntropyInterval: 10 * time.Minute,
+        AntiEntropyRate:     1000,
+        HistoryVersions:     storage.DefaultHistoryVersions,
+        TombstoneGrace:      24 * time.Hour,
+        WatchLogSize:        10000,
+        ChangeLogRetention:  24 * time.Hour,
+        ChangeLogMaxEntries: 1000000,
+        ChangeSinkMaxBytes:  64 << 20,
+        ChangeSinkMaxFiles:  10,
+        MaxClockOffset:      500 * time.Millisecond,
+    }
+}
\ No newline at end of file
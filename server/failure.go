@@ -0,0 +1,141 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "time"
+    
+    "rushkv/proto"
+    "rushkv/raft"
+    "rushkv/swim"
+)
+
+// leader检查死亡节点的间隔
+const reapInterval = 1 * time.Second
+
+// swimTransport 通过gRPC发送故障检测的探测消息
+type swimTransport struct {
+    s *RushKVServer
+}
+
+func (t *swimTransport) Ping(ctx context.Context, target string, updates []swim.Update) ([]swim.Update, error) {
+    return t.probe(ctx, target, "", updates)
+}
+
+func (t *swimTransport) PingReq(ctx context.Context, via, target string, updates []swim.Update) ([]swim.Update, error) {
+    return t.probe(ctx, via, target, updates)
+}
+
+func (t *swimTransport) probe(ctx context.Context, nodeID, target string, updates []swim.Update) ([]swim.Update, error) {
+    peer, err := t.s.peerClient(nodeID)
+    if err != nil {
+        return nil, err
+    }
+    
+    resp, err := peer.Probe(ctx, &proto.ProbeRequest{
+        From:    t.s.nodeID,
+        Target:  target,
+        Updates: toProtoUpdates(updates),
+    })
+    if err != nil {
+        return nil, err
+    }
+    if !resp.Success {
+        return nil, fmt.Errorf("probe of %s via %s failed: %s", target, nodeID, resp.Error)
+    }
+    return fromProtoUpdates(resp.Updates), nil
+}
+
+func (s *RushKVServer) Probe(ctx context.Context, req *proto.ProbeRequest) (*proto.ProbeResponse, error) {
+    updates := fromProtoUpdates(req.Updates)
+    if req.Target == "" || req.Target == s.nodeID {
+        return &proto.ProbeResponse{
+            Success: true,
+            Updates: toProtoUpdates(s.detector.HandlePing(updates)),
+        }, nil
+    }
+    
+    acked, err := s.detector.HandlePingReq(ctx, req.Target, updates)
+    if err != nil {
+        return &proto.ProbeResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    return &proto.ProbeResponse{
+        Success: true,
+        Updates: toProtoUpdates(acked),
+    }, nil
+}
+
+// onMemberChange 故障检测发现节点状态变化
+func (s *RushKVServer) onMemberChange(member swim.Member) {
+    log.Printf("Node %s is %s", member.ID, member.State)
+    if member.State == swim.StateAlive {
+        go s.replayHints(member.ID)
+    }
+}
+
+// nodeState 返回故障检测得到的节点状态
+func (s *RushKVServer) nodeState(nodeID string) proto.NodeState {
+    state, _ := s.detector.State(nodeID)
+    return proto.NodeState(state)
+}
+
+// reapDeadNodes leader定期把死亡超过DeadNodeTimeout的节点移出集群
+func (s *RushKVServer) reapDeadNodes() {
+    ticker := time.NewTicker(reapInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-s.stopCh:
+            return
+        case <-ticker.C:
+        }
+        
+        if !s.raft.IsLeader() {
+            continue
+        }
+        for _, member := range s.detector.Members() {
+            if member.State != swim.StateDead || time.Since(member.Since) < s.config.DeadNodeTimeout {
+                continue
+            }
+            
+            log.Printf("Removing node %s, dead since %s", member.ID, member.Since.Format(time.RFC3339))
+            ctx, cancel := context.WithTimeout(context.Background(), joinTimeout)
+            err := s.raft.RemoveServer(ctx, member.ID)
+            cancel()
+            if err != nil && err != raft.ErrConfigChangePending {
+                log.Printf("Failed to remove node %s: %v", member.ID, err)
+            }
+            // 每次只能变更一个成员
+            break
+        }
+    }
+}
+
+func toProtoUpdates(updates []swim.Update) []*proto.MemberUpdate {
+    result := make([]*proto.MemberUpdate, 0, len(updates))
+    for _, update := range updates {
+        result = append(result, &proto.MemberUpdate{
+            NodeId:      update.ID,
+            State:       proto.NodeState(update.State),
+            Incarnation: update.Incarnation,
+        })
+    }
+    return result
+}
+
+func fromProtoUpdates(updates []*proto.MemberUpdate) []swim.Update {
+    result := make([]swim.Update, 0, len(updates))
+    for _, update := range updates {
+        result = append(result, swim.Update{
+            ID:          update.NodeId,
+            State:       swim.State(update.State),
+            Incarnation: update.Incarnation,
+        })
+    }
+    return result
+}
\ No newline at end of file
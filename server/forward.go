@@ -0,0 +1,240 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    
+    "google.golang.org/grpc"
+    protobuf "google.golang.org/protobuf/proto"
+    "rushkv/proto"
+)
+
+// 请求最多被转发的次数，超过后说明各节点的哈希环不一致，直接返回错误
+const maxForwardHops = 2
+
+// peerClient 返回到指定节点的gRPC客户端
+func (s *RushKVServer) peerClient(nodeID string) (proto.RushKVClient, error) {
+    s.mutex.RLock()
+    node, ok := s.nodes[nodeID]
+    s.mutex.RUnlock()
+    if !ok {
+        return nil, fmt.Errorf("unknown node %s", nodeID)
+    }
+    
+    return s.dialPeer(nodeID, fmt.Sprintf("%s:%d", node.Address, node.Port))
+}
+
+// dialPeer 返回到指定地址节点的gRPC客户端，连接按需建立并复用
+func (s *RushKVServer) dialPeer(nodeID, address string) (proto.RushKVClient, error) {
+    s.peerMutex.Lock()
+    defer s.peerMutex.Unlock()
+    
+    if conn, ok := s.peers[nodeID]; ok {
+        if conn.Target() == address {
+            return proto.NewRushKVClient(conn), nil
+        }
+        // 节点地址已变化
+        conn.Close()
+        delete(s.peers, nodeID)
+    }
+    
+    conn, err := grpc.Dial(address,
+        grpc.WithInsecure(),
+        grpc.WithChainUnaryInterceptor(s.peerInterceptor(nodeID), s.peerClockInterceptor),
+        grpc.WithStreamInterceptor(s.peerClockStreamInterceptor),
+    )
+    if err != nil {
+        return nil, fmt.Errorf("failed to connect to node %s: %v", nodeID, err)
+    }
+    s.peers[nodeID] = conn
+    
+    return proto.NewRushKVClient(conn), nil
+}
+
+// closePeer 关闭到指定节点的连接
+func (s *RushKVServer) closePeer(nodeID string) {
+    s.peerMutex.Lock()
+    defer s.peerMutex.Unlock()
+    
+    if conn, ok := s.peers[nodeID]; ok {
+        conn.Close()
+        delete(s.peers, nodeID)
+    }
+}
+
+// closePeers 关闭所有节点连接
+func (s *RushKVServer) closePeers() {
+    s.peerMutex.Lock()
+    defer s.peerMutex.Unlock()
+    
+    for nodeID, conn := range s.peers {
+        conn.Close()
+        delete(s.peers, nodeID)
+    }
+}
+
+// containsNode 判断节点是否在列表中
+func containsNode(nodes []string, nodeID string) bool {
+    for _, node := range nodes {
+        if node == nodeID {
+            return true
+        }
+    }
+    return false
+}
+
+// forwardPut 将写请求依次尝试转发给key的副本节点，由第一个可达的副本协调
+func (s *RushKVServer) forwardPut(ctx context.Context, req *proto.PutRequest, replicas []string) *proto.PutResponse {
+    if len(replicas) == 0 {
+        return &proto.PutResponse{
+            Success: false,
+            Error:   "no available nodes",
+        }
+    }
+    if req.Hops >= maxForwardHops {
+        return &proto.PutResponse{
+            Success: false,
+            Error:   fmt.Sprintf("key should be stored on node %s", replicas[0]),
+        }
+    }
+    
+    forwarded := protobuf.Clone(req).(*proto.PutRequest)
+    forwarded.Hops++
+    
+    var lastErr error
+    for _, nodeID := range replicas {
+        peer, err := s.peerClient(nodeID)
+        if err != nil {
+            lastErr = err
+            continue
+        }
+        resp, err := peer.Put(ctx, forwarded)
+        if err != nil {
+            lastErr = fmt.Errorf("failed to forward to node %s: %v", nodeID, err)
+            continue
+        }
+        return resp
+    }
+    
+    return &proto.PutResponse{
+        Success: false,
+        Error:   lastErr.Error(),
+    }
+}
+
+// forwardGet 将读请求转发给key的副本节点
+func (s *RushKVServer) forwardGet(ctx context.Context, req *proto.GetRequest, replicas []string) *proto.GetResponse {
+    if len(replicas) == 0 {
+        return &proto.GetResponse{
+            Success: false,
+            Error:   "no available nodes",
+        }
+    }
+    if req.Hops >= maxForwardHops {
+        return &proto.GetResponse{
+            Success: false,
+            Error:   fmt.Sprintf("key should be retrieved from node %s", replicas[0]),
+        }
+    }
+    
+    forwarded := protobuf.Clone(req).(*proto.GetRequest)
+    forwarded.Hops++
+    
+    var lastErr error
+    for _, nodeID := range replicas {
+        peer, err := s.peerClient(nodeID)
+        if err != nil {
+            lastErr = err
+            continue
+        }
+        resp, err := peer.Get(ctx, forwarded)
+        if err != nil {
+            lastErr = fmt.Errorf("failed to forward to node %s: %v", nodeID, err)
+            continue
+        }
+        return resp
+    }
+    
+    return &proto.GetResponse{
+        Success: false,
+        Error:   lastErr.Error(),
+    }
+}
+
+// forwardDelete 将删除请求转发给key的副本节点
+func (s *RushKVServer) forwardDelete(ctx context.Context, req *proto.DeleteRequest, replicas []string) *proto.DeleteResponse {
+    if len(replicas) == 0 {
+        return &proto.DeleteResponse{
+            Success: false,
+            Error:   "no available nodes",
+        }
+    }
+    if req.Hops >= maxForwardHops {
+        return &proto.DeleteResponse{
+            Success: false,
+            Error:   fmt.Sprintf("key should be deleted from node %s", replicas[0]),
+        }
+    }
+    
+    forwarded := protobuf.Clone(req).(*proto.DeleteRequest)
+    forwarded.Hops++
+    
+    var lastErr error
+    for _, nodeID := range replicas {
+        peer, err := s.peerClient(nodeID)
+        if err != nil {
+            lastErr = err
+            continue
+        }
+        resp, err := peer.Delete(ctx, forwarded)
+        if err != nil {
+            lastErr = fmt.Errorf("failed to forward to node %s: %v", nodeID, err)
+            continue
+        }
+        return resp
+    }
+    
+    return &proto.DeleteResponse{
+        Success: false,
+        Error:   lastErr.Error(),
+    }
+}
+
+// forwardHistory 将历史版本查询转发给key的副本节点
+func (s *RushKVServer) forwardHistory(ctx context.Context, req *proto.HistoryRequest, replicas []string) *proto.HistoryResponse {
+    if len(replicas) == 0 {
+        return &proto.HistoryResponse{
+            Success: false,
+            Error:   "no available nodes",
+        }
+    }
+    if req.Hops >= maxForwardHops {
+        return &proto.HistoryResponse{
+            Success: false,
+            Error:   fmt.Sprintf("key should be retrieved from node %s", replicas[0]),
+        }
+    }
+    
+    forwarded := protobuf.Clone(req).(*proto.HistoryRequest)
+    forwarded.Hops++
+    
+    var lastErr error
+    for _, nodeID := range replicas {
+        peer, err := s.peerClient(nodeID)
+        if err != nil {
+            lastErr = err
+            continue
+        }
+        resp, err := peer.History(ctx, forwarded)
+        if err != nil {
+            lastErr = fmt.Errorf("failed to forward to node %s: %v", nodeID, err)
+            continue
+        }
+        return resp
+    }
+    
+    return &proto.HistoryResponse{
+        Success: false,
+        Error:   lastErr.Error(),
+    }
+}
\ No newline at end of file
@@ -0,0 +1,200 @@
+package server
+
+import (
+    "context"
+    "log"
+    "sync"
+    "time"
+    
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+const (
+    // 清理过期记录和墓碑的间隔
+    gcInterval = 30 * time.Second
+    // 每批检查的记录数
+    gcBatchSize = 1000
+    // 压缩存储文件的超时时间
+    compactTimeout = 10 * time.Minute
+)
+
+// gcStats 一次清理删除的记录数
+type gcStats struct {
+    expired    int
+    tombstones int
+}
+
+// sweepGarbage 定期清理本地超过宽限期的过期记录和墓碑。过期时间和墓碑随记录复制到所有副本，
+// 各副本独立清理，不需要写墓碑
+func (s *RushKVServer) sweepGarbage() {
+    ticker := time.NewTicker(gcInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-s.stopCh:
+            return
+        case <-ticker.C:
+        }
+        
+        stats, err := s.collectGarbage()
+        if err != nil {
+            log.Printf("Failed to collect garbage: %v", err)
+        }
+        if stats.expired > 0 || stats.tombstones > 0 {
+            log.Printf("Purged %d expired keys and %d tombstones", stats.expired, stats.tombstones)
+        }
+    }
+}
+
+// collectable 判断记录是否可以物理删除：超过宽限期的墓碑，或过期超过宽限期的记录。
+// 过期记录和墓碑一样保留到宽限期结束，保证所有副本（包括通过提示和反熵恢复的副本）都已收到，
+// 否则落后副本上的旧值会重新扩散
+func (s *RushKVServer) collectable(pair *storage.KVPair, now time.Time) bool {
+    if s.config.TombstoneGrace <= 0 {
+        return false
+    }
+    if pair.Deleted {
+        return now.Sub(pair.Timestamp) >= s.config.TombstoneGrace
+    }
+    return !pair.ExpiresAt.IsZero() && now.Sub(pair.ExpiresAt) >= s.config.TombstoneGrace
+}
+
+// collectGarbage 分批扫描本地记录并删除可以清理的记录
+func (s *RushKVServer) collectGarbage() (gcStats, error) {
+    s.gcMutex.Lock()
+    defer s.gcMutex.Unlock()
+    
+    var stats gcStats
+    after := ""
+    for {
+        select {
+        case <-s.stopCh:
+            return stats, nil
+        default:
+        }
+        
+        pairs, err := s.storage.ListPairs(after, gcBatchSize)
+        if err != nil {
+            return stats, err
+        }
+        if len(pairs) == 0 {
+            return stats, nil
+        }
+        
+        now := time.Now()
+        var expired, tombstones []*storage.KVPair
+        for _, pair := range pairs {
+            switch {
+            case !s.collectable(pair, now):
+            case pair.Deleted:
+                tombstones = append(tombstones, pair)
+            default:
+                expired = append(expired, pair)
+            }
+        }
+        if len(expired) > 0 {
+            n, err := s.storage.PurgePairs(expired)
+            if err != nil {
+                return stats, err
+            }
+            stats.expired += n
+            if n > 0 {
+                s.changes.notify()
+            }
+        }
+        if len(tombstones) > 0 {
+            n, err := s.storage.PurgePairs(tombstones)
+            if err != nil {
+                return stats, err
+            }
+            stats.tombstones += n
+        }
+        after = pairs[len(pairs)-1].Key
+    }
+}
+
+func (s *RushKVServer) Compact(ctx context.Context, req *proto.CompactRequest) (*proto.CompactResponse, error) {
+    results := []*proto.CompactResult{s.compactLocal(req)}
+    if req.Cluster {
+        ctx, cancel := context.WithTimeout(ctx, compactTimeout)
+        defer cancel()
+        
+        s.mutex.RLock()
+        var peers []string
+        for nodeID := range s.nodes {
+            if nodeID != s.nodeID {
+                peers = append(peers, nodeID)
+            }
+        }
+        s.mutex.RUnlock()
+        
+        var mutex sync.Mutex
+        var wg sync.WaitGroup
+        for _, nodeID := range peers {
+            wg.Add(1)
+            go func(nodeID string) {
+                defer wg.Done()
+                result := &proto.CompactResult{NodeId: nodeID}
+                resp, err := s.compactPeer(ctx, nodeID, req)
+                switch {
+                case err != nil:
+                    result.Error = err.Error()
+                case !resp.Success:
+                    result.Error = resp.Error
+                case len(resp.Results) > 0:
+                    result = resp.Results[0]
+                }
+                mutex.Lock()
+                results = append(results, result)
+                mutex.Unlock()
+            }(nodeID)
+        }
+        wg.Wait()
+    }
+    
+    return &proto.CompactResponse{
+        Success: true,
+        Results: results,
+    }, nil
+}
+
+func (s *RushKVServer) compactPeer(ctx context.Context, nodeID string, req *proto.CompactRequest) (*proto.CompactResponse, error) {
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return nil, err
+    }
+    return peer.Compact(ctx, &proto.CompactRequest{
+        Gc:      req.Gc,
+        Compact: req.Compact,
+    })
+}
+
+// compactLocal 在本节点上清理墓碑和压缩存储文件
+func (s *RushKVServer) compactLocal(req *proto.CompactRequest) *proto.CompactResult {
+    result := &proto.CompactResult{NodeId: s.nodeID}
+    
+    if req.Gc {
+        stats, err := s.collectGarbage()
+        result.TombstonesRemoved = int64(stats.tombstones)
+        result.ExpiredRemoved = int64(stats.expired)
+        if err != nil {
+            result.Error = err.Error()
+            return result
+        }
+    }
+    
+    if req.Compact {
+        before, after, err := s.storage.Compact()
+        if err != nil {
+            result.Error = err.Error()
+            return result
+        }
+        result.SizeBefore = before
+        result.SizeAfter = after
+        log.Printf("Compacted storage from %d to %d bytes", before, after)
+    }
+    
+    return result
+}
\ No newline at end of file
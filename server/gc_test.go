@@ -0,0 +1,71 @@
+package server
+
+import (
+    "testing"
+    "time"
+    
+    "rushkv/storage"
+)
+
+func TestExpiredRecordKeptUntilGrace(t *testing.T) {
+    s := newTestServer(t, "node1", func(config *Config) {
+        config.TombstoneGrace = time.Hour
+    })
+    
+    now := time.Now()
+    recent := &storage.KVPair{Key: "recent", Value: []byte("v"), Version: 1, Timestamp: now.Add(-2 * time.Minute), ExpiresAt: now.Add(-time.Minute)}
+    old := &storage.KVPair{Key: "old", Value: []byte("v"), Version: 2, Timestamp: now.Add(-3 * time.Hour), ExpiresAt: now.Add(-2 * time.Hour)}
+    if _, err := s.applyPairs([]*storage.KVPair{recent, old}); err != nil {
+        t.Fatal(err)
+    }
+    
+    stats, err := s.collectGarbage()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if stats.expired != 1 {
+        t.Errorf("expired = %d, want 1", stats.expired)
+    }
+    if pair, _ := s.storage.GetPair("recent"); pair == nil || pair.Version != 1 {
+        t.Errorf("recent = %+v, want it kept within the grace window", pair)
+    }
+    if pair, _ := s.storage.GetPair("old"); pair != nil {
+        t.Errorf("old = %+v, want it purged after the grace window", pair)
+    }
+}
+
+func TestAntiEntropyKeepsExpiredRecord(t *testing.T) {
+    servers := newTestCluster(t, 2, func(config *Config) {
+        config.ReplicationFactor = 2
+        config.AntiEntropyRate = 0
+        config.TombstoneGrace = time.Hour
+    })
+    node1, node2 := servers[0], servers[1]
+    
+    // node2错过了带TTL的写入，仍持有更旧的值
+    now := time.Now()
+    stale := &storage.KVPair{Key: "k", Value: []byte("old"), Version: 1, Timestamp: now.Add(-time.Minute)}
+    expired := &storage.KVPair{Key: "k", Value: []byte("new"), Version: 2, Timestamp: now.Add(-time.Minute), ExpiresAt: now.Add(-time.Second)}
+    if _, err := node1.applyPairs([]*storage.KVPair{expired}); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := node2.applyPairs([]*storage.KVPair{stale}); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := node1.collectGarbage(); err != nil {
+        t.Fatal(err)
+    }
+    
+    node1.antiEntropyRound()
+    node2.antiEntropyRound()
+    
+    for _, s := range servers {
+        if status := s.antiEntropyStatus(); status.LastError != "" {
+            t.Fatalf("%s anti-entropy round failed: %s", s.nodeID, status.LastError)
+        }
+        pair, err := s.storage.GetPair("k")
+        if err != nil || pair == nil || pair.Version != 2 {
+            t.Errorf("%s k = %+v, %v, want the expired version 2", s.nodeID, pair, err)
+        }
+    }
+}
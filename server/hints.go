@@ -0,0 +1,255 @@
+package server
+
+import (
+    "context"
+    "log"
+    "sync"
+    "time"
+    
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "rushkv/proto"
+    "rushkv/storage"
+    "rushkv/swim"
+)
+
+const (
+    // 清理过期提示、重放遗漏提示的间隔
+    hintSweepInterval = 10 * time.Second
+    // 每批重放的提示数
+    hintReplayBatch = 100
+)
+
+// hintReplayer 保证同一目标节点同时只有一个重放任务
+type hintReplayer struct {
+    mutex   sync.Mutex
+    running map[string]bool
+}
+
+// writeOrHint 写入单个副本，副本不可达时为目标节点保存提示，待其恢复后重放。
+// 节点已被判定不可用时直接保存提示。提示不算作副本确认，保存提示后仍返回错误，
+// 由调用方按一致性级别判断写入是否成功
+func (s *RushKVServer) writeOrHint(ctx context.Context, nodeID string, pair *storage.KVPair) error {
+    if nodeID == s.nodeID {
+        return s.writeReplica(ctx, nodeID, pair)
+    }
+    
+    if s.nodeState(nodeID) == proto.NodeState_ALIVE {
+        err := s.writeReplica(ctx, nodeID, pair)
+        if err != nil && status.Code(err) == codes.Unavailable {
+            s.storeHint(nodeID, pair)
+        }
+        return err
+    }
+    
+    if err := s.storeHint(nodeID, pair); err != nil {
+        return err
+    }
+    return errHinted(nodeID)
+}
+
+// writeBatchOrHint 与writeOrHint相同，但一批记录通过一次请求写入
+func (s *RushKVServer) writeBatchOrHint(ctx context.Context, nodeID string, pairs []*storage.KVPair) error {
+    if nodeID == s.nodeID {
+        return s.writeBatch(ctx, nodeID, pairs)
+    }
+    
+    if s.nodeState(nodeID) == proto.NodeState_ALIVE {
+        err := s.writeBatch(ctx, nodeID, pairs)
+        if err != nil && status.Code(err) == codes.Unavailable {
+            s.storeHints(nodeID, pairs)
+        }
+        return err
+    }
+    
+    if err := s.storeHints(nodeID, pairs); err != nil {
+        return err
+    }
+    return errHinted(nodeID)
+}
+
+// errHinted 副本不可用、写入只保存为提示时返回的错误
+func errHinted(nodeID string) error {
+    return status.Errorf(codes.Unavailable, "node %s is unavailable, write kept as hint", nodeID)
+}
+
+func (s *RushKVServer) storeHints(nodeID string, pairs []*storage.KVPair) error {
+    for _, pair := range pairs {
+        if err := s.storeHint(nodeID, pair); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+func (s *RushKVServer) storeHint(nodeID string, pair *storage.KVPair) error {
+    err := s.storage.AddHint(nodeID, pair, s.config.MaxHintsPerNode)
+    if err != nil {
+        log.Printf("Failed to store hint for node %s: %v", nodeID, err)
+    }
+    return err
+}
+
+// replayHints 把为nodeID保存的提示按批发送给它，发送成功后删除
+func (s *RushKVServer) replayHints(nodeID string) {
+    r := s.hints
+    r.mutex.Lock()
+    if r.running[nodeID] {
+        r.mutex.Unlock()
+        return
+    }
+    r.running[nodeID] = true
+    r.mutex.Unlock()
+    
+    defer func() {
+        r.mutex.Lock()
+        delete(r.running, nodeID)
+        r.mutex.Unlock()
+    }()
+    
+    replayed := 0
+    for {
+        select {
+        case <-s.stopCh:
+            return
+        default:
+        }
+        
+        hints, err := s.storage.Hints(nodeID, hintReplayBatch)
+        if err != nil {
+            log.Printf("Failed to read hints for node %s: %v", nodeID, err)
+            return
+        }
+        if len(hints) == 0 {
+            break
+        }
+        
+        pairs := make([]*proto.KVPair, 0, len(hints))
+        for _, hint := range hints {
+            pairs = append(pairs, toProtoPair(hint.Pair))
+        }
+        if err := s.handoffTo(nodeID, pairs); err != nil {
+            log.Printf("Failed to replay hints to node %s: %v", nodeID, err)
+            return
+        }
+        if err := s.storage.DeleteHints(hints); err != nil {
+            log.Printf("Failed to delete replayed hints: %v", err)
+            return
+        }
+        replayed += len(hints)
+    }
+    
+    if replayed > 0 {
+        log.Printf("Replayed %d hints to node %s", replayed, nodeID)
+    }
+}
+
+// sweepHints 定期清理过期提示，丢弃已离开集群的节点的提示，
+// 并重放存活节点上遗漏的提示（例如故障检测尚未发现的短暂失败）
+func (s *RushKVServer) sweepHints() {
+    ticker := time.NewTicker(hintSweepInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-s.stopCh:
+            return
+        case <-ticker.C:
+        }
+        
+        if expired, err := s.storage.ExpireHints(time.Now().Add(-s.config.HintTTL)); err != nil {
+            log.Printf("Failed to expire hints: %v", err)
+        } else if expired > 0 {
+            log.Printf("Expired %d hints", expired)
+        }
+        
+        backlogs, err := s.storage.HintBacklogs()
+        if err != nil {
+            log.Printf("Failed to list hints: %v", err)
+            continue
+        }
+        for _, backlog := range backlogs {
+            state, member := s.detector.State(backlog.NodeID)
+            if !member {
+                s.storage.DropHints(backlog.NodeID)
+                continue
+            }
+            if state == swim.StateAlive {
+                go s.replayHints(backlog.NodeID)
+            }
+        }
+    }
+}
+
+func (s *RushKVServer) GetHints(ctx context.Context, req *proto.HintsRequest) (*proto.HintsResponse, error) {
+    backlogs, err := s.localHints()
+    if err != nil {
+        return &proto.HintsResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    if !req.Cluster {
+        return &proto.HintsResponse{
+            Success:  true,
+            Backlogs: backlogs,
+        }, nil
+    }
+    
+    ctx, cancel := context.WithTimeout(ctx, nodeStatusTimeout)
+    defer cancel()
+    
+    s.mutex.RLock()
+    var peers []string
+    for nodeID := range s.nodes {
+        if nodeID != s.nodeID {
+            peers = append(peers, nodeID)
+        }
+    }
+    s.mutex.RUnlock()
+    
+    var mutex sync.Mutex
+    var wg sync.WaitGroup
+    for _, nodeID := range peers {
+        wg.Add(1)
+        go func(nodeID string) {
+            defer wg.Done()
+            peer, err := s.peerClient(nodeID)
+            if err != nil {
+                return
+            }
+            resp, err := peer.GetHints(ctx, &proto.HintsRequest{})
+            if err != nil || !resp.Success {
+                return
+            }
+            mutex.Lock()
+            backlogs = append(backlogs, resp.Backlogs...)
+            mutex.Unlock()
+        }(nodeID)
+    }
+    wg.Wait()
+    
+    return &proto.HintsResponse{
+        Success:  true,
+        Backlogs: backlogs,
+    }, nil
+}
+
+// localHints 返回本节点保存的提示
+func (s *RushKVServer) localHints() ([]*proto.HintBacklog, error) {
+    backlogs, err := s.storage.HintBacklogs()
+    if err != nil {
+        return nil, err
+    }
+    
+    result := make([]*proto.HintBacklog, 0, len(backlogs))
+    for _, backlog := range backlogs {
+        result = append(result, &proto.HintBacklog{
+            Holder: s.nodeID,
+            Target: backlog.NodeID,
+            Count:  int64(backlog.Count),
+            Oldest: backlog.Oldest.Unix(),
+        })
+    }
+    return result, nil
+}
\ No newline at end of file
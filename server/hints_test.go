@@ -0,0 +1,68 @@
+package server
+
+import (
+    "context"
+    "testing"
+    
+    "rushkv/proto"
+    "rushkv/storage"
+    "rushkv/swim"
+)
+
+func TestPutAllFailsWhenReplicaOnlyHinted(t *testing.T) {
+    s := newTestServer(t, "node1", func(config *Config) {
+        config.ReplicationFactor = 2
+    })
+    setMembers(s, 1, nil, "node1", "node2")
+    s.detector.HandlePing([]swim.Update{{ID: "node2", State: swim.StateDead, Incarnation: 1}})
+    
+    resp, err := s.Put(context.Background(), &proto.PutRequest{
+        Key:         "k",
+        Value:       []byte("v"),
+        Consistency: proto.ConsistencyLevel_ALL,
+    })
+    if err != nil {
+        t.Fatalf("Put: %v", err)
+    }
+    if resp.Success {
+        t.Fatal("Put with consistency ALL succeeded while a replica was down")
+    }
+    
+    backlogs, err := s.storage.HintBacklogs()
+    if err != nil {
+        t.Fatalf("HintBacklogs: %v", err)
+    }
+    if len(backlogs) != 1 || backlogs[0].NodeID != "node2" || backlogs[0].Count != 1 {
+        t.Fatalf("hint backlogs = %+v, want one hint for node2", backlogs)
+    }
+    
+    resp, err = s.Put(context.Background(), &proto.PutRequest{
+        Key:         "k",
+        Value:       []byte("v"),
+        Consistency: proto.ConsistencyLevel_ONE,
+    })
+    if err != nil || !resp.Success {
+        t.Fatalf("Put with consistency ONE = %+v, %v, want success", resp, err)
+    }
+}
+
+func TestBatchAllFailsWhenReplicaOnlyHinted(t *testing.T) {
+    s := newTestServer(t, "node1", func(config *Config) {
+        config.ReplicationFactor = 2
+    })
+    setMembers(s, 1, nil, "node1", "node2")
+    s.detector.HandlePing([]swim.Update{{ID: "node2", State: swim.StateDead, Incarnation: 1}})
+    
+    err := s.replicatePairs(context.Background(), []*storage.KVPair{s.newPair("a", []byte("1")), s.newPair("b", []byte("2"))}, proto.ConsistencyLevel_ALL)
+    if err == nil {
+        t.Fatal("batch with consistency ALL succeeded while a replica was down")
+    }
+    
+    backlogs, err := s.storage.HintBacklogs()
+    if err != nil {
+        t.Fatalf("HintBacklogs: %v", err)
+    }
+    if len(backlogs) != 1 || backlogs[0].Count != 2 {
+        t.Fatalf("hint backlogs = %+v, want two hints for node2", backlogs)
+    }
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "time"
+    
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+// getAt 读取key的历史版本：各副本返回本地满足条件的最新版本，取其中版本最大的
+func (s *RushKVServer) getAt(ctx context.Context, req *proto.GetRequest, replicas []string) *proto.GetResponse {
+    required := s.requiredAcks(req.Consistency, len(replicas))
+    responses, err := s.readVersions(ctx, &proto.ReadReplicaRequest{
+        Key:       req.Key,
+        AtVersion: req.AtVersion,
+        AtTime:    req.AtTime,
+    }, replicas, required)
+    if err != nil {
+        return &proto.GetResponse{
+            Success: false,
+            Error:   err.Error(),
+        }
+    }
+    
+    var pair *storage.KVPair
+    for _, pairs := range responses {
+        for _, p := range pairs {
+            if pair == nil || p.Version > pair.Version {
+                pair = p
+            }
+        }
+    }
+    // 按时间读取时以该时间判断是否已过期
+    if pair == nil || pair.Deleted || (req.AtTime > 0 && pair.Expired(time.Unix(0, req.AtTime))) {
+        return &proto.GetResponse{
+            Success: false,
+            Error:   "key not found",
+        }
+    }
+    
+    return &proto.GetResponse{
+        Success:   true,
+        Value:     pair.Value,
+        ExpiresAt: expiresAt(pair),
+        Version:   pair.Version,
+    }
+}
+
+func (s *RushKVServer) History(ctx context.Context, req *proto.HistoryRequest) (*proto.HistoryResponse, error) {
+    var resp *proto.HistoryResponse
+    s.withRingRetry(func() bool {
+        resp = s.history(ctx, req)
+        return resp.Success
+    })
+    return resp, nil
+}
+
+// history 合并各副本保留的版本，同一版本只返回一次
+func (s *RushKVServer) history(ctx context.Context, req *proto.HistoryRequest) *proto.HistoryResponse {
+    replicas := s.replicasFor(req.Key)
+    if !containsNode(replicas, s.nodeID) {
+        return s.forwardHistory(ctx, req, replicas)
+    }
+    
+    required := s.requiredAcks(req.Consistency, len(replicas))
+    responses, err := s.readVersions(ctx, &proto.ReadReplicaRequest{
+        Key:     req.Key,
+        History: true,
+        Limit:   req.Limit,
+    }, replicas, required)
+    if err != nil {
+        return &proto.HistoryResponse{
+            Success: false,
+            Error:   err.Error(),
+        }
+    }
+    
+    versions := make(map[int64]*storage.KVPair)
+    for _, pairs := range responses {
+        for _, pair := range pairs {
+            versions[pair.Version] = pair
+        }
+    }
+    merged := make([]*storage.KVPair, 0, len(versions))
+    for _, pair := range versions {
+        merged = append(merged, pair)
+    }
+    sort.Slice(merged, func(i, j int) bool {
+        return merged[i].Version > merged[j].Version
+    })
+    if req.Limit > 0 && len(merged) > int(req.Limit) {
+        merged = merged[:req.Limit]
+    }
+    
+    resp := &proto.HistoryResponse{Success: true}
+    for _, pair := range merged {
+        resp.Versions = append(resp.Versions, toProtoPair(pair))
+    }
+    return resp
+}
+
+// readVersions 并发向各副本发送读请求，收到required个响应后返回每个副本的结果
+func (s *RushKVServer) readVersions(ctx context.Context, req *proto.ReadReplicaRequest, replicas []string, required int) (map[string][]*storage.KVPair, error) {
+    type result struct {
+        nodeID string
+        pairs  []*storage.KVPair
+        err    error
+    }
+    
+    results := make(chan result, len(replicas))
+    for _, nodeID := range replicas {
+        go func(nodeID string) {
+            pairs, err := s.readReplicaVersions(ctx, nodeID, req)
+            results <- result{nodeID: nodeID, pairs: pairs, err: err}
+        }(nodeID)
+    }
+    
+    responses := make(map[string][]*storage.KVPair)
+    failures := 0
+    var lastErr error
+    for range replicas {
+        r := <-results
+        if r.err != nil {
+            failures++
+            lastErr = r.err
+        } else {
+            responses[r.nodeID] = r.pairs
+        }
+        
+        if len(responses) >= required {
+            return responses, nil
+        }
+        if len(replicas)-failures < required {
+            break
+        }
+    }
+    
+    return nil, fmt.Errorf("only %d of %d required replicas responded: %v", len(responses), required, lastErr)
+}
+
+// readReplicaVersions 读取单个副本上的历史版本
+func (s *RushKVServer) readReplicaVersions(ctx context.Context, nodeID string, req *proto.ReadReplicaRequest) ([]*storage.KVPair, error) {
+    if nodeID == s.nodeID {
+        return s.localVersions(req)
+    }
+    
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return nil, err
+    }
+    
+    resp, err := peer.ReadReplica(ctx, req)
+    if err != nil {
+        return nil, fmt.Errorf("read from node %s failed: %v", nodeID, err)
+    }
+    if !resp.Success {
+        return nil, fmt.Errorf("read from node %s failed: %s", nodeID, resp.Error)
+    }
+    
+    var pairs []*storage.KVPair
+    if resp.Pair != nil {
+        pairs = append(pairs, fromProtoPair(resp.Pair))
+    }
+    for _, pair := range resp.History {
+        pairs = append(pairs, fromProtoPair(pair))
+    }
+    return pairs, nil
+}
+
+// localVersions 按请求读取本地的历史版本列表或某个时间点的版本
+func (s *RushKVServer) localVersions(req *proto.ReadReplicaRequest) ([]*storage.KVPair, error) {
+    if req.History {
+        return s.storage.History(req.Key, int(req.Limit))
+    }
+    
+    var pair *storage.KVPair
+    var err error
+    switch {
+    case req.AtVersion > 0:
+        pair, err = s.storage.GetPairAt(req.Key, req.AtVersion)
+    case req.AtTime > 0:
+        pair, err = s.storage.GetPairAtTime(req.Key, time.Unix(0, req.AtTime))
+    default:
+        pair, err = s.storage.GetPair(req.Key)
+    }
+    if err != nil || pair == nil {
+        return nil, err
+    }
+    return []*storage.KVPair{pair}, nil
+}
\ No newline at end of file
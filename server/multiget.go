@@ -0,0 +1,153 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "rushkv/proto"
+)
+
+func (s *RushKVServer) MultiGet(ctx context.Context, req *proto.MultiGetRequest) (*proto.MultiGetResponse, error) {
+    for _, key := range req.Keys {
+        if key == "" {
+            return &proto.MultiGetResponse{
+                Success: false,
+                Error:   "empty key",
+            }, nil
+        }
+    }
+    
+    return &proto.MultiGetResponse{
+        Success: true,
+        Results: s.multiGet(ctx, req),
+    }, nil
+}
+
+// multiGet 按协调节点对key分组执行。转发因本节点集群版本落后被拒绝的key，在追赶到新版本后按新环重新分组执行一次
+func (s *RushKVServer) multiGet(ctx context.Context, req *proto.MultiGetRequest) []*proto.MultiGetResult {
+    results := make([]*proto.MultiGetResult, len(req.Keys))
+    pending := make([]int, len(req.Keys))
+    for i := range pending {
+        pending[i] = i
+    }
+    s.withRingRetry(func() bool {
+        pending = s.multiGetRound(ctx, req, pending, results)
+        return len(pending) == 0
+    })
+    return results
+}
+
+// multiGetRound 对pending中的key分组并发查询，本节点是副本的key直接在本地协调读取，
+// 其余key转发给第一个存活的副本。返回因集群版本落后被拒绝的key
+func (s *RushKVServer) multiGetRound(ctx context.Context, req *proto.MultiGetRequest, pending []int, results []*proto.MultiGetResult) []int {
+    groups := make(map[string][]int)
+    for _, i := range pending {
+        replicas := s.replicasFor(req.Keys[i])
+        nodeID := s.casCoordinator(replicas)
+        if containsNode(replicas, s.nodeID) {
+            nodeID = s.nodeID
+        }
+        groups[nodeID] = append(groups[nodeID], i)
+    }
+    
+    var mutex sync.Mutex
+    var rejected []int
+    var wg sync.WaitGroup
+    for nodeID, indexes := range groups {
+        keys := make([]string, 0, len(indexes))
+        for _, i := range indexes {
+            keys = append(keys, req.Keys[i])
+        }
+        
+        wg.Add(1)
+        go func(nodeID string, indexes []int, keys []string) {
+            defer wg.Done()
+            var groupResults []*proto.MultiGetResult
+            stale := false
+            switch {
+            case nodeID == "":
+                groupResults = failedMultiGet(keys, "no available nodes")
+            case nodeID == s.nodeID || req.Hops >= maxForwardHops:
+                groupResults = s.localMultiGet(ctx, keys, req.Consistency)
+            default:
+                groupResults, stale = s.forwardMultiGet(ctx, nodeID, keys, req)
+            }
+            for j, i := range indexes {
+                results[i] = groupResults[j]
+            }
+            if stale {
+                mutex.Lock()
+                rejected = append(rejected, indexes...)
+                mutex.Unlock()
+            }
+        }(nodeID, indexes, keys)
+    }
+    wg.Wait()
+    
+    return rejected
+}
+
+// localMultiGet 以本节点为协调者并发读取各个key
+func (s *RushKVServer) localMultiGet(ctx context.Context, keys []string, level proto.ConsistencyLevel) []*proto.MultiGetResult {
+    results := make([]*proto.MultiGetResult, len(keys))
+    var wg sync.WaitGroup
+    for i, key := range keys {
+        wg.Add(1)
+        go func(i int, key string) {
+            defer wg.Done()
+            result := &proto.MultiGetResult{Key: key}
+            pair, err := s.readKey(ctx, key, s.replicasFor(key), level)
+            switch {
+            case err != nil:
+                result.Error = err.Error()
+            case pair != nil:
+                result.Found = true
+                result.Value = pair.Value
+                result.Version = pair.Version
+                result.ExpiresAt = expiresAt(pair)
+            }
+            results[i] = result
+        }(i, key)
+    }
+    wg.Wait()
+    
+    return results
+}
+
+// forwardMultiGet 将一组key转发给它们的副本节点读取，对方因本节点集群版本落后拒绝时stale为true
+func (s *RushKVServer) forwardMultiGet(ctx context.Context, nodeID string, keys []string, req *proto.MultiGetRequest) ([]*proto.MultiGetResult, bool) {
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return failedMultiGet(keys, err.Error()), false
+    }
+    resp, err := peer.MultiGet(ctx, &proto.MultiGetRequest{
+        Keys:        keys,
+        Hops:        req.Hops + 1,
+        Consistency: req.Consistency,
+    })
+    if err != nil {
+        return failedMultiGet(keys, fmt.Sprintf("failed to forward to node %s: %v", nodeID, err)), status.Code(err) == codes.FailedPrecondition
+    }
+    if !resp.Success {
+        return failedMultiGet(keys, resp.Error), false
+    }
+    if len(resp.Results) != len(keys) {
+        return failedMultiGet(keys, fmt.Sprintf("node %s returned %d results for %d keys", nodeID, len(resp.Results), len(keys))), false
+    }
+    return resp.Results, false
+}
+
+// failedMultiGet 返回所有key都以相同错误失败的结果
+func failedMultiGet(keys []string, err string) []*proto.MultiGetResult {
+    results := make([]*proto.MultiGetResult, 0, len(keys))
+    for _, key := range keys {
+        results = append(results, &proto.MultiGetResult{
+            Key:   key,
+            Error: err,
+        })
+    }
+    return results
+}
\ No newline at end of file
@@ -0,0 +1,360 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "log"
+    "sync"
+    "time"
+    
+    "rushkv/hash"
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+const (
+    // 每批扫描的本地记录数
+    rebalanceBatchSize = 100
+    // 单批数据迁移的超时时间
+    handoffTimeout = 30 * time.Second
+    // 本节点迁移完成后仍从旧副本回退读取的时间，等待其他节点完成迁移
+    handoffGrace = 30 * time.Second
+)
+
+// rebalancer 在哈希环变化后把负责节点发生变化的数据迁移到新副本
+type rebalancer struct {
+    mutex      sync.Mutex
+    pendingOld *hash.ConsistentHash
+    pendingNew *hash.ConsistentHash
+    notify     chan struct{}
+    stopCh     chan struct{}
+    
+    // 交接期间的各个旧哈希环，新副本上读不到数据时回退到旧副本
+    previous     []*hash.ConsistentHash
+    handoffUntil time.Time // 为零表示迁移仍在进行
+    
+    status rebalanceStatus
+}
+
+type rebalanceStatus struct {
+    active          bool
+    movedRanges     int
+    keysTotal       int64
+    keysScanned     int64
+    keysTransferred int64
+    startedAt       time.Time
+    finishedAt      time.Time
+}
+
+func newRebalancer() *rebalancer {
+    return &rebalancer{
+        notify: make(chan struct{}, 1),
+        stopCh: make(chan struct{}),
+    }
+}
+
+// scheduleRebalance 记录一次哈希环变化，尚未开始的连续变化会合并为一次迁移
+func (s *RushKVServer) scheduleRebalance(oldRing, newRing *hash.ConsistentHash) {
+    r := s.rebalancer
+    r.mutex.Lock()
+    if r.pendingOld == nil {
+        r.pendingOld = oldRing
+    }
+    r.pendingNew = newRing
+    r.previous = append(r.previous, oldRing)
+    r.handoffUntil = time.Time{}
+    r.mutex.Unlock()
+    
+    select {
+    case r.notify <- struct{}{}:
+    default:
+    }
+}
+
+func (s *RushKVServer) rebalanceLoop() {
+    r := s.rebalancer
+    for {
+        select {
+        case <-r.stopCh:
+            return
+        case <-r.notify:
+        }
+        
+        for {
+            r.mutex.Lock()
+            oldRing, newRing := r.pendingOld, r.pendingNew
+            r.pendingOld, r.pendingNew = nil, nil
+            r.mutex.Unlock()
+            if oldRing == nil {
+                break
+            }
+            s.rebalance(oldRing, newRing)
+        }
+        
+        r.mutex.Lock()
+        if r.pendingOld == nil {
+            r.handoffUntil = time.Now().Add(handoffGrace)
+        }
+        r.mutex.Unlock()
+    }
+}
+
+// rebalance 扫描本地数据，把落在迁移区间内的记录发送给新增的副本，
+// 本节点不再负责的记录在发送成功后删除
+func (s *RushKVServer) rebalance(oldRing, newRing *hash.ConsistentHash) {
+    moves := hash.MovedRanges(oldRing, newRing, s.config.ReplicationFactor)
+    total, _ := s.storage.Count()
+    
+    r := s.rebalancer
+    r.mutex.Lock()
+    r.status = rebalanceStatus{
+        active:      true,
+        movedRanges: len(moves),
+        keysTotal:   int64(total),
+        startedAt:   time.Now(),
+    }
+    r.mutex.Unlock()
+    
+    if len(moves) > 0 {
+        log.Printf("Rebalancing %d moved ranges (%d local keys)", len(moves), total)
+    }
+    
+    after := ""
+    for len(moves) > 0 {
+        select {
+        case <-r.stopCh:
+            return
+        default:
+        }
+        
+        pairs, err := s.storage.ListPairs(after, rebalanceBatchSize)
+        if err != nil {
+            log.Printf("Rebalance scan failed: %v", err)
+            break
+        }
+        if len(pairs) == 0 {
+            break
+        }
+        after = pairs[len(pairs)-1].Key
+        
+        transferred := s.rebalanceBatch(pairs, moves, newRing)
+        
+        r.mutex.Lock()
+        r.status.keysScanned += int64(len(pairs))
+        r.status.keysTransferred += int64(transferred)
+        r.mutex.Unlock()
+    }
+    
+    r.mutex.Lock()
+    r.status.active = false
+    r.status.finishedAt = time.Now()
+    transferred := r.status.keysTransferred
+    r.mutex.Unlock()
+    
+    if len(moves) > 0 {
+        log.Printf("Rebalance finished: %d keys transferred", transferred)
+    }
+}
+
+// rebalanceBatch 迁移一批记录，返回成功发送的记录数
+func (s *RushKVServer) rebalanceBatch(pairs []*storage.KVPair, moves []hash.RangeMove, newRing *hash.ConsistentHash) int {
+    type purge struct {
+        pair    *storage.KVPair
+        targets []string
+    }
+    
+    batches := make(map[string][]*proto.KVPair)
+    var purges []purge
+    for _, pair := range pairs {
+        move := findMove(moves, newRing.Hash(pair.Key))
+        if move == nil {
+            continue
+        }
+        
+        var targets []string
+        for _, nodeID := range move.NewReplicas {
+            if nodeID != s.nodeID && !containsNode(move.OldReplicas, nodeID) {
+                targets = append(targets, nodeID)
+                batches[nodeID] = append(batches[nodeID], toProtoPair(pair))
+            }
+        }
+        if !containsNode(move.NewReplicas, s.nodeID) {
+            purges = append(purges, purge{pair: pair, targets: targets})
+        }
+    }
+    
+    transferred := 0
+    failed := make(map[string]bool)
+    for nodeID, batch := range batches {
+        if err := s.handoffTo(nodeID, batch); err != nil {
+            log.Printf("Handoff to node %s failed: %v", nodeID, err)
+            failed[nodeID] = true
+            continue
+        }
+        transferred += len(batch)
+    }
+    
+    // 所有新副本都已收到后才删除本地不再负责的记录
+    for _, p := range purges {
+        ok := true
+        for _, nodeID := range p.targets {
+            if failed[nodeID] {
+                ok = false
+            }
+        }
+        if ok {
+            if err := s.storage.Purge(p.pair.Key, p.pair.Version); err != nil {
+                log.Printf("Failed to purge key %s: %v", p.pair.Key, err)
+            }
+        }
+    }
+    
+    return transferred
+}
+
+// idle 判断是否没有进行中或等待中的迁移
+func (r *rebalancer) idle() bool {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    return r.pendingOld == nil && !r.status.active && !r.handoffUntil.IsZero()
+}
+
+// handoffOnLeave 主动离开集群后把本地数据交给新的副本，
+// 被移除的节点未必能收到移除自己的配置提交，因此不等待Raft日志
+func (s *RushKVServer) handoffOnLeave() {
+    s.mutex.RLock()
+    oldRing := s.hash.Clone()
+    s.mutex.RUnlock()
+    
+    newRing := oldRing.Clone()
+    newRing.RemoveNode(s.nodeID)
+    s.scheduleRebalance(oldRing, newRing)
+    
+    deadline := time.Now().Add(handoffTimeout)
+    for !s.rebalancer.idle() && time.Now().Before(deadline) {
+        time.Sleep(100 * time.Millisecond)
+    }
+}
+
+// stopRebalance 停止迁移任务
+func (s *RushKVServer) stopRebalance() {
+    close(s.rebalancer.stopCh)
+}
+
+// handoffTo 通过流式RPC把一批记录发送给目标节点
+func (s *RushKVServer) handoffTo(nodeID string, pairs []*proto.KVPair) error {
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return err
+    }
+    
+    ctx, cancel := context.WithTimeout(context.Background(), handoffTimeout)
+    defer cancel()
+    
+    stream, err := peer.Handoff(ctx)
+    if err != nil {
+        return err
+    }
+    if err := stream.Send(&proto.HandoffRequest{Pairs: pairs}); err != nil {
+        return err
+    }
+    resp, err := stream.CloseAndRecv()
+    if err != nil {
+        return err
+    }
+    if !resp.Success {
+        return fmt.Errorf("%s", resp.Error)
+    }
+    return nil
+}
+
+func (s *RushKVServer) Handoff(stream proto.RushKV_HandoffServer) error {
+    var received int64
+    for {
+        req, err := stream.Recv()
+        if err == io.EOF {
+            return stream.SendAndClose(&proto.HandoffResponse{
+                Success:  true,
+                Received: received,
+            })
+        }
+        if err != nil {
+            return err
+        }
+        
+        for _, pair := range req.Pairs {
+            if _, err := s.applyPair(fromProtoPair(pair)); err != nil {
+                return stream.SendAndClose(&proto.HandoffResponse{
+                    Success:  false,
+                    Received: received,
+                    Error:    err.Error(),
+                })
+            }
+            received++
+        }
+    }
+}
+
+// handoffReplicas 交接期间返回key在旧哈希环上、但不在replicas中的副本
+func (s *RushKVServer) handoffReplicas(key string, replicas []string) []string {
+    r := s.rebalancer
+    r.mutex.Lock()
+    if r.previous != nil && !r.handoffUntil.IsZero() && time.Now().After(r.handoffUntil) {
+        r.previous = nil
+    }
+    previous := r.previous
+    r.mutex.Unlock()
+    
+    var nodes []string
+    for i := len(previous) - 1; i >= 0; i-- {
+        for _, nodeID := range previous[i].GetReplicas(key, s.config.ReplicationFactor) {
+            if !containsNode(replicas, nodeID) && !containsNode(nodes, nodeID) {
+                nodes = append(nodes, nodeID)
+            }
+        }
+    }
+    return nodes
+}
+
+// readHandoff 新副本上没有数据时，从尚未完成迁移的旧副本读取
+func (s *RushKVServer) readHandoff(ctx context.Context, key string, replicas []string) *storage.KVPair {
+    // 旧副本可能已把数据交出并删除，依次读取直到找到为止
+    for _, nodeID := range s.handoffReplicas(key, replicas) {
+        if pair, err := s.readReplica(ctx, nodeID, key); err == nil && pair != nil {
+            return pair
+        }
+    }
+    return nil
+}
+
+// rebalanceStatus 返回本节点的迁移进度
+func (s *RushKVServer) rebalanceStatus() *proto.RebalanceStatus {
+    r := s.rebalancer
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+    
+    status := &proto.RebalanceStatus{
+        Active:          r.status.active,
+        MovedRanges:     int32(r.status.movedRanges),
+        KeysTotal:       r.status.keysTotal,
+        KeysScanned:     r.status.keysScanned,
+        KeysTransferred: r.status.keysTransferred,
+    }
+    if !r.status.startedAt.IsZero() {
+        status.StartedAt = r.status.startedAt.Unix()
+    }
+    if !r.status.finishedAt.IsZero() {
+        status.FinishedAt = r.status.finishedAt.Unix()
+    }
+    return status
+}
+
+func findMove(moves []hash.RangeMove, h int) *hash.RangeMove {
+    for i := range moves {
+        if moves[i].Range.Contains(h) {
+            return &moves[i]
+        }
+    }
+    return nil
+}
\ No newline at end of file
@@ -0,0 +1,72 @@
+package server
+
+import (
+    "context"
+    "log"
+    "sync"
+    "sync/atomic"
+    
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+// readRepairStats 读修复计数，用于观察副本间的偏差程度
+type readRepairStats struct {
+    reads    int64
+    replicas int64
+    failures int64
+}
+
+// repairReplicas 把最新记录写回返回旧版本或缺少记录的副本，
+// responses只包含本次读请求已响应的副本
+func (s *RushKVServer) repairReplicas(newest *storage.KVPair, responses map[string]*storage.KVPair) {
+    if newest == nil || s.config.ReadRepair == ReadRepairOff {
+        return
+    }
+    
+    var stale []string
+    for nodeID, pair := range responses {
+        if pair == nil || pair.Version < newest.Version {
+            stale = append(stale, nodeID)
+        }
+    }
+    if len(stale) == 0 {
+        return
+    }
+    atomic.AddInt64(&s.repairs.reads, 1)
+    
+    repair := func() {
+        ctx, cancel := context.WithTimeout(context.Background(), replicaTimeout)
+        defer cancel()
+        
+        var wg sync.WaitGroup
+        for _, nodeID := range stale {
+            wg.Add(1)
+            go func(nodeID string) {
+                defer wg.Done()
+                if err := s.writeReplica(ctx, nodeID, newest); err != nil {
+                    atomic.AddInt64(&s.repairs.failures, 1)
+                    log.Printf("Read repair of key %s on node %s failed: %v", newest.Key, nodeID, err)
+                    return
+                }
+                atomic.AddInt64(&s.repairs.replicas, 1)
+            }(nodeID)
+        }
+        wg.Wait()
+    }
+    
+    if s.config.ReadRepair == ReadRepairSync {
+        repair()
+    } else {
+        go repair()
+    }
+}
+
+// readRepairStatus 返回本节点的读修复计数
+func (s *RushKVServer) readRepairStatus() *proto.ReadRepairStats {
+    return &proto.ReadRepairStats{
+        Reads:    atomic.LoadInt64(&s.repairs.reads),
+        Replicas: atomic.LoadInt64(&s.repairs.replicas),
+        Failures: atomic.LoadInt64(&s.repairs.failures),
+    }
+}
\ No newline at end of file
@@ -0,0 +1,366 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+    
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+// replicasFor 返回key的副本节点列表，第一个为主节点
+func (s *RushKVServer) replicasFor(key string) []string {
+    return s.hash.GetReplicas(key, s.config.ReplicationFactor)
+}
+
+// 副本间RPC的超时时间，达到一致性级别后剩余的副本写入仍在后台继续
+const replicaTimeout = 3 * time.Second
+
+// requiredAcks 根据一致性级别计算需要等待的副本响应数
+func (s *RushKVServer) requiredAcks(level proto.ConsistencyLevel, replicas int) int {
+    if level == proto.ConsistencyLevel_DEFAULT {
+        level = s.config.DefaultConsistency
+    }
+    
+    switch level {
+    case proto.ConsistencyLevel_ONE:
+        return 1
+    case proto.ConsistencyLevel_ALL:
+        return replicas
+    default:
+        return replicas/2 + 1
+    }
+}
+
+// replicateWrite 将记录写入所有副本节点，收到required个确认后即返回成功，
+// 不可用的副本保存提示待恢复后重放，但不计入确认
+func (s *RushKVServer) replicateWrite(ctx context.Context, pair *storage.KVPair, replicas []string, required int) error {
+    // 副本写入不随请求取消，保证已返回后其余副本仍能收到数据
+    replicaCtx, cancel := context.WithTimeout(context.Background(), replicaTimeout)
+    
+    errs := make(chan error, len(replicas))
+    var wg sync.WaitGroup
+    for _, nodeID := range replicas {
+        wg.Add(1)
+        go func(nodeID string) {
+            defer wg.Done()
+            errs <- s.writeOrHint(replicaCtx, nodeID, pair)
+        }(nodeID)
+    }
+    go func() {
+        wg.Wait()
+        cancel()
+    }()
+    
+    acks, failures := 0, 0
+    var lastErr error
+    for range replicas {
+        select {
+        case err := <-errs:
+            if err != nil {
+                failures++
+                lastErr = err
+            } else {
+                acks++
+            }
+        case <-ctx.Done():
+            return ctx.Err()
+        }
+        
+        if acks >= required {
+            return nil
+        }
+        if len(replicas)-failures < required {
+            break
+        }
+    }
+    
+    return fmt.Errorf("only %d of %d required replicas acknowledged: %v", acks, required, lastErr)
+}
+
+// replicatePairs 把一批记录按副本节点分组，每个节点的记录通过一次请求在一个存储事务中写入，
+// 每条记录都收到一致性级别要求的确认后返回成功
+func (s *RushKVServer) replicatePairs(ctx context.Context, pairs []*storage.KVPair, level proto.ConsistencyLevel) error {
+    batches := make(map[string][]*storage.KVPair)
+    needed := make(map[string]int, len(pairs))
+    for _, pair := range pairs {
+        replicas := s.replicasFor(pair.Key)
+        needed[pair.Key] = s.requiredAcks(level, len(replicas))
+        for _, nodeID := range replicas {
+            batches[nodeID] = append(batches[nodeID], pair)
+        }
+    }
+    
+    replicaCtx, cancel := context.WithTimeout(context.Background(), replicaTimeout)
+    
+    type result struct {
+        nodeID string
+        err    error
+    }
+    results := make(chan result, len(batches))
+    var wg sync.WaitGroup
+    for nodeID, batch := range batches {
+        wg.Add(1)
+        go func(nodeID string, batch []*storage.KVPair) {
+            defer wg.Done()
+            results <- result{nodeID: nodeID, err: s.writeBatchOrHint(replicaCtx, nodeID, batch)}
+        }(nodeID, batch)
+    }
+    go func() {
+        wg.Wait()
+        cancel()
+    }()
+    
+    pending := len(needed)
+    var lastErr error
+    for range batches {
+        select {
+        case r := <-results:
+            if r.err != nil {
+                lastErr = r.err
+                continue
+            }
+            for _, pair := range batches[r.nodeID] {
+                needed[pair.Key]--
+                if needed[pair.Key] == 0 {
+                    pending--
+                }
+            }
+        case <-ctx.Done():
+            return ctx.Err()
+        }
+        
+        if pending == 0 {
+            return nil
+        }
+    }
+    
+    return fmt.Errorf("%d keys were not acknowledged by enough replicas: %v", pending, lastErr)
+}
+
+// readReplicas 并发读取各副本，收到required个响应后返回版本最新的记录
+// responses记录每个已响应副本返回的记录（不存在时为nil）
+func (s *RushKVServer) readReplicas(ctx context.Context, key string, replicas []string, required int) (*storage.KVPair, map[string]*storage.KVPair, error) {
+    type result struct {
+        nodeID string
+        pair   *storage.KVPair
+        err    error
+    }
+    
+    results := make(chan result, len(replicas))
+    for _, nodeID := range replicas {
+        go func(nodeID string) {
+            pair, err := s.readReplica(ctx, nodeID, key)
+            results <- result{nodeID: nodeID, pair: pair, err: err}
+        }(nodeID)
+    }
+    
+    responses := make(map[string]*storage.KVPair)
+    var newest *storage.KVPair
+    failures := 0
+    var lastErr error
+    for range replicas {
+        r := <-results
+        if r.err != nil {
+            failures++
+            lastErr = r.err
+        } else {
+            responses[r.nodeID] = r.pair
+            // 以版本号解决副本间的冲突
+            if r.pair != nil && (newest == nil || r.pair.Version > newest.Version) {
+                newest = r.pair
+            }
+        }
+        
+        if len(responses) >= required {
+            return newest, responses, nil
+        }
+        if len(replicas)-failures < required {
+            break
+        }
+    }
+    
+    return nil, responses, fmt.Errorf("only %d of %d required replicas responded: %v", len(responses), required, lastErr)
+}
+
+// readReplica 读取单个副本上的记录
+func (s *RushKVServer) readReplica(ctx context.Context, nodeID string, key string) (*storage.KVPair, error) {
+    if nodeID == s.nodeID {
+        return s.storage.GetPair(key)
+    }
+    
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return nil, err
+    }
+    
+    resp, err := peer.ReadReplica(ctx, &proto.ReadReplicaRequest{
+        Key: key,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("read from node %s failed: %v", nodeID, err)
+    }
+    if !resp.Success {
+        return nil, fmt.Errorf("read from node %s failed: %s", nodeID, resp.Error)
+    }
+    if resp.Pair == nil {
+        return nil, nil
+    }
+    return fromProtoPair(resp.Pair), nil
+}
+
+// writeReplica 将记录写入单个副本节点
+func (s *RushKVServer) writeReplica(ctx context.Context, nodeID string, pair *storage.KVPair) error {
+    if nodeID == s.nodeID {
+        _, err := s.applyPair(pair)
+        return err
+    }
+    
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return err
+    }
+    
+    resp, err := peer.Replicate(ctx, &proto.ReplicateRequest{
+        Pair: toProtoPair(pair),
+    })
+    if err != nil {
+        return fmt.Errorf("replication to node %s failed: %w", nodeID, err)
+    }
+    if !resp.Success {
+        return fmt.Errorf("replication to node %s failed: %s", nodeID, resp.Error)
+    }
+    return nil
+}
+
+// writeBatch 将一批记录写入单个副本节点，在该节点上一个存储事务中生效
+func (s *RushKVServer) writeBatch(ctx context.Context, nodeID string, pairs []*storage.KVPair) error {
+    if nodeID == s.nodeID {
+        _, err := s.applyPairs(pairs)
+        return err
+    }
+    
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return err
+    }
+    
+    req := &proto.ReplicateRequest{}
+    for _, pair := range pairs {
+        req.Pairs = append(req.Pairs, toProtoPair(pair))
+    }
+    resp, err := peer.Replicate(ctx, req)
+    if err != nil {
+        return fmt.Errorf("replication to node %s failed: %w", nodeID, err)
+    }
+    if !resp.Success {
+        return fmt.Errorf("replication to node %s failed: %s", nodeID, resp.Error)
+    }
+    return nil
+}
+
+func (s *RushKVServer) Replicate(ctx context.Context, req *proto.ReplicateRequest) (*proto.ReplicateResponse, error) {
+    if len(req.Pairs) > 0 {
+        pairs := make([]*storage.KVPair, 0, len(req.Pairs))
+        for _, pair := range req.Pairs {
+            pairs = append(pairs, fromProtoPair(pair))
+        }
+        if _, err := s.applyPairs(pairs); err != nil {
+            return &proto.ReplicateResponse{
+                Success: false,
+                Error:   err.Error(),
+            }, nil
+        }
+        return &proto.ReplicateResponse{
+            Success: true,
+        }, nil
+    }
+    if req.Pair == nil {
+        return &proto.ReplicateResponse{
+            Success: false,
+            Error:   "missing pair",
+        }, nil
+    }
+    
+    if _, err := s.applyPair(fromProtoPair(req.Pair)); err != nil {
+        return &proto.ReplicateResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    
+    return &proto.ReplicateResponse{
+        Success: true,
+    }, nil
+}
+
+func (s *RushKVServer) ReadReplica(ctx context.Context, req *proto.ReadReplicaRequest) (*proto.ReadReplicaResponse, error) {
+    if req.History || req.AtVersion > 0 || req.AtTime > 0 {
+        pairs, err := s.localVersions(req)
+        if err != nil {
+            return &proto.ReadReplicaResponse{
+                Success: false,
+                Error:   err.Error(),
+            }, nil
+        }
+        
+        resp := &proto.ReadReplicaResponse{
+            Success: true,
+        }
+        for _, pair := range pairs {
+            resp.History = append(resp.History, toProtoPair(pair))
+        }
+        return resp, nil
+    }
+    
+    pair, err := s.storage.GetPair(req.Key)
+    if err != nil {
+        return &proto.ReadReplicaResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    
+    resp := &proto.ReadReplicaResponse{
+        Success: true,
+    }
+    if pair != nil {
+        resp.Pair = toProtoPair(pair)
+    }
+    return resp, nil
+}
+
+func toProtoPair(pair *storage.KVPair) *proto.KVPair {
+    return &proto.KVPair{
+        Key:       pair.Key,
+        Value:     pair.Value,
+        Version:   pair.Version,
+        Timestamp: pair.Timestamp.UnixNano(),
+        Deleted:   pair.Deleted,
+        ExpiresAt: expiresAt(pair),
+    }
+}
+
+func fromProtoPair(pair *proto.KVPair) *storage.KVPair {
+    result := &storage.KVPair{
+        Key:       pair.Key,
+        Value:     pair.Value,
+        Version:   pair.Version,
+        Timestamp: time.Unix(0, pair.Timestamp),
+        Deleted:   pair.Deleted,
+    }
+    if pair.ExpiresAt != 0 {
+        result.ExpiresAt = time.Unix(0, pair.ExpiresAt)
+    }
+    return result
+}
+
+// expiresAt 返回记录的过期时间（Unix纳秒），不过期时为0
+func expiresAt(pair *storage.KVPair) int64 {
+    if pair.ExpiresAt.IsZero() {
+        return 0
+    }
+    return pair.ExpiresAt.UnixNano()
+}
\ No newline at end of file
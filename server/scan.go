@@ -0,0 +1,254 @@
+package server
+
+import (
+    "container/heap"
+    "context"
+    "fmt"
+    "io"
+    "log"
+    "time"
+    
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+// 每次从本地存储读取的记录数
+const scanBatchSize = 100
+
+// Scan 按key顺序流式返回范围内的记录。协调节点向所有节点发起本地扫描，
+// 按key归并各节点的有序结果，同一key取版本最新的记录并跳过墓碑
+func (s *RushKVServer) Scan(req *proto.ScanRequest, stream proto.RushKV_ScanServer) error {
+    start, end := scanBounds(req)
+    if end != "" && start >= end {
+        return nil
+    }
+    
+    if req.Local {
+        next := s.localScan(start, end, req.Reverse)
+        for sent := 0; req.Limit <= 0 || sent < int(req.Limit); sent++ {
+            pair, err := next()
+            if err != nil {
+                return err
+            }
+            if pair == nil {
+                return nil
+            }
+            if err := stream.Send(pair); err != nil {
+                return err
+            }
+        }
+        return nil
+    }
+    
+    ctx, cancel := context.WithCancel(stream.Context())
+    defer cancel()
+    
+    sources, tolerated := s.scanSources(ctx, start, end, req.Reverse)
+    merged := &scanHeap{reverse: req.Reverse}
+    failures := 0
+    for _, src := range sources {
+        if err := src.advance(); err != nil {
+            failures++
+            log.Printf("Scan on node %s failed: %v", src.nodeID, err)
+            continue
+        }
+        if src.head != nil {
+            merged.sources = append(merged.sources, src)
+        }
+    }
+    if failures > tolerated {
+        return fmt.Errorf("scan failed on %d nodes", failures)
+    }
+    heap.Init(merged)
+    
+    now := time.Now().UnixNano()
+    sent := 0
+    for merged.Len() > 0 {
+        key := merged.sources[0].head.Key
+        var newest *proto.KVPair
+        for merged.Len() > 0 && merged.sources[0].head.Key == key {
+            src := merged.sources[0]
+            if newest == nil || src.head.Version > newest.Version {
+                newest = src.head
+            }
+            
+            if err := src.advance(); err != nil {
+                failures++
+                log.Printf("Scan on node %s failed: %v", src.nodeID, err)
+                if failures > tolerated {
+                    return fmt.Errorf("scan failed on %d nodes", failures)
+                }
+                src.head = nil
+            }
+            if src.head == nil {
+                heap.Pop(merged)
+            } else {
+                heap.Fix(merged, 0)
+            }
+        }
+        
+        if newest.Deleted || (newest.ExpiresAt != 0 && newest.ExpiresAt <= now) {
+            continue
+        }
+        if err := stream.Send(newest); err != nil {
+            return err
+        }
+        sent++
+        if req.Limit > 0 && sent >= int(req.Limit) {
+            break
+        }
+    }
+    
+    return nil
+}
+
+// scanSources 为每个节点创建一个有序的记录来源，并返回最多允许失败的节点数：
+// 每个key有N个副本，少于N个节点失败时每个key至少有一个副本参与归并
+func (s *RushKVServer) scanSources(ctx context.Context, start, end string, reverse bool) ([]*scanSource, int) {
+    s.mutex.RLock()
+    nodeIDs := make([]string, 0, len(s.nodes))
+    for nodeID := range s.nodes {
+        nodeIDs = append(nodeIDs, nodeID)
+    }
+    s.mutex.RUnlock()
+    
+    tolerated := s.config.ReplicationFactor - 1
+    if tolerated > len(nodeIDs)-1 {
+        tolerated = len(nodeIDs) - 1
+    }
+    
+    sources := make([]*scanSource, 0, len(nodeIDs))
+    for _, nodeID := range nodeIDs {
+        if nodeID == s.nodeID {
+            sources = append(sources, &scanSource{nodeID: nodeID, next: s.localScan(start, end, reverse)})
+            continue
+        }
+        sources = append(sources, &scanSource{nodeID: nodeID, next: s.remoteScan(ctx, nodeID, start, end, reverse)})
+    }
+    return sources, tolerated
+}
+
+// localScan 返回按批读取本地存储的迭代函数，读完时返回nil
+func (s *RushKVServer) localScan(start, end string, reverse bool) func() (*proto.KVPair, error) {
+    var batch []*storage.KVPair
+    done := false
+    return func() (*proto.KVPair, error) {
+        if len(batch) == 0 && !done {
+            pairs, err := s.storage.ScanPairs(start, end, reverse, scanBatchSize)
+            if err != nil {
+                return nil, err
+            }
+            if len(pairs) < scanBatchSize {
+                done = true
+            }
+            if len(pairs) > 0 {
+                // 下一批从本批最后一个key之后继续
+                last := pairs[len(pairs)-1].Key
+                if reverse {
+                    end = last
+                } else {
+                    start = last + "\x00"
+                }
+            }
+            batch = pairs
+        }
+        if len(batch) == 0 {
+            return nil, nil
+        }
+        
+        pair := batch[0]
+        batch = batch[1:]
+        return toProtoPair(pair), nil
+    }
+}
+
+// remoteScan 返回读取其他节点本地扫描结果的迭代函数，流结束时返回nil
+func (s *RushKVServer) remoteScan(ctx context.Context, nodeID, start, end string, reverse bool) func() (*proto.KVPair, error) {
+    var stream proto.RushKV_ScanClient
+    return func() (*proto.KVPair, error) {
+        if stream == nil {
+            peer, err := s.peerClient(nodeID)
+            if err != nil {
+                return nil, err
+            }
+            stream, err = peer.Scan(ctx, &proto.ScanRequest{
+                Start:   start,
+                End:     end,
+                Reverse: reverse,
+                Local:   true,
+            })
+            if err != nil {
+                return nil, err
+            }
+        }
+        
+        pair, err := stream.Recv()
+        if err == io.EOF {
+            return nil, nil
+        }
+        return pair, err
+    }
+}
+
+// scanBounds 合并请求的范围和前缀，返回[start, end)，end为空表示不设上界
+func scanBounds(req *proto.ScanRequest) (string, string) {
+    start, end := req.Start, req.End
+    if req.Prefix == "" {
+        return start, end
+    }
+    
+    if req.Prefix > start {
+        start = req.Prefix
+    }
+    if prefixEnd := storage.PrefixEnd(req.Prefix); prefixEnd != "" && (end == "" || prefixEnd < end) {
+        end = prefixEnd
+    }
+    return start, end
+}
+
+// scanSource 一个节点的有序记录流，head为当前记录
+type scanSource struct {
+    nodeID string
+    next   func() (*proto.KVPair, error)
+    head   *proto.KVPair
+}
+
+func (src *scanSource) advance() error {
+    pair, err := src.next()
+    if err != nil {
+        return err
+    }
+    src.head = pair
+    return nil
+}
+
+// scanHeap 按各来源当前记录的key排序，reverse时key大的在前
+type scanHeap struct {
+    sources []*scanSource
+    reverse bool
+}
+
+func (h *scanHeap) Len() int {
+    return len(h.sources)
+}
+
+func (h *scanHeap) Less(i, j int) bool {
+    if h.reverse {
+        return h.sources[i].head.Key > h.sources[j].head.Key
+    }
+    return h.sources[i].head.Key < h.sources[j].head.Key
+}
+
+func (h *scanHeap) Swap(i, j int) {
+    h.sources[i], h.sources[j] = h.sources[j], h.sources[i]
+}
+
+func (h *scanHeap) Push(x interface{}) {
+    h.sources = append(h.sources, x.(*scanSource))
+}
+
+func (h *scanHeap) Pop() interface{} {
+    last := h.sources[len(h.sources)-1]
+    h.sources = h.sources[:len(h.sources)-1]
+    return last
+}
\ No newline at end of file
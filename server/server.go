@@ -5,143 +5,315 @@ import (
     "fmt"
     "log"
     "net"
+    "path/filepath"
+    "strconv"
     "sync"
+    "sync/atomic"
+    "time"
     
     "google.golang.org/grpc"
     "rushkv/hash"
+    "rushkv/hlc"
     "rushkv/proto"
+    "rushkv/raft"
     "rushkv/storage"
+    "rushkv/swim"
 )
 
 type RushKVServer struct {
     proto.UnimplementedRushKVServer
     
-    nodeID      string
-    address     string
-    port        int
-    storage     *storage.StorageEngine
-    hash        *hash.ConsistentHash
-    nodes       map[string]*proto.NodeInfo
-    isLeader    bool
-    mutex       sync.RWMutex
-    grpcServer  *grpc.Server
+    config     *Config
+    nodeID     string
+    address    string
+    port       int
+    storage    storage.Engine
+    hash       *hash.ConsistentHash
+    nodes      map[string]*proto.NodeInfo
+    mutex      sync.RWMutex
+    grpcServer *grpc.Server
+    peers      map[string]*grpc.ClientConn
+    peerMutex  sync.Mutex
+    
+    // 成员信息通过Raft日志在所有节点间保持一致
+    raft            *raft.Node
+    raftStore       *raft.Store
+    membershipIndex uint64 // 已应用的成员配置所在的日志索引，即集群状态版本
+    // 每次应用新的成员配置时关闭并替换
+    membershipChanged chan struct{}
+    stopCh            chan struct{}
+    
+    // 哈希环变化后的数据迁移
+    rebalancer *rebalancer
+    // 故障检测，死亡节点由leader移出集群
+    detector *swim.Detector
+    // 不可用副本的提示重放
+    hints *hintReplayer
+    // 读修复计数
+    repairs readRepairStats
+    // 副本间的反熵同步
+    antiEntropy *antiEntropy
+    // 条件写入和事务在协调节点上按key串行执行
+    casLocks [casLockStripes]sync.Mutex
+    // 两阶段提交中已锁定key的事务
+    txns *txnManager
+    // 同时只进行一次垃圾清理
+    gcMutex sync.Mutex
+    // 最近写入的变更，供Watch补发和推送
+    changes *changeLog
+    // 导出变更日志的goroutine退出时关闭，未配置导出目录时为nil
+    changeSinkDone chan struct{}
+    // 生成记录版本的混合逻辑时钟，随节点间RPC与其他节点同步
+    clock *hlc.Clock
+    // 上一次打印时钟偏差警告的时间，Unix纳秒
+    clockWarned atomic.Int64
 }
 
-func NewRushKVServer(nodeID, address string, port int, dataPath string) (*RushKVServer, error) {
-    storageEngine, err := storage.NewStorageEngine(dataPath)
+func NewRushKVServer(config *Config) (*RushKVServer, error) {
+    if config.ReplicationFactor < 1 {
+        return nil, fmt.Errorf("replication factor must be at least 1")
+    }
+    
+    storageEngine, err := storage.Open(config.Engine, config.DataPath)
     if err != nil {
         return nil, fmt.Errorf("failed to create storage engine: %v", err)
     }
     
-    return &RushKVServer{
-        nodeID:   nodeID,
-        address:  address,
-        port:     port,
-        storage:  storageEngine,
-        hash:     hash.NewConsistentHash(3), // 每个节点3个虚拟节点
-        nodes:    make(map[string]*proto.NodeInfo),
-        isLeader: true, // 简化实现，第一个节点为leader
-    }, nil
+    storageEngine.SetHistoryVersions(config.HistoryVersions)
+    if config.TombstoneGrace > 0 && config.TombstoneGrace < config.HintTTL {
+        log.Printf("Warning: tombstone grace %v is shorter than hint TTL %v, replayed hints may bring deleted keys back", config.TombstoneGrace, config.HintTTL)
+    }
+    
+    raftStore, err := raft.OpenStore(filepath.Join(config.DataPath, "raft.db"))
+    if err != nil {
+        storageEngine.Close()
+        return nil, err
+    }
+    
+    s := &RushKVServer{
+        config:    config,
+        nodeID:    config.NodeID,
+        address:   config.Address,
+        port:      config.Port,
+        storage:   storageEngine,
+        hash:      hash.NewConsistentHash(3), // 每个节点3个虚拟节点
+        nodes:     make(map[string]*proto.NodeInfo),
+        peers:     make(map[string]*grpc.ClientConn),
+        raftStore: raftStore,
+        
+        membershipChanged: make(chan struct{}),
+        stopCh:            make(chan struct{}),
+        rebalancer:        newRebalancer(),
+        hints:             &hintReplayer{running: make(map[string]bool)},
+        antiEntropy:       newAntiEntropy(config.AntiEntropyRate),
+        txns:              newTxnManager(),
+        changes:           newChangeLog(config.WatchLogSize),
+        clock:             hlc.New(config.MaxClockOffset),
+    }
+    
+    s.detector = swim.NewDetector(swim.DefaultConfig(config.NodeID), &swimTransport{s: s}, s.onMemberChange)
+    s.raft, err = raft.NewNode(raft.DefaultConfig(config.NodeID), raftStore, &raftTransport{s: s}, s.applyEntry)
+    if err != nil {
+        raftStore.Close()
+        storageEngine.Close()
+        return nil, err
+    }
+    
+    return s, nil
 }
 
 func (s *RushKVServer) Put(ctx context.Context, req *proto.PutRequest) (*proto.PutResponse, error) {
-    // 检查key应该存储在哪个节点
-    targetNode := s.hash.GetNode(req.Key)
-    if targetNode != s.nodeID {
-        // 转发到正确的节点（简化实现，这里直接返回错误）
-        return &proto.PutResponse{
-            Success: false,
-            Error:   fmt.Sprintf("key should be stored on node %s", targetNode),
-        }, nil
+    var resp *proto.PutResponse
+    s.withRingRetry(func() bool {
+        resp = s.put(ctx, req)
+        return resp.Success
+    })
+    return resp, nil
+}
+
+func (s *RushKVServer) put(ctx context.Context, req *proto.PutRequest) *proto.PutResponse {
+    // 检查key应该存储在哪些节点
+    replicas := s.replicasFor(req.Key)
+    if !containsNode(replicas, s.nodeID) {
+        // 本节点不是副本，转发到正确的节点
+        return s.forwardPut(ctx, req, replicas)
     }
     
-    err := s.storage.Put(req.Key, req.Value)
-    if err != nil {
+    // 本节点作为协调者，将写入同步到所有副本
+    pair := s.newPair(req.Key, req.Value)
+    if req.TtlSeconds > 0 {
+        pair.ExpiresAt = pair.Timestamp.Add(time.Duration(req.TtlSeconds) * time.Second)
+    }
+    required := s.requiredAcks(req.Consistency, len(replicas))
+    if err := s.replicateWrite(ctx, pair, replicas, required); err != nil {
         return &proto.PutResponse{
             Success: false,
             Error:   err.Error(),
-        }, nil
+        }
     }
     
     return &proto.PutResponse{
         Success: true,
-    }, nil
+    }
 }
 
 func (s *RushKVServer) Get(ctx context.Context, req *proto.GetRequest) (*proto.GetResponse, error) {
-    targetNode := s.hash.GetNode(req.Key)
-    if targetNode != s.nodeID {
-        return &proto.GetResponse{
-            Success: false,
-            Error:   fmt.Sprintf("key should be retrieved from node %s", targetNode),
-        }, nil
+    var resp *proto.GetResponse
+    s.withRingRetry(func() bool {
+        resp = s.get(ctx, req)
+        return resp.Success
+    })
+    return resp, nil
+}
+
+func (s *RushKVServer) get(ctx context.Context, req *proto.GetRequest) *proto.GetResponse {
+    replicas := s.replicasFor(req.Key)
+    if !containsNode(replicas, s.nodeID) {
+        return s.forwardGet(ctx, req, replicas)
+    }
+    if req.AtVersion > 0 || req.AtTime > 0 {
+        return s.getAt(ctx, req, replicas)
     }
     
-    value, err := s.storage.Get(req.Key)
+    pair, err := s.readKey(ctx, req.Key, replicas, req.Consistency)
     if err != nil {
         return &proto.GetResponse{
             Success: false,
             Error:   err.Error(),
-        }, nil
+        }
+    }
+    if pair == nil {
+        return &proto.GetResponse{
+            Success: false,
+            Error:   "key not found",
+        }
     }
     
     return &proto.GetResponse{
-        Success: true,
-        Value:   value,
-    }, nil
+        Success:   true,
+        Value:     pair.Value,
+        ExpiresAt: expiresAt(pair),
+        Version:   pair.Version,
+    }
+}
+
+// readKey 以本节点为协调者按一致性级别读取key并修复落后的副本，
+// key不存在、已删除或已过期时返回nil
+func (s *RushKVServer) readKey(ctx context.Context, key string, replicas []string, level proto.ConsistencyLevel) (*storage.KVPair, error) {
+    required := s.requiredAcks(level, len(replicas))
+    pair, responses, err := s.readReplicas(ctx, key, replicas, required)
+    if err != nil {
+        return nil, err
+    }
+    if pair == nil {
+        pair = s.readHandoff(ctx, key, replicas)
+    }
+    s.repairReplicas(pair, responses)
+    if pair == nil || pair.Deleted || pair.Expired(time.Now()) {
+        return nil, nil
+    }
+    return pair, nil
 }
 
 func (s *RushKVServer) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
-    targetNode := s.hash.GetNode(req.Key)
-    if targetNode != s.nodeID {
+    var resp *proto.DeleteResponse
+    s.withRingRetry(func() bool {
+        resp = s.delete(ctx, req)
+        return resp.Success
+    })
+    return resp, nil
+}
+
+func (s *RushKVServer) delete(ctx context.Context, req *proto.DeleteRequest) *proto.DeleteResponse {
+    replicas := s.replicasFor(req.Key)
+    if !containsNode(replicas, s.nodeID) {
+        return s.forwardDelete(ctx, req, replicas)
+    }
+    
+    required := s.requiredAcks(req.Consistency, len(replicas))
+    current, _, err := s.readReplicas(ctx, req.Key, replicas, required)
+    if err != nil {
         return &proto.DeleteResponse{
             Success: false,
-            Error:   fmt.Sprintf("key should be deleted from node %s", targetNode),
-        }, nil
+            Error:   err.Error(),
+        }
+    }
+    if current == nil {
+        current = s.readHandoff(ctx, req.Key, replicas)
+    }
+    if current == nil || current.Deleted || current.Expired(time.Now()) {
+        return &proto.DeleteResponse{
+            Success: false,
+            Error:   "key not found",
+        }
     }
     
-    err := s.storage.Delete(req.Key)
-    if err != nil {
+    // 删除以墓碑形式同步到所有副本
+    tombstone := s.newTombstone(req.Key)
+    if err := s.replicateWrite(ctx, tombstone, replicas, required); err != nil {
         return &proto.DeleteResponse{
             Success: false,
             Error:   err.Error(),
-        }, nil
+        }
     }
     
     return &proto.DeleteResponse{
         Success: true,
-    }, nil
+    }
 }
 
 func (s *RushKVServer) Join(ctx context.Context, req *proto.JoinRequest) (*proto.JoinResponse, error) {
-    s.mutex.Lock()
-    defer s.mutex.Unlock()
-    
-    nodeInfo := &proto.NodeInfo{
-        Id:       req.NodeId,
-        Address:  req.Address,
-        Port:     req.Port,
-        IsLeader: false,
+    // 成员变更只能由leader写入Raft日志，其他节点转发给leader
+    if !s.raft.IsLeader() {
+        leader, err := s.leaderClient()
+        if err != nil {
+            return &proto.JoinResponse{
+                Success: false,
+                Error:   err.Error(),
+            }, nil
+        }
+        return leader.Join(ctx, req)
     }
     
-    s.nodes[req.NodeId] = nodeInfo
-    s.hash.AddNode(req.NodeId)
-    
-    log.Printf("Node %s joined the cluster", req.NodeId)
+    err := s.raft.AddServer(ctx, raft.Server{
+        ID:      req.NodeId,
+        Address: net.JoinHostPort(req.Address, strconv.Itoa(int(req.Port))),
+    })
+    if err != nil {
+        return &proto.JoinResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
     
+    // 返回提交后的成员列表，新节点无需等待Raft日志即可构建哈希环
+    servers, index := s.raft.Configuration()
     return &proto.JoinResponse{
-        Success: true,
+        Success:           true,
+        Nodes:             nodeInfos(servers),
+        Version:           index,
+        ReplicationFactor: int32(s.config.ReplicationFactor),
     }, nil
 }
 
 func (s *RushKVServer) Leave(ctx context.Context, req *proto.LeaveRequest) (*proto.LeaveResponse, error) {
-    s.mutex.Lock()
-    defer s.mutex.Unlock()
-    
-    delete(s.nodes, req.NodeId)
-    s.hash.RemoveNode(req.NodeId)
+    if !s.raft.IsLeader() {
+        leader, err := s.leaderClient()
+        if err != nil {
+            return &proto.LeaveResponse{
+                Success: false,
+                Error:   err.Error(),
+            }, nil
+        }
+        return leader.Leave(ctx, req)
+    }
     
-    log.Printf("Node %s left the cluster", req.NodeId)
+    if err := s.raft.RemoveServer(ctx, req.NodeId); err != nil {
+        return &proto.LeaveResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
     
     return &proto.LeaveResponse{
         Success: true,
@@ -149,41 +321,113 @@ func (s *RushKVServer) Leave(ctx context.Context, req *proto.LeaveRequest) (*pro
 }
 
 func (s *RushKVServer) GetClusterInfo(ctx context.Context, req *proto.ClusterInfoRequest) (*proto.ClusterInfoResponse, error) {
-    s.mutex.RLock()
-    defer s.mutex.RUnlock()
+    leader, _ := s.raft.Leader()
     
+    s.mutex.RLock()
     nodes := make([]*proto.NodeInfo, 0, len(s.nodes))
-    var leader string
-    
     for _, node := range s.nodes {
-        nodes = append(nodes, node)
-        if node.IsLeader {
-            leader = node.Id
-        }
+        nodes = append(nodes, &proto.NodeInfo{
+            Id:       node.Id,
+            Address:  node.Address,
+            Port:     node.Port,
+            IsLeader: node.Id == leader.ID,
+            State:    s.nodeState(node.Id),
+        })
+    }
+    version := s.membershipIndex
+    s.mutex.RUnlock()
+    
+    if req.WithStatus {
+        s.fillNodeStatus(ctx, nodes)
     }
     
     return &proto.ClusterInfoResponse{
-        Nodes:  nodes,
-        Leader: leader,
+        Nodes:   nodes,
+        Leader:  leader.ID,
+        Version: version,
+    }, nil
+}
+
+func (s *RushKVServer) GetNodeStatus(ctx context.Context, req *proto.NodeStatusRequest) (*proto.NodeStatus, error) {
+    return &proto.NodeStatus{
+        NodeId:      s.nodeID,
+        Rebalance:   s.rebalanceStatus(),
+        ReadRepair:  s.readRepairStatus(),
+        AntiEntropy: s.antiEntropyStatus(),
     }, nil
 }
 
+// 查询其他节点运行状态的超时时间
+const nodeStatusTimeout = 1 * time.Second
+
+// fillNodeStatus 并发获取各节点的运行状态，不可达的节点保持为空
+func (s *RushKVServer) fillNodeStatus(ctx context.Context, nodes []*proto.NodeInfo) {
+    ctx, cancel := context.WithTimeout(ctx, nodeStatusTimeout)
+    defer cancel()
+    
+    var wg sync.WaitGroup
+    for _, node := range nodes {
+        if node.Id == s.nodeID {
+            node.Status, _ = s.GetNodeStatus(ctx, &proto.NodeStatusRequest{})
+            continue
+        }
+        wg.Add(1)
+        go func(node *proto.NodeInfo) {
+            defer wg.Done()
+            peer, err := s.peerClient(node.Id)
+            if err != nil {
+                return
+            }
+            status, err := peer.GetNodeStatus(ctx, &proto.NodeStatusRequest{})
+            if err != nil {
+                return
+            }
+            node.Status = status
+        }(node)
+    }
+    wg.Wait()
+}
+
 func (s *RushKVServer) Start() error {
     lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", s.address, s.port))
     if err != nil {
         return fmt.Errorf("failed to listen: %v", err)
     }
     
-    s.grpcServer = grpc.NewServer()
+    s.grpcServer = grpc.NewServer(
+        grpc.ChainUnaryInterceptor(s.clockInterceptor, s.versionInterceptor),
+        grpc.StreamInterceptor(s.clockStreamInterceptor),
+    )
     proto.RegisterRushKVServer(s.grpcServer, s)
     
-    // 将自己添加到集群
-    s.hash.AddNode(s.nodeID)
-    s.nodes[s.nodeID] = &proto.NodeInfo{
-        Id:       s.nodeID,
-        Address:  s.address,
-        Port:     int32(s.port),
-        IsLeader: s.isLeader,
+    // 没有Raft状态时以自己为唯一成员初始化新集群，否则等待被加入已有集群
+    if _, index := s.raft.Configuration(); index == 0 && s.config.Bootstrap {
+        err := s.raft.Bootstrap([]raft.Server{{ID: s.nodeID, Address: s.raftAddress()}})
+        if err != nil {
+            return fmt.Errorf("failed to bootstrap cluster: %v", err)
+        }
+    }
+    
+    // 重启时先按日志中最新的配置恢复哈希环，不必等待日志重新提交
+    servers, index := s.raft.Configuration()
+    s.applyMembership(servers, index)
+    s.raft.Start()
+    go s.rebalanceLoop()
+    s.detector.Start()
+    go s.reapDeadNodes()
+    go s.sweepHints()
+    go s.antiEntropyLoop()
+    go s.sweepGarbage()
+    go s.resumeTxns()
+    go s.trimChangeLog()
+    if s.config.ChangeSinkDir != "" {
+        s.changeSinkDone = make(chan struct{})
+        go s.exportChanges()
+    }
+    
+    // 通过种子节点加入已有集群，需要在开始服务之后进行，新成员要响应leader的日志复制
+    if len(s.config.Seeds) > 0 {
+        go s.joinCluster()
     }
     
     log.Printf("RushKV server %s starting on %s:%d", s.nodeID, s.address, s.port)
@@ -191,9 +435,20 @@ func (s *RushKVServer) Start() error {
 }
 
 func (s *RushKVServer) Stop() {
+    close(s.stopCh)
+    s.leaveCluster()
+    s.stopRebalance()
+    s.detector.Stop()
+    s.raft.Stop()
     if s.grpcServer != nil {
         s.grpcServer.GracefulStop()
     }
+    s.closePeers()
+    s.raftStore.Close()
+    // 导出的变更落盘后再关闭存储
+    if s.changeSinkDone != nil {
+        <-s.changeSinkDone
+    }
     if s.storage != nil {
         s.storage.Close()
     }
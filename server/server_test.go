@@ -0,0 +1,94 @@
+package server
+
+import (
+    "fmt"
+    "net"
+    "testing"
+    
+    "google.golang.org/grpc"
+    "rushkv/proto"
+    "rushkv/raft"
+)
+
+// newTestServer 创建未启动的服务器，数据保存在临时目录，测试结束时关闭
+func newTestServer(t *testing.T, nodeID string, configure func(*Config)) *RushKVServer {
+    t.Helper()
+    
+    config := DefaultConfig()
+    config.NodeID = nodeID
+    config.DataPath = t.TempDir()
+    if configure != nil {
+        configure(config)
+    }
+    
+    s, err := NewRushKVServer(config)
+    if err != nil {
+        t.Fatalf("NewRushKVServer: %v", err)
+    }
+    t.Cleanup(func() {
+        s.closePeers()
+        s.raftStore.Close()
+        s.storage.Close()
+    })
+    return s
+}
+
+// setMembers 以集群版本version应用成员列表，addresses中没有的节点使用一个不可达的地址
+func setMembers(s *RushKVServer, version uint64, addresses map[string]string, nodeIDs ...string) {
+    servers := make([]raft.Server, 0, len(nodeIDs))
+    for i, nodeID := range nodeIDs {
+        address, ok := addresses[nodeID]
+        if !ok {
+            address = fmt.Sprintf("127.0.0.1:%d", i+1)
+        }
+        servers = append(servers, raft.Server{ID: nodeID, Address: address})
+    }
+    s.applyMembership(servers, version)
+}
+
+// serveTestServer 在本地随机端口上提供服务器的gRPC接口，不启动Raft和后台任务，返回监听地址
+func serveTestServer(t *testing.T, s *RushKVServer) string {
+    t.Helper()
+    
+    lis, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("Listen: %v", err)
+    }
+    s.grpcServer = grpc.NewServer(
+        grpc.ChainUnaryInterceptor(s.clockInterceptor, s.versionInterceptor),
+        grpc.StreamInterceptor(s.clockStreamInterceptor),
+    )
+    proto.RegisterRushKVServer(s.grpcServer, s)
+    go s.grpcServer.Serve(lis)
+    t.Cleanup(s.grpcServer.Stop)
+    return lis.Addr().String()
+}
+
+// newTestCluster 创建n个互相连通的服务器，以集群版本1应用相同的成员列表
+func newTestCluster(t *testing.T, n int, configure func(*Config)) []*RushKVServer {
+    t.Helper()
+    
+    servers := make([]*RushKVServer, n)
+    nodeIDs := make([]string, n)
+    addresses := make(map[string]string, n)
+    for i := range servers {
+        nodeIDs[i] = fmt.Sprintf("node%d", i+1)
+        servers[i] = newTestServer(t, nodeIDs[i], configure)
+        addresses[nodeIDs[i]] = serveTestServer(t, servers[i])
+    }
+    for _, s := range servers {
+        setMembers(s, 1, addresses, nodeIDs...)
+    }
+    return servers
+}
+
+// memberAddresses 返回s的成员列表中各节点的地址
+func memberAddresses(s *RushKVServer) map[string]string {
+    s.mutex.RLock()
+    defer s.mutex.RUnlock()
+    addresses := make(map[string]string, len(s.nodes))
+    for _, node := range s.nodes {
+        addresses[node.Id] = fmt.Sprintf("%s:%d", node.Address, node.Port)
+    }
+    return addresses
+}
@@ -0,0 +1,747 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "sort"
+    "sync"
+    "time"
+    
+    protobuf "google.golang.org/protobuf/proto"
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+const (
+    // 等待key锁的最长时间，超时后事务中止，避免跨节点事务互相等待
+    txnLockTimeout = time.Second
+    // 参与者锁定key的最长时间，协调节点未在此时间内通知结果时自动释放
+    txnPrepareTimeout = 10 * time.Second
+    // 协调节点持久化提交决定时，距离参与者锁定超时至少保留的时间，用于通知参与者提交
+    txnCommitWindow = 3 * time.Second
+    // 参与者保留已超时事务锁定时读到的版本的时间，用于之后收到提交时检查key是否被修改
+    txnExpiredRetention = 10 * time.Minute
+    // 检查未完成提交的间隔
+    txnResumeInterval = 10 * time.Second
+)
+
+// txnManager 参与者上已锁定、等待协调节点通知结果的事务，以及锁定已超时的事务锁定时读到的版本
+type txnManager struct {
+    mutex    sync.Mutex
+    prepared map[string]*preparedTxn
+    expired  map[string]map[string]int64
+}
+
+func newTxnManager() *txnManager {
+    return &txnManager{
+        prepared: make(map[string]*preparedTxn),
+        expired:  make(map[string]map[string]int64),
+    }
+}
+
+type preparedTxn struct {
+    stripes []int
+    // 锁定时各key的当前版本，不存在的key为0
+    versions map[string]int64
+    timer    *time.Timer
+}
+
+func (s *RushKVServer) Txn(ctx context.Context, req *proto.TxnRequest) (*proto.TxnResponse, error) {
+    if err := validateTxn(req); err != nil {
+        return &proto.TxnResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    return s.txn(ctx, req), nil
+}
+
+// txn 按协调节点对涉及的key分组：只涉及一个节点时由该节点在本地执行，
+// 否则由接收请求的节点以两阶段提交协调各参与者
+func (s *RushKVServer) txn(ctx context.Context, req *proto.TxnRequest) *proto.TxnResponse {
+    keys := txnKeys(req)
+    participants := make(map[string][]string)
+    for _, key := range keys {
+        nodeID := s.casCoordinator(s.replicasFor(key))
+        participants[nodeID] = append(participants[nodeID], key)
+    }
+    
+    if len(participants) == 1 {
+        for nodeID := range participants {
+            if nodeID == s.nodeID {
+                return s.localTxn(ctx, req, keys)
+            }
+            if req.Hops < maxForwardHops {
+                return s.forwardTxn(ctx, req, nodeID)
+            }
+        }
+    }
+    return s.distributedTxn(ctx, req, participants)
+}
+
+// localTxn 锁定所有key，读取当前记录并判断条件后，把写入按副本节点分组，
+// 每个副本节点在一个存储事务中写入
+func (s *RushKVServer) localTxn(ctx context.Context, req *proto.TxnRequest, keys []string) *proto.TxnResponse {
+    stripes, ok := s.lockStripes(keys)
+    if !ok {
+        return &proto.TxnResponse{
+            Success: false,
+            Error:   "txn conflict: keys are locked by another transaction",
+        }
+    }
+    defer s.unlockStripes(stripes)
+    
+    current, err := s.readCurrent(ctx, keys, req.Consistency)
+    if err != nil {
+        return &proto.TxnResponse{
+            Success: false,
+            Error:   err.Error(),
+        }
+    }
+    
+    resp, pairs := evaluateTxn(req, current, time.Now(), s.clock.Now())
+    if len(pairs) > 0 {
+        if err := s.replicatePairs(ctx, pairs, req.Consistency); err != nil {
+            return &proto.TxnResponse{
+                Success: false,
+                Error:   err.Error(),
+            }
+        }
+    }
+    return resp
+}
+
+// distributedTxn 两阶段提交：各参与者锁定自己负责的key并返回当前记录，
+// 协调节点判断条件并计算写入，持久化提交决定后通知各参与者写入并释放锁
+func (s *RushKVServer) distributedTxn(ctx context.Context, req *proto.TxnRequest, participants map[string][]string) *proto.TxnResponse {
+    start := time.Now()
+    id := fmt.Sprintf("%s-%d", s.nodeID, start.UnixNano())
+    
+    type result struct {
+        nodeID string
+        pairs  []*storage.KVPair
+        err    error
+    }
+    results := make(chan result, len(participants))
+    for nodeID, keys := range participants {
+        go func(nodeID string, keys []string) {
+            pairs, err := s.prepareOn(ctx, nodeID, id, keys, req.Consistency)
+            results <- result{nodeID: nodeID, pairs: pairs, err: err}
+        }(nodeID, keys)
+    }
+    
+    current := make(map[string]*storage.KVPair)
+    var prepared []string
+    var prepareErr error
+    for range participants {
+        r := <-results
+        if r.err != nil {
+            prepareErr = r.err
+            continue
+        }
+        prepared = append(prepared, r.nodeID)
+        for _, pair := range r.pairs {
+            current[pair.Key] = pair
+        }
+    }
+    if prepareErr != nil {
+        s.abortTxn(id, prepared, req.Consistency)
+        return &proto.TxnResponse{
+            Success: false,
+            Error:   fmt.Sprintf("txn aborted: %v", prepareErr),
+        }
+    }
+    
+    now := time.Now()
+    resp, pairs := evaluateTxn(req, current, now, s.clock.Now())
+    if len(pairs) == 0 {
+        s.abortTxn(id, prepared, req.Consistency)
+        return resp
+    }
+    
+    // 参与者的锁定从收到请求时开始计时，晚于start。剩余时间不够通知参与者时中止，
+    // 否则参与者可能已释放锁并接受了其他写入
+    if time.Since(start) >= txnPrepareTimeout-txnCommitWindow {
+        s.abortTxn(id, prepared, req.Consistency)
+        return &proto.TxnResponse{
+            Success: false,
+            Error:   "txn aborted: prepare took too long, locks may have expired",
+        }
+    }
+    
+    // 提交决定先持久化，之后即使协调节点崩溃，也会在恢复后继续完成提交
+    record := &storage.TxnRecord{ID: id, Pairs: pairs, CreatedAt: now}
+    if err := s.storage.SaveTxn(record); err != nil {
+        s.abortTxn(id, prepared, req.Consistency)
+        return &proto.TxnResponse{
+            Success: false,
+            Error:   fmt.Sprintf("txn aborted: %v", err),
+        }
+    }
+    
+    if err := s.commitTxn(ctx, record, participants, req.Consistency); err != nil {
+        log.Printf("Txn %s committed but not yet applied on all nodes: %v", id, err)
+        return &proto.TxnResponse{
+            Success: false,
+            Error:   fmt.Sprintf("txn committed but not yet applied on all nodes, it will be completed in the background: %v", err),
+        }
+    }
+    if err := s.storage.DeleteTxn(id); err != nil {
+        log.Printf("Failed to delete txn %s: %v", id, err)
+    }
+    return resp
+}
+
+// commitTxn 把写入发给负责对应key的参与者，没有写入的参与者只释放锁
+func (s *RushKVServer) commitTxn(ctx context.Context, record *storage.TxnRecord, participants map[string][]string, level proto.ConsistencyLevel) error {
+    owner := make(map[string]string)
+    for nodeID, keys := range participants {
+        for _, key := range keys {
+            owner[key] = nodeID
+        }
+    }
+    batches := make(map[string][]*storage.KVPair, len(participants))
+    for nodeID := range participants {
+        batches[nodeID] = nil
+    }
+    for _, pair := range record.Pairs {
+        nodeID, ok := owner[pair.Key]
+        if !ok {
+            nodeID = s.casCoordinator(s.replicasFor(pair.Key))
+        }
+        batches[nodeID] = append(batches[nodeID], pair)
+    }
+    
+    errs := make(chan error, len(batches))
+    for nodeID, pairs := range batches {
+        go func(nodeID string, pairs []*storage.KVPair) {
+            errs <- s.finishOn(ctx, nodeID, record.ID, true, pairs, level)
+        }(nodeID, pairs)
+    }
+    
+    var lastErr error
+    for range batches {
+        if err := <-errs; err != nil {
+            lastErr = err
+        }
+    }
+    return lastErr
+}
+
+// abortTxn 通知已锁定key的参与者释放锁
+func (s *RushKVServer) abortTxn(id string, prepared []string, level proto.ConsistencyLevel) {
+    for _, nodeID := range prepared {
+        go func(nodeID string) {
+            ctx, cancel := context.WithTimeout(context.Background(), replicaTimeout)
+            defer cancel()
+            if err := s.finishOn(ctx, nodeID, id, false, nil, level); err != nil {
+                log.Printf("Failed to abort txn %s on node %s: %v", id, nodeID, err)
+            }
+        }(nodeID)
+    }
+}
+
+// resumeTxns 定期重新提交协调节点已决定提交但未完成的事务
+func (s *RushKVServer) resumeTxns() {
+    ticker := time.NewTicker(txnResumeInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-s.stopCh:
+            return
+        case <-ticker.C:
+        }
+        s.resumePendingTxns()
+    }
+}
+
+// resumePendingTxns 重新提交已决定提交但未完成的事务。写入带有固定的版本号，
+// 参与者检查key在锁定超时后没有被修改或已经写入，重复提交不会产生不同的结果
+func (s *RushKVServer) resumePendingTxns() {
+    records, err := s.storage.PendingTxns()
+    if err != nil {
+        log.Printf("Failed to list pending txns: %v", err)
+        return
+    }
+    for _, record := range records {
+        // 较新的事务可能仍在正常提交
+        if time.Since(record.CreatedAt) < txnPrepareTimeout {
+            continue
+        }
+        
+        ctx, cancel := context.WithTimeout(context.Background(), replicaTimeout)
+        err := s.commitTxn(ctx, record, nil, proto.ConsistencyLevel_DEFAULT)
+        cancel()
+        if err != nil {
+            log.Printf("Failed to resume txn %s: %v", record.ID, err)
+            continue
+        }
+        if err := s.storage.DeleteTxn(record.ID); err != nil {
+            log.Printf("Failed to delete txn %s: %v", record.ID, err)
+            continue
+        }
+        log.Printf("Resumed txn %s", record.ID)
+    }
+}
+
+// prepareOn 在参与者上锁定keys并读取当前记录
+func (s *RushKVServer) prepareOn(ctx context.Context, nodeID, id string, keys []string, level proto.ConsistencyLevel) ([]*storage.KVPair, error) {
+    if nodeID == s.nodeID {
+        return s.prepareTxn(ctx, id, keys, level)
+    }
+    
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return nil, err
+    }
+    resp, err := peer.TxnPrepare(ctx, &proto.TxnPrepareRequest{
+        TxnId:       id,
+        Keys:        keys,
+        Consistency: level,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("prepare on node %s failed: %v", nodeID, err)
+    }
+    if !resp.Success {
+        return nil, fmt.Errorf("prepare on node %s failed: %s", nodeID, resp.Error)
+    }
+    
+    pairs := make([]*storage.KVPair, 0, len(resp.Pairs))
+    for _, pair := range resp.Pairs {
+        pairs = append(pairs, fromProtoPair(pair))
+    }
+    return pairs, nil
+}
+
+// finishOn 通知参与者提交或中止
+func (s *RushKVServer) finishOn(ctx context.Context, nodeID, id string, commit bool, pairs []*storage.KVPair, level proto.ConsistencyLevel) error {
+    if nodeID == s.nodeID {
+        return s.finishTxn(ctx, id, commit, pairs, level)
+    }
+    
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return err
+    }
+    req := &proto.TxnFinishRequest{
+        TxnId:       id,
+        Commit:      commit,
+        Consistency: level,
+    }
+    for _, pair := range pairs {
+        req.Pairs = append(req.Pairs, toProtoPair(pair))
+    }
+    resp, err := peer.TxnFinish(ctx, req)
+    if err != nil {
+        return fmt.Errorf("finish on node %s failed: %v", nodeID, err)
+    }
+    if !resp.Success {
+        return fmt.Errorf("finish on node %s failed: %s", nodeID, resp.Error)
+    }
+    return nil
+}
+
+func (s *RushKVServer) TxnPrepare(ctx context.Context, req *proto.TxnPrepareRequest) (*proto.TxnPrepareResponse, error) {
+    pairs, err := s.prepareTxn(ctx, req.TxnId, req.Keys, req.Consistency)
+    if err != nil {
+        return &proto.TxnPrepareResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    
+    resp := &proto.TxnPrepareResponse{Success: true}
+    for _, pair := range pairs {
+        resp.Pairs = append(resp.Pairs, toProtoPair(pair))
+    }
+    return resp, nil
+}
+
+func (s *RushKVServer) TxnFinish(ctx context.Context, req *proto.TxnFinishRequest) (*proto.TxnFinishResponse, error) {
+    pairs := make([]*storage.KVPair, 0, len(req.Pairs))
+    for _, pair := range req.Pairs {
+        pairs = append(pairs, fromProtoPair(pair))
+    }
+    
+    if err := s.finishTxn(ctx, req.TxnId, req.Commit, pairs, req.Consistency); err != nil {
+        return &proto.TxnFinishResponse{
+            Success: false,
+            Error:   err.Error(),
+        }, nil
+    }
+    return &proto.TxnFinishResponse{
+        Success: true,
+    }, nil
+}
+
+// prepareTxn 锁定keys直到finishTxn或超时，返回各key的当前记录
+func (s *RushKVServer) prepareTxn(ctx context.Context, id string, keys []string, level proto.ConsistencyLevel) ([]*storage.KVPair, error) {
+    stripes, ok := s.lockStripes(keys)
+    if !ok {
+        return nil, fmt.Errorf("txn conflict: keys are locked by another transaction")
+    }
+    
+    current, err := s.readCurrent(ctx, keys, level)
+    if err != nil {
+        s.unlockStripes(stripes)
+        return nil, err
+    }
+    
+    versions := make(map[string]int64, len(keys))
+    pairs := make([]*storage.KVPair, 0, len(current))
+    for _, key := range keys {
+        versions[key] = 0
+    }
+    for key, pair := range current {
+        versions[key] = pair.Version
+        pairs = append(pairs, pair)
+    }
+    
+    m := s.txns
+    m.mutex.Lock()
+    m.prepared[id] = &preparedTxn{
+        stripes:  stripes,
+        versions: versions,
+        timer: time.AfterFunc(txnPrepareTimeout, func() {
+            if s.expireTxn(id) {
+                log.Printf("Txn %s timed out waiting for its coordinator, locks released", id)
+            }
+        }),
+    }
+    m.mutex.Unlock()
+    
+    return pairs, nil
+}
+
+// finishTxn 提交时写入pairs后释放锁，中止时只释放锁。
+// 锁定已超时时，只有在各key没有被其他写入修改时才写入
+func (s *RushKVServer) finishTxn(ctx context.Context, id string, commit bool, pairs []*storage.KVPair, level proto.ConsistencyLevel) error {
+    prepared := s.takeTxn(id)
+    if !commit {
+        if prepared != nil {
+            s.unlockStripes(prepared.stripes)
+        }
+        s.txns.forget(id)
+        return nil
+    }
+    if len(pairs) == 0 {
+        if prepared != nil {
+            s.unlockStripes(prepared.stripes)
+        }
+        return nil
+    }
+    
+    if prepared == nil {
+        return s.finishExpiredTxn(ctx, id, pairs, level)
+    }
+    defer s.unlockStripes(prepared.stripes)
+    return s.replicatePairs(ctx, pairs, level)
+}
+
+// finishExpiredTxn 重新锁定key，确认每个key仍是锁定时的版本或已是本事务写入的版本后写入。
+// 本节点没有该事务的记录（例如已重启）时，只接受所有key都已写入的重复提交
+func (s *RushKVServer) finishExpiredTxn(ctx context.Context, id string, pairs []*storage.KVPair, level proto.ConsistencyLevel) error {
+    keys := make([]string, 0, len(pairs))
+    for _, pair := range pairs {
+        keys = append(keys, pair.Key)
+    }
+    stripes, ok := s.lockStripes(keys)
+    if !ok {
+        return fmt.Errorf("txn conflict: keys are locked by another transaction")
+    }
+    defer s.unlockStripes(stripes)
+    
+    current, err := s.readCurrent(ctx, keys, level)
+    if err != nil {
+        return err
+    }
+    
+    m := s.txns
+    m.mutex.Lock()
+    versions, known := m.expired[id]
+    m.mutex.Unlock()
+    
+    var pending []*storage.KVPair
+    for _, pair := range pairs {
+        var version int64
+        if existing := current[pair.Key]; existing != nil {
+            version = existing.Version
+        }
+        if version == pair.Version {
+            continue
+        }
+        if prepared, ok := versions[pair.Key]; !known || !ok || prepared != version {
+            return fmt.Errorf("txn %s is no longer prepared and key %s has changed since", id, pair.Key)
+        }
+        pending = append(pending, pair)
+    }
+    if len(pending) > 0 {
+        if err := s.replicatePairs(ctx, pending, level); err != nil {
+            return err
+        }
+    }
+    m.forget(id)
+    return nil
+}
+
+// takeTxn 取出已锁定的事务并停止超时计时，之后由调用方释放锁。事务不存在或已超时时返回nil
+func (s *RushKVServer) takeTxn(id string) *preparedTxn {
+    m := s.txns
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    
+    prepared, ok := m.prepared[id]
+    if !ok {
+        return nil
+    }
+    delete(m.prepared, id)
+    prepared.timer.Stop()
+    return prepared
+}
+
+// expireTxn 在锁定超时时释放事务的锁，保留锁定时的版本供之后的提交检查，事务已结束时返回false
+func (s *RushKVServer) expireTxn(id string) bool {
+    prepared := s.takeTxn(id)
+    if prepared == nil {
+        return false
+    }
+    
+    m := s.txns
+    m.mutex.Lock()
+    m.expired[id] = prepared.versions
+    m.mutex.Unlock()
+    time.AfterFunc(txnExpiredRetention, func() {
+        m.forget(id)
+    })
+    
+    s.unlockStripes(prepared.stripes)
+    return true
+}
+
+// forget 删除已超时事务的记录
+func (m *txnManager) forget(id string) {
+    m.mutex.Lock()
+    delete(m.expired, id)
+    m.mutex.Unlock()
+}
+
+// lockStripes 按序号从小到大锁定keys所在的锁段，在txnLockTimeout内未能全部锁定时返回false
+func (s *RushKVServer) lockStripes(keys []string) ([]int, bool) {
+    seen := make(map[int]bool)
+    var stripes []int
+    for _, key := range keys {
+        stripe := int(uint32(s.hash.Hash(key)) % casLockStripes)
+        if !seen[stripe] {
+            seen[stripe] = true
+            stripes = append(stripes, stripe)
+        }
+    }
+    sort.Ints(stripes)
+    
+    deadline := time.Now().Add(txnLockTimeout)
+    for i, stripe := range stripes {
+        for !s.casLocks[stripe].TryLock() {
+            if time.Now().After(deadline) {
+                s.unlockStripes(stripes[:i])
+                return nil, false
+            }
+            time.Sleep(5 * time.Millisecond)
+        }
+    }
+    return stripes, true
+}
+
+func (s *RushKVServer) unlockStripes(stripes []int) {
+    for _, stripe := range stripes {
+        s.casLocks[stripe].Unlock()
+    }
+}
+
+// readCurrent 并发读取各key的当前记录（包括墓碑），不存在的key不在结果中
+func (s *RushKVServer) readCurrent(ctx context.Context, keys []string, level proto.ConsistencyLevel) (map[string]*storage.KVPair, error) {
+    type result struct {
+        key  string
+        pair *storage.KVPair
+        err  error
+    }
+    
+    results := make(chan result, len(keys))
+    for _, key := range keys {
+        go func(key string) {
+            replicas := s.replicasFor(key)
+            pair, _, err := s.readReplicas(ctx, key, replicas, s.requiredAcks(level, len(replicas)))
+            if err == nil && pair == nil {
+                pair = s.readHandoff(ctx, key, replicas)
+            }
+            results <- result{key: key, pair: pair, err: err}
+        }(key)
+    }
+    
+    current := make(map[string]*storage.KVPair, len(keys))
+    var lastErr error
+    for range keys {
+        r := <-results
+        if r.err != nil {
+            lastErr = fmt.Errorf("read %s failed: %v", r.key, r.err)
+            continue
+        }
+        if r.pair != nil {
+            current[r.key] = r.pair
+        }
+    }
+    if lastErr != nil {
+        return nil, lastErr
+    }
+    return current, nil
+}
+
+// evaluateTxn 根据当前记录判断条件并依次执行选中分支的操作，返回响应及需要写入的记录。
+// 同一事务的写入使用相同的版本号，不小于version且大于所有涉及key的当前版本
+func evaluateTxn(req *proto.TxnRequest, current map[string]*storage.KVPair, now time.Time, version int64) (*proto.TxnResponse, []*storage.KVPair) {
+    for _, pair := range current {
+        if pair.Version >= version {
+            version = pair.Version + 1
+        }
+    }
+    
+    written := make(map[string]*storage.KVPair)
+    live := func(key string) *storage.KVPair {
+        pair, ok := written[key]
+        if !ok {
+            pair = current[key]
+        }
+        if pair == nil || pair.Deleted || pair.Expired(now) {
+            return nil
+        }
+        return pair
+    }
+    
+    succeeded := true
+    for _, cmp := range req.Compare {
+        if !conditionMatches(cmp.Condition, cmp.ExpectedVersion, cmp.ExpectedValue, live(cmp.Key)) {
+            succeeded = false
+            break
+        }
+    }
+    ops := req.Success
+    if !succeeded {
+        ops = req.Failure
+    }
+    
+    resp := &proto.TxnResponse{
+        Success:   true,
+        Succeeded: succeeded,
+    }
+    for _, op := range ops {
+        result := &proto.TxnOpResult{Key: op.Key}
+        switch op.Type {
+        case proto.TxnOpType_GET:
+            if pair := live(op.Key); pair != nil {
+                result.Found = true
+                result.Value = pair.Value
+                result.Version = pair.Version
+            }
+        case proto.TxnOpType_PUT:
+            pair := &storage.KVPair{
+                Key:       op.Key,
+                Value:     op.Value,
+                Version:   version,
+                Timestamp: now,
+            }
+            if op.TtlSeconds > 0 {
+                pair.ExpiresAt = now.Add(time.Duration(op.TtlSeconds) * time.Second)
+            }
+            written[op.Key] = pair
+            result.Found = true
+            result.Version = version
+        case proto.TxnOpType_DELETE:
+            // 删除不存在的key不写墓碑
+            if live(op.Key) != nil {
+                written[op.Key] = &storage.KVPair{
+                    Key:       op.Key,
+                    Version:   version,
+                    Timestamp: now,
+                    Deleted:   true,
+                }
+                result.Found = true
+                result.Version = version
+            }
+        }
+        resp.Results = append(resp.Results, result)
+    }
+    
+    pairs := make([]*storage.KVPair, 0, len(written))
+    for _, pair := range written {
+        pairs = append(pairs, pair)
+    }
+    sort.Slice(pairs, func(i, j int) bool {
+        return pairs[i].Key < pairs[j].Key
+    })
+    return resp, pairs
+}
+
+// txnKeys 返回事务涉及的所有key，按字典序排列
+func txnKeys(req *proto.TxnRequest) []string {
+    seen := make(map[string]bool)
+    var keys []string
+    add := func(key string) {
+        if !seen[key] {
+            seen[key] = true
+            keys = append(keys, key)
+        }
+    }
+    for _, cmp := range req.Compare {
+        add(cmp.Key)
+    }
+    for _, op := range req.Success {
+        add(op.Key)
+    }
+    for _, op := range req.Failure {
+        add(op.Key)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+func validateTxn(req *proto.TxnRequest) error {
+    for _, cmp := range req.Compare {
+        if cmp.Key == "" {
+            return fmt.Errorf("compare with empty key")
+        }
+    }
+    for _, ops := range [][]*proto.TxnOp{req.Success, req.Failure} {
+        for _, op := range ops {
+            if op.Key == "" {
+                return fmt.Errorf("operation with empty key")
+            }
+            if _, ok := proto.TxnOpType_name[int32(op.Type)]; !ok {
+                return fmt.Errorf("unknown operation type %d", op.Type)
+            }
+        }
+    }
+    return nil
+}
+
+// forwardTxn 将只涉及一个节点的事务转发给该节点执行
+func (s *RushKVServer) forwardTxn(ctx context.Context, req *proto.TxnRequest, nodeID string) *proto.TxnResponse {
+    forwarded := protobuf.Clone(req).(*proto.TxnRequest)
+    forwarded.Hops++
+    
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return &proto.TxnResponse{
+            Success: false,
+            Error:   err.Error(),
+        }
+    }
+    resp, err := peer.Txn(ctx, forwarded)
+    if err != nil {
+        return &proto.TxnResponse{
+            Success: false,
+            Error:   fmt.Sprintf("failed to forward to node %s: %v", nodeID, err),
+        }
+    }
+    return resp
+}
\ No newline at end of file
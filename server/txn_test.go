@@ -0,0 +1,220 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "testing"
+    "time"
+    
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+// keyOn 返回在s的哈希环上由nodeID协调的一个key
+func keyOn(s *RushKVServer, nodeID, prefix string) string {
+    for i := 0; ; i++ {
+        key := fmt.Sprintf("%s%d", prefix, i)
+        if s.casCoordinator(s.replicasFor(key)) == nodeID {
+            return key
+        }
+    }
+}
+
+func putTxn(keys ...string) *proto.TxnRequest {
+    req := &proto.TxnRequest{Consistency: proto.ConsistencyLevel_ONE}
+    for _, key := range keys {
+        req.Success = append(req.Success, &proto.TxnOp{Type: proto.TxnOpType_PUT, Key: key, Value: []byte("txn")})
+    }
+    return req
+}
+
+func expectValue(t *testing.T, s *RushKVServer, key, want string) {
+    t.Helper()
+    pair, err := s.storage.GetPair(key)
+    if err != nil || pair == nil || pair.Deleted || string(pair.Value) != want {
+        t.Errorf("%s on %s = %+v, %v, want %q", key, s.nodeID, pair, err, want)
+    }
+}
+
+func TestLocalTxnAppliesSelectedBranch(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 1, nil, "node1")
+    if _, err := s.applyPairs([]*storage.KVPair{s.newPair("a", []byte("1"))}); err != nil {
+        t.Fatal(err)
+    }
+    
+    req := putTxn("b")
+    req.Compare = []*proto.TxnCompare{{Key: "a", Condition: proto.CasCondition_VALUE_EQUALS, ExpectedValue: []byte("1")}}
+    req.Failure = []*proto.TxnOp{{Type: proto.TxnOpType_GET, Key: "a"}}
+    resp, err := s.Txn(context.Background(), req)
+    if err != nil || !resp.Success || !resp.Succeeded {
+        t.Fatalf("Txn = %+v, %v, want the success branch", resp, err)
+    }
+    expectValue(t, s, "b", "txn")
+    
+    req.Compare[0].ExpectedValue = []byte("2")
+    resp, err = s.Txn(context.Background(), req)
+    if err != nil || !resp.Success || resp.Succeeded {
+        t.Fatalf("Txn = %+v, %v, want the failure branch", resp, err)
+    }
+    if len(resp.Results) != 1 || !resp.Results[0].Found || string(resp.Results[0].Value) != "1" {
+        t.Errorf("failure branch results = %+v, want a = 1", resp.Results)
+    }
+}
+
+func TestLocalTxnConflict(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 1, nil, "node1")
+    
+    stripes, ok := s.lockStripes([]string{"a"})
+    if !ok {
+        t.Fatal("failed to lock stripes")
+    }
+    defer s.unlockStripes(stripes)
+    
+    resp := s.localTxn(context.Background(), putTxn("a"), []string{"a"})
+    if resp.Success {
+        t.Fatalf("localTxn = %+v, want a lock conflict", resp)
+    }
+    if pair, _ := s.storage.GetPair("a"); pair != nil {
+        t.Errorf("a = %+v, want nothing written", pair)
+    }
+}
+
+func TestDistributedTxnCommitsOnAllParticipants(t *testing.T) {
+    servers := newTestCluster(t, 2, func(config *Config) {
+        config.ReplicationFactor = 1
+    })
+    node1, node2 := servers[0], servers[1]
+    a, b := keyOn(node1, "node1", "a"), keyOn(node1, "node2", "b")
+    
+    resp, err := node1.Txn(context.Background(), putTxn(a, b))
+    if err != nil || !resp.Success || !resp.Succeeded {
+        t.Fatalf("Txn = %+v, %v", resp, err)
+    }
+    expectValue(t, node1, a, "txn")
+    expectValue(t, node2, b, "txn")
+    
+    if records, _ := node1.storage.PendingTxns(); len(records) != 0 {
+        t.Errorf("pending txns = %d, want 0", len(records))
+    }
+    for _, s := range servers {
+        if n := len(s.txns.prepared); n != 0 {
+            t.Errorf("%s still holds %d prepared txns", s.nodeID, n)
+        }
+    }
+}
+
+func TestDistributedTxnAbortsOnConflict(t *testing.T) {
+    servers := newTestCluster(t, 2, func(config *Config) {
+        config.ReplicationFactor = 1
+    })
+    node1, node2 := servers[0], servers[1]
+    a, b := keyOn(node1, "node1", "a"), keyOn(node1, "node2", "b")
+    
+    // 另一个事务已锁定node2上的key
+    if _, err := node2.prepareTxn(context.Background(), "other", []string{b}, proto.ConsistencyLevel_ONE); err != nil {
+        t.Fatal(err)
+    }
+    defer node2.finishTxn(context.Background(), "other", false, nil, proto.ConsistencyLevel_ONE)
+    
+    resp, err := node1.Txn(context.Background(), putTxn(a, b))
+    if err != nil || resp.Success {
+        t.Fatalf("Txn = %+v, %v, want a conflict", resp, err)
+    }
+    if pair, _ := node1.storage.GetPair(a); pair != nil {
+        t.Errorf("%s = %+v, want nothing written", a, pair)
+    }
+    
+    // 中止异步通知参与者，node1上的锁随后释放
+    deadline := time.Now().Add(time.Second)
+    for {
+        node1.txns.mutex.Lock()
+        n := len(node1.txns.prepared)
+        node1.txns.mutex.Unlock()
+        if n == 0 {
+            break
+        }
+        if time.Now().After(deadline) {
+            t.Fatalf("node1 still holds %d prepared txns after abort", n)
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+}
+
+func TestExpiredPrepareRejectsChangedKey(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 1, nil, "node1")
+    ctx := context.Background()
+    
+    if _, err := s.prepareTxn(ctx, "t1", []string{"a"}, proto.ConsistencyLevel_ONE); err != nil {
+        t.Fatal(err)
+    }
+    if !s.expireTxn("t1") {
+        t.Fatal("expireTxn = false, want the prepared txn released")
+    }
+    
+    // 锁释放后其他写入修改了key，超时事务的提交不能覆盖它
+    if resp := s.put(ctx, &proto.PutRequest{Key: "a", Value: []byte("other")}); !resp.Success {
+        t.Fatalf("put = %+v", resp)
+    }
+    pair := &storage.KVPair{Key: "a", Value: []byte("txn"), Version: s.clock.Now(), Timestamp: time.Now()}
+    if err := s.finishTxn(ctx, "t1", true, []*storage.KVPair{pair}, proto.ConsistencyLevel_ONE); err == nil {
+        t.Fatal("finishTxn after expiry and a conflicting write succeeded")
+    }
+    expectValue(t, s, "a", "other")
+}
+
+func TestExpiredPrepareCommitsUnchangedKey(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 1, nil, "node1")
+    ctx := context.Background()
+    
+    if _, err := s.prepareTxn(ctx, "t1", []string{"a"}, proto.ConsistencyLevel_ONE); err != nil {
+        t.Fatal(err)
+    }
+    s.expireTxn("t1")
+    
+    pair := &storage.KVPair{Key: "a", Value: []byte("txn"), Version: s.clock.Now(), Timestamp: time.Now()}
+    if err := s.finishTxn(ctx, "t1", true, []*storage.KVPair{pair}, proto.ConsistencyLevel_ONE); err != nil {
+        t.Fatalf("finishTxn = %v", err)
+    }
+    expectValue(t, s, "a", "txn")
+    
+    // 重复提交已写入的事务仍然成功
+    if err := s.finishTxn(ctx, "t1", true, []*storage.KVPair{pair}, proto.ConsistencyLevel_ONE); err != nil {
+        t.Errorf("repeated finishTxn = %v", err)
+    }
+}
+
+func TestResumeTxnsCompletesCommittedTxn(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 1, nil, "node1")
+    ctx := context.Background()
+    
+    // 协调节点持久化提交决定后崩溃，参与者的锁已超时
+    if _, err := s.prepareTxn(ctx, "t1", []string{"a", "b"}, proto.ConsistencyLevel_ONE); err != nil {
+        t.Fatal(err)
+    }
+    s.expireTxn("t1")
+    version := s.clock.Now()
+    record := &storage.TxnRecord{
+        ID:        "t1",
+        CreatedAt: time.Now().Add(-2 * txnPrepareTimeout),
+        Pairs: []*storage.KVPair{
+            {Key: "a", Value: []byte("txn"), Version: version, Timestamp: time.Now()},
+            {Key: "b", Value: []byte("txn"), Version: version, Timestamp: time.Now()},
+        },
+    }
+    if err := s.storage.SaveTxn(record); err != nil {
+        t.Fatal(err)
+    }
+    
+    s.resumePendingTxns()
+    
+    expectValue(t, s, "a", "txn")
+    expectValue(t, s, "b", "txn")
+    if records, _ := s.storage.PendingTxns(); len(records) != 0 {
+        t.Errorf("pending txns = %d, want 0", len(records))
+    }
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+    "context"
+    "strconv"
+    "time"
+    
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "rushkv/proto"
+)
+
+// 节点间RPC通过metadata携带发送方的集群状态版本
+const (
+    versionHeader = "rushkv-cluster-version"
+    nodeHeader    = "rushkv-node-id"
+)
+
+// 等待Raft日志自然送达的最长时间，超时后直接从对方拉取成员信息
+const catchUpWait = 500 * time.Millisecond
+
+// 按哈希环路由的数据请求，发送方集群版本落后时会被拒绝
+var routedMethods = map[string]bool{
+    "/rushkv.RushKV/Put":            true,
+    "/rushkv.RushKV/Get":            true,
+    "/rushkv.RushKV/MultiGet":       true,
+    "/rushkv.RushKV/History":        true,
+    "/rushkv.RushKV/Delete":         true,
+    "/rushkv.RushKV/Batch":          true,
+    "/rushkv.RushKV/CompareAndSwap": true,
+    "/rushkv.RushKV/Txn":            true,
+    "/rushkv.RushKV/TxnPrepare":     true,
+    "/rushkv.RushKV/TxnFinish":      true,
+    "/rushkv.RushKV/Replicate":      true,
+    "/rushkv.RushKV/ReadReplica":    true,
+    "/rushkv.RushKV/MerkleTrees":    true,
+    "/rushkv.RushKV/RangePairs":     true,
+}
+
+// 成员管理相关的请求不触发追赶，避免与Raft日志复制互相等待
+var membershipMethods = map[string]bool{
+    "/rushkv.RushKV/Join":           true,
+    "/rushkv.RushKV/Leave":          true,
+    "/rushkv.RushKV/GetClusterInfo": true,
+    "/rushkv.RushKV/RequestVote":    true,
+    "/rushkv.RushKV/AppendEntries":  true,
+    "/rushkv.RushKV/Probe":          true,
+}
+
+// clusterVersion 返回本节点已应用的集群状态版本
+func (s *RushKVServer) clusterVersion() uint64 {
+    s.mutex.RLock()
+    defer s.mutex.RUnlock()
+    return s.membershipIndex
+}
+
+// versionInterceptor 检查请求方的集群版本：对方更新时先追赶，对方落后时拒绝路由请求
+func (s *RushKVServer) versionInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    if md, ok := metadata.FromIncomingContext(ctx); ok && !membershipMethods[info.FullMethod] {
+        if version, ok := headerVersion(md); ok {
+            local := s.clusterVersion()
+            if version > local {
+                if nodes := md.Get(nodeHeader); len(nodes) > 0 {
+                    s.catchUp(nodes[0], version)
+                }
+            } else if version < local && routedMethods[info.FullMethod] {
+                grpc.SetHeader(ctx, metadata.Pairs(versionHeader, strconv.FormatUint(local, 10)))
+                return nil, status.Errorf(codes.FailedPrecondition, "stale cluster version %d, current is %d", version, local)
+            }
+        }
+    }
+    
+    grpc.SetHeader(ctx, metadata.Pairs(versionHeader, strconv.FormatUint(s.clusterVersion(), 10)))
+    return handler(ctx, req)
+}
+
+// peerInterceptor 为发往nodeID的请求附加本节点的集群版本，并在对方版本更新时追赶
+func (s *RushKVServer) peerInterceptor(nodeID string) grpc.UnaryClientInterceptor {
+    return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+        ctx = metadata.AppendToOutgoingContext(ctx,
+            versionHeader, strconv.FormatUint(s.clusterVersion(), 10),
+            nodeHeader, s.nodeID,
+        )
+        
+        var header metadata.MD
+        err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+        
+        if !membershipMethods[method] {
+            if version, ok := headerVersion(header); ok && version > s.clusterVersion() {
+                s.catchUp(nodeID, version)
+            }
+        }
+        return err
+    }
+}
+
+// catchUp 在得知nodeID的集群版本更新时追赶成员信息
+func (s *RushKVServer) catchUp(nodeID string, version uint64) {
+    timer := time.NewTimer(catchUpWait)
+    defer timer.Stop()
+    
+    // 通常Raft日志很快就会送达
+    for {
+        s.mutex.RLock()
+        current, changed := s.membershipIndex, s.membershipChanged
+        s.mutex.RUnlock()
+        if current >= version {
+            return
+        }
+        
+        select {
+        case <-changed:
+            continue
+        case <-timer.C:
+        }
+        break
+    }
+    
+    // 仍未追上（例如本节点已被移出集群，收不到日志），直接从对方拉取已提交的成员信息
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return
+    }
+    ctx, cancel := context.WithTimeout(context.Background(), replicaTimeout)
+    defer cancel()
+    
+    resp, err := peer.GetClusterInfo(ctx, &proto.ClusterInfoRequest{})
+    if err != nil {
+        return
+    }
+    
+    s.applyMembership(raftServers(resp.Nodes), resp.Version)
+}
+
+// withRingRetry 执行按哈希环路由的操作，若执行期间本节点追赶到了新的集群版本则按新环重试一次
+func (s *RushKVServer) withRingRetry(op func() bool) {
+    version := s.clusterVersion()
+    if op() || s.clusterVersion() == version {
+        return
+    }
+    op()
+}
+
+func headerVersion(md metadata.MD) (uint64, bool) {
+    values := md.Get(versionHeader)
+    if len(values) == 0 {
+        return 0, false
+    }
+    version, err := strconv.ParseUint(values[0], 10, 64)
+    if err != nil {
+        return 0, false
+    }
+    return version, true
+}
\ No newline at end of file
@@ -0,0 +1,136 @@
+package server
+
+import (
+    "context"
+    "fmt"
+    "testing"
+    
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+// callWithVersion 以集群版本version经过versionInterceptor调用method，返回处理函数是否被执行
+func callWithVersion(s *RushKVServer, method string, version string) (bool, error) {
+    ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+        versionHeader, version,
+        nodeHeader, "node2",
+    ))
+    handled := false
+    _, err := s.versionInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+        handled = true
+        return nil, nil
+    })
+    return handled, err
+}
+
+func TestStaleVersionRejectsRoutedMethods(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 5, nil, "node1", "node2")
+    
+    for _, method := range []string{
+        "/rushkv.RushKV/Put",
+        "/rushkv.RushKV/Delete",
+        "/rushkv.RushKV/Batch",
+        "/rushkv.RushKV/Get",
+        "/rushkv.RushKV/MultiGet",
+    } {
+        handled, err := callWithVersion(s, method, "3")
+        if handled || status.Code(err) != codes.FailedPrecondition {
+            t.Errorf("%s with stale version: handled = %v, err = %v, want FailedPrecondition", method, handled, err)
+        }
+        
+        handled, err = callWithVersion(s, method, "5")
+        if !handled || err != nil {
+            t.Errorf("%s with current version: handled = %v, err = %v", method, handled, err)
+        }
+    }
+}
+
+func TestStaleVersionAllowsMembershipMethods(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 5, nil, "node1", "node2")
+    
+    handled, err := callWithVersion(s, "/rushkv.RushKV/GetClusterInfo", "3")
+    if !handled || err != nil {
+        t.Errorf("GetClusterInfo with stale version: handled = %v, err = %v", handled, err)
+    }
+}
+
+// newStaleCluster 创建三个节点，node1停留在只有node1和node2的集群版本2，node2和node3已应用加入node3的版本3。
+// 返回在旧环上由node2协调、在新环上由node3协调的若干key
+func newStaleCluster(t *testing.T, n int) ([]*RushKVServer, []string) {
+    t.Helper()
+    
+    servers := newTestCluster(t, 3, func(config *Config) {
+        config.ReplicationFactor = 1
+    })
+    node1, node2, node3 := servers[0], servers[1], servers[2]
+    addresses := memberAddresses(node1)
+    setMembers(node1, 2, addresses, "node1", "node2")
+    setMembers(node2, 3, addresses, "node1", "node2", "node3")
+    setMembers(node3, 3, addresses, "node1", "node2", "node3")
+    
+    var keys []string
+    for i := 0; len(keys) < n; i++ {
+        key := fmt.Sprintf("key%d", i)
+        if node1.replicasFor(key)[0] == "node2" && node2.replicasFor(key)[0] == "node3" {
+            keys = append(keys, key)
+        }
+    }
+    return servers, keys
+}
+
+func TestStaleForwarderRetriesBatch(t *testing.T) {
+    servers, keys := newStaleCluster(t, 3)
+    node1, node3 := servers[0], servers[2]
+    
+    req := &proto.BatchRequest{Consistency: proto.ConsistencyLevel_ONE}
+    for _, key := range keys {
+        req.Ops = append(req.Ops, &proto.TxnOp{Type: proto.TxnOpType_PUT, Key: key, Value: []byte("v")})
+    }
+    resp, err := node1.Batch(context.Background(), req)
+    if err != nil || !resp.Success {
+        t.Fatalf("Batch = %+v, %v", resp, err)
+    }
+    for _, result := range resp.Results {
+        if !result.Success {
+            t.Errorf("%s failed: %s", result.Key, result.Error)
+        }
+    }
+    
+    if version := node1.clusterVersion(); version != 3 {
+        t.Errorf("node1 cluster version = %d, want 3", version)
+    }
+    for _, key := range keys {
+        if pair, _ := node3.storage.GetPair(key); pair == nil {
+            t.Errorf("%s not written to node3", key)
+        }
+    }
+}
+
+func TestStaleForwarderRetriesMultiGet(t *testing.T) {
+    servers, keys := newStaleCluster(t, 3)
+    node1, node3 := servers[0], servers[2]
+    
+    for _, key := range keys {
+        if _, err := node3.applyPairs([]*storage.KVPair{node3.newPair(key, []byte(key))}); err != nil {
+            t.Fatal(err)
+        }
+    }
+    resp, err := node1.MultiGet(context.Background(), &proto.MultiGetRequest{
+        Keys:        keys,
+        Consistency: proto.ConsistencyLevel_ONE,
+    })
+    if err != nil || !resp.Success {
+        t.Fatalf("MultiGet = %+v, %v", resp, err)
+    }
+    for _, result := range resp.Results {
+        if !result.Found || string(result.Value) != result.Key {
+            t.Errorf("%s = %+v, want the value stored on node3", result.Key, result)
+        }
+    }
+}
@@ -0,0 +1,430 @@
+package server
+
+import (
+    "context"
+    "log"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+    
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+// 协调节点在响应头中返回监听的起始版本，客户端在收到任何变更前断开时从该版本继续
+const watchStartHeader = "rushkv-watch-start"
+
+// 协调节点结束监听时在trailer中返回可以安全继续的低水位，不高于任一节点尚未送达的变更的版本
+const watchResumeHeader = "rushkv-watch-resume"
+
+// 每个监听者未发送的变更数上限，超出后断开该监听者，由客户端从最后收到的版本重新监听
+const watcherBuffer = 1024
+
+// 同一次写入在各副本上的变更应在该时间内都到达协调节点，协调节点只为最近的版本去重
+const watchDedupeWindow = 10 * time.Second
+
+// changeLog 本节点最近写入的变更，以及监听这些变更的本地订阅者
+type changeLog struct {
+    mutex sync.Mutex
+    // 环形缓冲区，head为最早的变更
+    events []*proto.KVPair
+    head   int
+    size   int
+    // 已被淘汰的变更中最大的版本，从更早的版本开始监听时需要从持久的变更日志补发
+    evicted  int64
+    watchers map[*watcher]struct{}
+    // 本地存储写入新的变更时关闭并替换，唤醒等待持久变更日志的ChangeFeed
+    updated chan struct{}
+}
+
+// watcher 一个本地订阅者，events被关闭表示它已落后太多而被断开
+type watcher struct {
+    key    string
+    prefix string
+    events chan *proto.KVPair
+}
+
+// newChangeLog 节点启动前的变更不在内存日志中，evicted从当前时间开始
+func newChangeLog(size int) *changeLog {
+    return &changeLog{
+        size:     size,
+        evicted:  time.Now().UnixNano(),
+        watchers: make(map[*watcher]struct{}),
+        updated:  make(chan struct{}),
+    }
+}
+
+func (w *watcher) matches(key string) bool {
+    if w.key != "" {
+        return key == w.key
+    }
+    return strings.HasPrefix(key, w.prefix)
+}
+
+// publish 记录已写入本地存储的变更并发给匹配的订阅者
+func (l *changeLog) publish(pairs []*storage.KVPair) {
+    l.mutex.Lock()
+    defer l.mutex.Unlock()
+    
+    for _, pair := range pairs {
+        event := toProtoPair(pair)
+        switch {
+        case l.size <= 0:
+            l.evicted = max(l.evicted, event.Version)
+        case len(l.events) < l.size:
+            l.events = append(l.events, event)
+        default:
+            l.evicted = max(l.evicted, l.events[l.head].Version)
+            l.events[l.head] = event
+            l.head = (l.head + 1) % l.size
+        }
+        
+        for w := range l.watchers {
+            if !w.matches(event.Key) {
+                continue
+            }
+            select {
+            case w.events <- event:
+            default:
+                close(w.events)
+                delete(l.watchers, w)
+            }
+        }
+    }
+    l.signal()
+}
+
+// notify 通知本地存储写入了不经过Watch的变更，例如清理过期的记录
+func (l *changeLog) notify() {
+    l.mutex.Lock()
+    defer l.mutex.Unlock()
+    l.signal()
+}
+
+func (l *changeLog) signal() {
+    close(l.updated)
+    l.updated = make(chan struct{})
+}
+
+// changed 返回在下一次变更时关闭的channel
+func (l *changeLog) changed() <-chan struct{} {
+    l.mutex.Lock()
+    defer l.mutex.Unlock()
+    return l.updated
+}
+
+// subscribe 注册订阅者，并返回日志中版本大于start的匹配变更，start为0时只接收之后的变更。
+// 版本大于start的变更已有被淘汰的时，complete为false
+func (l *changeLog) subscribe(key, prefix string, start int64) (*watcher, []*proto.KVPair, bool) {
+    l.mutex.Lock()
+    defer l.mutex.Unlock()
+    
+    w := &watcher{
+        key:    key,
+        prefix: prefix,
+        events: make(chan *proto.KVPair, watcherBuffer),
+    }
+    var replay []*proto.KVPair
+    if start > 0 {
+        for i := range l.events {
+            event := l.events[(l.head+i)%len(l.events)]
+            if event.Version > start && w.matches(event.Key) {
+                replay = append(replay, event)
+            }
+        }
+    }
+    l.watchers[w] = struct{}{}
+    return w, replay, start == 0 || start >= l.evicted
+}
+
+func (l *changeLog) unsubscribe(w *watcher) {
+    l.mutex.Lock()
+    defer l.mutex.Unlock()
+    delete(l.watchers, w)
+}
+
+// applyPair 写入本地存储，记录被写入时通知监听者
+func (s *RushKVServer) applyPair(pair *storage.KVPair) (bool, error) {
+    applied, err := s.storage.Apply(pair)
+    if applied {
+        s.changes.publish([]*storage.KVPair{pair})
+    }
+    return applied, err
+}
+
+// applyPairs 原子地写入一批记录，并通知监听者其中被写入的记录
+func (s *RushKVServer) applyPairs(pairs []*storage.KVPair) ([]bool, error) {
+    applied, err := s.storage.ApplyPairs(pairs)
+    if err != nil {
+        return nil, err
+    }
+    
+    var changed []*storage.KVPair
+    for i, ok := range applied {
+        if ok {
+            changed = append(changed, pairs[i])
+        }
+    }
+    if len(changed) > 0 {
+        s.changes.publish(changed)
+    }
+    return applied, nil
+}
+
+// Watch 流式返回key或前缀下的变更。协调节点在所有节点上监听本地写入，
+// 同一个key的变更只发送版本更新的一次。成员变化或监听失败的节点过多时结束，
+// 客户端从最后收到的版本重新监听
+func (s *RushKVServer) Watch(req *proto.WatchRequest, stream proto.RushKV_WatchServer) error {
+    if req.Local {
+        return s.followChanges(stream.Context(), req, func(pair *proto.KVPair) error {
+            return stream.Send(watchEvent(pair))
+        })
+    }
+    
+    ctx, cancel := context.WithCancel(stream.Context())
+    defer cancel()
+    
+    start := req.StartVersion
+    if start == 0 {
+        start = s.clock.Now()
+    }
+    if err := stream.SendHeader(metadata.Pairs(watchStartHeader, strconv.FormatInt(start, 10))); err != nil {
+        return err
+    }
+    
+    s.mutex.RLock()
+    nodeIDs := make([]string, 0, len(s.nodes))
+    for nodeID := range s.nodes {
+        nodeIDs = append(nodeIDs, nodeID)
+    }
+    changed := s.membershipChanged
+    s.mutex.RUnlock()
+    
+    // 与Scan相同，少于N个节点失败时每个key至少还有一个副本在监听
+    tolerated := s.config.ReplicationFactor - 1
+    if tolerated > len(nodeIDs)-1 {
+        tolerated = len(nodeIDs) - 1
+    }
+    
+    type item struct {
+        nodeID string
+        pair   *proto.KVPair
+        err    error
+    }
+    items := make(chan item, watcherBuffer)
+    for _, nodeID := range nodeIDs {
+        go func(nodeID string) {
+            err := s.watchNode(ctx, nodeID, req, func(pair *proto.KVPair) error {
+                select {
+                case items <- item{nodeID: nodeID, pair: pair}:
+                    return nil
+                case <-ctx.Done():
+                    return ctx.Err()
+                }
+            })
+            select {
+            case items <- item{nodeID: nodeID, err: err}:
+            case <-ctx.Done():
+            }
+        }(nodeID)
+    }
+    
+    // sent记录每个key已发送的最新版本。progress是各节点已发来的最大版本，
+    // 版本低于最慢节点的进度减去去重窗口的记录定期清理，之后更旧的变更视为已发送
+    sent := make(map[string]int64)
+    progress := make(map[string]int64)
+    active := make(map[string]bool)
+    var pruned int64
+    pruneTicker := time.NewTicker(watchDedupeWindow)
+    defer pruneTicker.Stop()
+    
+    // 各节点的变更可能乱序到达，客户端不能从已收到的最大版本继续
+    defer func() {
+        resume := max(start, pruned, watchFloor(progress, s.clock.Last()-int64(watchDedupeWindow)))
+        stream.SetTrailer(metadata.Pairs(watchResumeHeader, strconv.FormatInt(resume, 10)))
+    }()
+    
+    failures := 0
+    for {
+        select {
+        case it := <-items:
+            if it.err != nil {
+                if status.Code(it.err) == codes.OutOfRange {
+                    return it.err
+                }
+                failures++
+                delete(progress, it.nodeID)
+                delete(active, it.nodeID)
+                log.Printf("Watch on node %s failed: %v", it.nodeID, it.err)
+                if failures > tolerated {
+                    return status.Errorf(codes.Unavailable, "watch failed on %d nodes", failures)
+                }
+                continue
+            }
+            progress[it.nodeID] = max(progress[it.nodeID], it.pair.Version)
+            active[it.nodeID] = true
+            // 各副本都会收到同一次写入，重放的旧版本也不再发送
+            if it.pair.Version <= pruned || it.pair.Version <= sent[it.pair.Key] {
+                continue
+            }
+            sent[it.pair.Key] = it.pair.Version
+            if err := stream.Send(watchEvent(it.pair)); err != nil {
+                return err
+            }
+        case <-pruneTicker.C:
+            pruned = pruneSent(sent, progress, active, s.clock.Last()-int64(watchDedupeWindow), pruned)
+        case <-changed:
+            return status.Error(codes.Unavailable, "cluster membership changed")
+        case <-s.stopCh:
+            return status.Error(codes.Unavailable, "server stopping")
+        case <-ctx.Done():
+            return ctx.Err()
+        }
+    }
+}
+
+// watchFloor 返回最慢节点的进度减去去重窗口，版本不高于它的变更都已到达协调节点。
+// 没有发来变更的节点视为已追上idle
+func watchFloor(progress map[string]int64, idle int64) int64 {
+    low := idle
+    for _, version := range progress {
+        low = min(low, version-int64(watchDedupeWindow))
+    }
+    return low
+}
+
+// pruneSent 删除sent中版本低于最慢节点进度减去去重窗口的记录，返回新的清理上限。
+// 上次清理后没有发来变更的节点没有积压，不计入最慢节点；所有节点都空闲时以idle为上限
+func pruneSent(sent, progress map[string]int64, active map[string]bool, idle, pruned int64) int64 {
+    low := idle
+    for nodeID := range active {
+        low = min(low, progress[nodeID]-int64(watchDedupeWindow))
+        delete(active, nodeID)
+    }
+    if low <= pruned {
+        return pruned
+    }
+    
+    for key, version := range sent {
+        if version <= low {
+            delete(sent, key)
+        }
+    }
+    return low
+}
+
+// watchNode 监听一个节点的本地变更并对每个变更调用fn，直到出错或ctx取消
+func (s *RushKVServer) watchNode(ctx context.Context, nodeID string, req *proto.WatchRequest, fn func(*proto.KVPair) error) error {
+    if nodeID == s.nodeID {
+        return s.followChanges(ctx, req, fn)
+    }
+    
+    peer, err := s.peerClient(nodeID)
+    if err != nil {
+        return err
+    }
+    stream, err := peer.Watch(ctx, &proto.WatchRequest{
+        Key:          req.Key,
+        Prefix:       req.Prefix,
+        StartVersion: req.StartVersion,
+        Local:        true,
+    })
+    if err != nil {
+        return err
+    }
+    for {
+        event, err := stream.Recv()
+        if err != nil {
+            return err
+        }
+        if err := fn(event.Pair); err != nil {
+            return err
+        }
+    }
+}
+
+// followChanges 先补发本地日志中起始版本之后的变更，再持续发送新的变更。
+// 内存日志已淘汰了起始版本之后的变更时（例如节点重启后），从持久的变更日志补发
+func (s *RushKVServer) followChanges(ctx context.Context, req *proto.WatchRequest, fn func(*proto.KVPair) error) error {
+    w, replay, complete := s.changes.subscribe(req.Key, req.Prefix, req.StartVersion)
+    defer s.changes.unsubscribe(w)
+    
+    if !complete {
+        if err := s.replayChangeFeed(w, req.StartVersion, fn); err != nil {
+            return err
+        }
+    }
+    for _, pair := range replay {
+        if err := fn(pair); err != nil {
+            return err
+        }
+    }
+    for {
+        select {
+        case pair, ok := <-w.events:
+            if !ok {
+                return status.Error(codes.ResourceExhausted, "watcher fell behind")
+            }
+            if err := fn(pair); err != nil {
+                return err
+            }
+        case <-s.stopCh:
+            return status.Error(codes.Unavailable, "server stopping")
+        case <-ctx.Done():
+            return ctx.Err()
+        }
+    }
+}
+
+// replayChangeFeed 从持久的变更日志补发版本大于start的匹配写入。已清理的变更中可能有版本大于start的时返回OutOfRange，
+// 变更日志按写入顺序追加，版本只在去重窗口内乱序
+func (s *RushKVServer) replayChangeFeed(w *watcher, start int64, fn func(*proto.KVPair) error) error {
+    first, last, err := s.storage.ChangeBounds()
+    if err != nil {
+        return status.Errorf(codes.Internal, "failed to read change log: %v", err)
+    }
+    
+    trimmed := first > 1
+    for from := first; from <= last; {
+        changes, err := s.storage.Changes(from, changeFeedBatchSize)
+        if err != nil {
+            return status.Errorf(codes.Internal, "failed to read change log: %v", err)
+        }
+        if len(changes) == 0 {
+            break
+        }
+        if trimmed && changes[0].Pair.Version > start-int64(watchDedupeWindow) {
+            return status.Errorf(codes.OutOfRange, "changes after version %d are no longer retained", start)
+        }
+        trimmed = false
+        
+        for _, change := range changes {
+            if change.Type == storage.ChangeExpire || change.Pair.Version <= start || !w.matches(change.Pair.Key) {
+                continue
+            }
+            if err := fn(toProtoPair(change.Pair)); err != nil {
+                return err
+            }
+        }
+        from = changes[len(changes)-1].Seq + 1
+    }
+    if trimmed {
+        return status.Errorf(codes.OutOfRange, "changes after version %d are no longer retained", start)
+    }
+    return nil
+}
+
+func watchEvent(pair *proto.KVPair) *proto.WatchEvent {
+    eventType := proto.TxnOpType_PUT
+    if pair.Deleted {
+        eventType = proto.TxnOpType_DELETE
+    }
+    return &proto.WatchEvent{
+        Type: eventType,
+        Pair: pair,
+    }
+}
\ No newline at end of file
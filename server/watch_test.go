@@ -0,0 +1,159 @@
+package server
+
+import (
+    "context"
+    "testing"
+    "time"
+    
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "rushkv/client"
+    "rushkv/proto"
+    "rushkv/storage"
+)
+
+func TestPruneSentKeepsWindowBehindSlowestNode(t *testing.T) {
+    window := int64(watchDedupeWindow)
+    now := int64(100 * time.Second)
+    sent := map[string]int64{
+        "old":    now - 3*window,
+        "recent": now - window/2,
+        "slow":   now - 2*window + 1,
+    }
+    // node2仍在发送较旧的变更，只能清理比它的进度更早一个窗口的记录
+    progress := map[string]int64{"node1": now, "node2": now - window}
+    active := map[string]bool{"node1": true, "node2": true}
+    
+    pruned := pruneSent(sent, progress, active, now-window, 0)
+    if pruned != now-2*window {
+        t.Fatalf("pruned = %d, want %d", pruned, now-2*window)
+    }
+    if _, ok := sent["old"]; ok {
+        t.Error("entry below the slowest node's window was kept")
+    }
+    if len(sent) != 2 {
+        t.Errorf("sent = %v, want recent and slow kept", sent)
+    }
+    if len(active) != 0 {
+        t.Errorf("active = %v, want reset after pruning", active)
+    }
+    
+    // 所有节点都空闲时清理到idle
+    pruned = pruneSent(sent, progress, active, now-window/4, pruned)
+    if pruned != now-window/4 || len(sent) != 0 {
+        t.Errorf("idle prune = %d with %v left, want %d and none", pruned, sent, now-window/4)
+    }
+    
+    // 清理上限不会后退
+    if got := pruneSent(sent, progress, map[string]bool{"node2": true}, now, pruned); got != pruned {
+        t.Errorf("pruned moved back to %d from %d", got, pruned)
+    }
+}
+
+func watcherCount(s *RushKVServer) int {
+    s.changes.mutex.Lock()
+    defer s.changes.mutex.Unlock()
+    return len(s.changes.watchers)
+}
+
+func waitUntil(t *testing.T, what string, cond func() bool) {
+    t.Helper()
+    deadline := time.Now().Add(5 * time.Second)
+    for !cond() {
+        if time.Now().After(deadline) {
+            t.Fatalf("timed out waiting for %s", what)
+        }
+        time.Sleep(5 * time.Millisecond)
+    }
+}
+
+func TestWatchResumesBelowOutOfOrderEvents(t *testing.T) {
+    servers := newTestCluster(t, 2, func(config *Config) {
+        config.ReplicationFactor = 1
+    })
+    node1, node2 := servers[0], servers[1]
+    
+    c, err := client.NewRushKVClient(memberAddresses(node1)["node1"])
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer c.Close()
+    
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    events := make(chan *client.WatchEvent, 16)
+    go c.WatchPrefix(ctx, "", func(event *client.WatchEvent) error {
+        events <- event
+        return nil
+    })
+    waitUntil(t, "the watch to start", func() bool {
+        return watcherCount(node1) > 0 && watcherCount(node2) > 0
+    })
+    
+    a := node1.newPair("a", []byte("1"))
+    if _, err := node1.applyPairs([]*storage.KVPair{a}); err != nil {
+        t.Fatal(err)
+    }
+    if event := <-events; event.Key != "a" {
+        t.Fatalf("first event = %+v, want a", event)
+    }
+    
+    // 成员变化结束监听，node2上版本更低的写入在断开后才到达
+    setMembers(node1, 2, memberAddresses(node1), "node1", "node2")
+    waitUntil(t, "the watch to end", func() bool {
+        return watcherCount(node2) == 0
+    })
+    b := &storage.KVPair{Key: "b", Value: []byte("2"), Version: a.Version - int64(time.Millisecond), Timestamp: time.Now()}
+    if _, err := node2.applyPairs([]*storage.KVPair{b}); err != nil {
+        t.Fatal(err)
+    }
+    
+    select {
+    case event := <-events:
+        if event.Key != "b" || event.Version != b.Version {
+            t.Fatalf("event after reconnect = %+v, want b at version %d", event, b.Version)
+        }
+    case <-time.After(5 * time.Second):
+        t.Fatal("out-of-order change was lost across the reconnect")
+    }
+    select {
+    case event := <-events:
+        t.Errorf("unexpected event %+v, replayed changes should not be delivered again", event)
+    case <-time.After(200 * time.Millisecond):
+    }
+}
+
+func TestFollowChangesReplaysChangeFeedAfterRestart(t *testing.T) {
+    s := newTestServer(t, "node1", nil)
+    setMembers(s, 1, nil, "node1")
+    
+    pair := s.newPair("a", []byte("1"))
+    if _, err := s.applyPairs([]*storage.KVPair{pair}); err != nil {
+        t.Fatal(err)
+    }
+    // 重启后内存中的变更日志为空
+    s.changes = newChangeLog(s.config.WatchLogSize)
+    
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+    var replayed []*proto.KVPair
+    err := s.followChanges(ctx, &proto.WatchRequest{StartVersion: pair.Version - 1}, func(pair *proto.KVPair) error {
+        replayed = append(replayed, pair)
+        cancel()
+        return nil
+    })
+    if len(replayed) != 1 || replayed[0].Key != "a" || replayed[0].Version != pair.Version {
+        t.Fatalf("replayed = %v, %v, want a from the change log", replayed, err)
+    }
+    
+    // 变更日志被清理后无法确认没有漏掉变更
+    if _, err := s.storage.TrimChanges(time.Now().Add(time.Hour), 0); err != nil {
+        t.Fatal(err)
+    }
+    err = s.followChanges(context.Background(), &proto.WatchRequest{StartVersion: pair.Version - 1}, func(*proto.KVPair) error {
+        return nil
+    })
+    if status.Code(err) != codes.OutOfRange {
+        t.Errorf("followChanges after trimming = %v, want OutOfRange", err)
+    }
+}
@@ -0,0 +1,59 @@
+package storage
+
+import "hash/fnv"
+
+// 每个key占用的位数，约1%的误判率
+const bloomBitsPerKey = 10
+
+// bloomFilter SSTable的布隆过滤器，最后一个字节保存哈希函数的个数
+type bloomFilter []byte
+
+func newBloomFilter(keys []string) bloomFilter {
+    // k = bitsPerKey * ln2
+    k := bloomBitsPerKey * 69 / 100
+    if k < 1 {
+        k = 1
+    }
+    bits := len(keys) * bloomBitsPerKey
+    if bits < 64 {
+        bits = 64
+    }
+    bytes := (bits + 7) / 8
+    bits = bytes * 8
+    
+    filter := make(bloomFilter, bytes+1)
+    filter[bytes] = byte(k)
+    for _, key := range keys {
+        h1, h2 := bloomHash(key)
+        for i := 0; i < k; i++ {
+            bit := (h1 + uint32(i)*h2) % uint32(bits)
+            filter[bit/8] |= 1 << (bit % 8)
+        }
+    }
+    return filter
+}
+
+// mayContain 返回false时key一定不在过滤器中
+func (f bloomFilter) mayContain(key string) bool {
+    if len(f) < 2 {
+        return true
+    }
+    bits := uint32(len(f)-1) * 8
+    k := int(f[len(f)-1])
+    h1, h2 := bloomHash(key)
+    for i := 0; i < k; i++ {
+        bit := (h1 + uint32(i)*h2) % bits
+        if f[bit/8]&(1<<(bit%8)) == 0 {
+            return false
+        }
+    }
+    return true
+}
+
+// bloomHash 用一个64位哈希的高低两半做双重哈希
+func bloomHash(key string) (uint32, uint32) {
+    h := fnv.New64a()
+    h.Write([]byte(key))
+    sum := h.Sum64()
+    return uint32(sum), uint32(sum>>32) | 1
+}
\ No newline at end of file
@@ -0,0 +1,446 @@
+package storage
+
+import (
+    "fmt"
+    "io"
+    "log"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+    
+    "github.com/boltdb/bolt"
+)
+
+// BoltEngine 基于BoltDB的存储引擎，数据保存在数据目录下的rushkv.db中
+type BoltEngine struct {
+    db       *bolt.DB
+    dataPath string
+    mutex    sync.RWMutex
+    // 每个key最多保留的版本数（包括当前版本）
+    historyVersions int
+    // 每个节点积压的提示数，打开时从文件统计，避免每次保存提示都遍历整个bucket
+    hintCounts map[string]int
+    // 同时只进行一次文件压缩
+    compactMutex sync.Mutex
+    // 后台格式迁移，不需要迁移时migrateDone已关闭
+    migrateStop chan struct{}
+    migrateDone chan struct{}
+    stopOnce    sync.Once
+}
+
+func NewBoltEngine(dataPath string) (*BoltEngine, error) {
+    if err := os.MkdirAll(dataPath, 0755); err != nil {
+        return nil, fmt.Errorf("failed to create data directory: %v", err)
+    }
+    
+    dbPath := filepath.Join(dataPath, "rushkv.db")
+    db, err := bolt.Open(dbPath, 0600, &bolt.Options{
+        Timeout: 1 * time.Second,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("failed to open database: %v", err)
+    }
+    
+    // 检查格式版本并创建默认bucket
+    var format int
+    var hintCounts map[string]int
+    err = db.Update(func(tx *bolt.Tx) error {
+        var err error
+        if format, err = initFormat(tx); err != nil {
+            return err
+        }
+        if _, err = tx.CreateBucketIfNotExists([]byte("kv")); err != nil {
+            return err
+        }
+        hintCounts = countHints(tx)
+        return nil
+    })
+    if err != nil {
+        db.Close()
+        return nil, fmt.Errorf("failed to initialize database: %v", err)
+    }
+    
+    se := &BoltEngine{
+        db:              db,
+        dataPath:        dataPath,
+        historyVersions: DefaultHistoryVersions,
+        hintCounts:      hintCounts,
+        migrateStop:     make(chan struct{}),
+        migrateDone:     make(chan struct{}),
+    }
+    if format < CurrentFormat {
+        log.Printf("Storage format %d is outdated, migrating to format %d in background", format, CurrentFormat)
+        go se.migrate()
+    } else {
+        close(se.migrateDone)
+    }
+    return se, nil
+}
+
+func (se *BoltEngine) Put(key string, value []byte, version int64) error {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    kvPair := &KVPair{
+        Key:       key,
+        Value:     value,
+        Version:   version,
+        Timestamp: time.Now(),
+        Deleted:   false,
+    }
+    
+    data := encodePair(kvPair)
+    
+    return se.db.Update(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket([]byte("kv"))
+        if err := bucket.Put([]byte(key), data); err != nil {
+            return err
+        }
+        return appendChange(tx, ChangePut, kvPair)
+    })
+}
+
+func (se *BoltEngine) Get(key string) ([]byte, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var result []byte
+    err := se.db.View(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket([]byte("kv"))
+        data := bucket.Get([]byte(key))
+        if data == nil {
+            return fmt.Errorf("key not found")
+        }
+        
+        kvPair, err := decodePair(data)
+        if err != nil {
+            return err
+        }
+        
+        if kvPair.Deleted || kvPair.Expired(time.Now()) {
+            return fmt.Errorf("key not found")
+        }
+        
+        result = kvPair.Value
+        return nil
+    })
+    
+    return result, err
+}
+
+func (se *BoltEngine) Delete(key string, version int64) error {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    return se.db.Update(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket([]byte("kv"))
+        data := bucket.Get([]byte(key))
+        if data == nil {
+            return fmt.Errorf("key not found")
+        }
+        
+        kvPair, err := decodePair(data)
+        if err != nil {
+            return err
+        }
+        
+        kvPair.Deleted = true
+        kvPair.Version = version
+        kvPair.Timestamp = time.Now()
+        
+        if err := bucket.Put([]byte(key), encodePair(kvPair)); err != nil {
+            return err
+        }
+        return appendChange(tx, ChangeDelete, kvPair)
+    })
+}
+
+// GetPair 返回key对应的完整记录（包括墓碑），不存在时返回nil
+func (se *BoltEngine) GetPair(key string) (*KVPair, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var result *KVPair
+    err := se.db.View(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket([]byte("kv"))
+        data := bucket.Get([]byte(key))
+        if data == nil {
+            return nil
+        }
+        
+        kvPair, err := decodePair(data)
+        if err != nil {
+            return err
+        }
+        
+        result = kvPair
+        return nil
+    })
+    
+    return result, err
+}
+
+// Apply 写入一条已带版本的记录，只有版本不低于本地记录时才会覆盖
+// 返回值表示记录是否被写入
+func (se *BoltEngine) Apply(pair *KVPair) (bool, error) {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    applied := false
+    err := se.db.Update(func(tx *bolt.Tx) error {
+        var err error
+        applied, err = se.applyPair(tx, pair)
+        return err
+    })
+    
+    return applied, err
+}
+
+// ApplyPairs 在一个事务中按Apply的规则写入一批记录，返回每条记录是否被写入
+func (se *BoltEngine) ApplyPairs(pairs []*KVPair) ([]bool, error) {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    applied := make([]bool, len(pairs))
+    err := se.db.Update(func(tx *bolt.Tx) error {
+        for i, pair := range pairs {
+            ok, err := se.applyPair(tx, pair)
+            if err != nil {
+                return err
+            }
+            applied[i] = ok
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    return applied, nil
+}
+
+// applyPair 已有记录的版本更新时不写入，较旧的记录只存入历史版本，
+// 被取代的当前记录转入历史版本，写入的记录追加到变更日志
+func (se *BoltEngine) applyPair(tx *bolt.Tx, pair *KVPair) (bool, error) {
+    bucket := tx.Bucket([]byte("kv"))
+    if existing := bucket.Get([]byte(pair.Key)); existing != nil {
+        current, err := decodePair(existing)
+        if err != nil {
+            return false, err
+        }
+        if current.Version > pair.Version {
+            return false, se.archive(tx, pair)
+        }
+        if current.Version < pair.Version {
+            if err := se.archive(tx, current); err != nil {
+                return false, err
+            }
+        }
+    }
+    
+    if err := bucket.Put([]byte(pair.Key), encodePair(pair)); err != nil {
+        return false, err
+    }
+    return true, appendChange(tx, pairChange(pair), pair)
+}
+
+// ListPairs 按key顺序返回至多limit条key大于after的记录（包括墓碑）
+func (se *BoltEngine) ListPairs(after string, limit int) ([]*KVPair, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var pairs []*KVPair
+    err := se.db.View(func(tx *bolt.Tx) error {
+        c := tx.Bucket([]byte("kv")).Cursor()
+        k, v := c.Seek([]byte(after))
+        if k != nil && string(k) == after {
+            k, v = c.Next()
+        }
+        for ; k != nil && len(pairs) < limit; k, v = c.Next() {
+            kvPair, err := decodePair(v)
+            if err != nil {
+                return err
+            }
+            pairs = append(pairs, kvPair)
+        }
+        return nil
+    })
+    
+    return pairs, err
+}
+
+// ScanPairs 返回key在[start, end)内的至多limit条记录（包括墓碑），end为空表示不设上界，
+// reverse为true时按key逆序返回
+func (se *BoltEngine) ScanPairs(start, end string, reverse bool, limit int) ([]*KVPair, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var pairs []*KVPair
+    err := se.db.View(func(tx *bolt.Tx) error {
+        c := tx.Bucket([]byte("kv")).Cursor()
+        
+        var k, v []byte
+        if !reverse {
+            k, v = c.Seek([]byte(start))
+        } else if end == "" {
+            k, v = c.Last()
+        } else if k, v = c.Seek([]byte(end)); k == nil {
+            // end之后没有记录，从最后一条开始
+            k, v = c.Last()
+        } else {
+            k, v = c.Prev()
+        }
+        
+        for k != nil && len(pairs) < limit {
+            if !reverse && end != "" && string(k) >= end {
+                break
+            }
+            if reverse && string(k) < start {
+                break
+            }
+            
+            kvPair, err := decodePair(v)
+            if err != nil {
+                return err
+            }
+            pairs = append(pairs, kvPair)
+            
+            if reverse {
+                k, v = c.Prev()
+            } else {
+                k, v = c.Next()
+            }
+        }
+        return nil
+    })
+    
+    return pairs, err
+}
+
+// Purge 物理删除key的记录及其历史版本，仅当记录版本仍为version时生效，避免误删之后写入的新数据
+func (se *BoltEngine) Purge(key string, version int64) error {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    return se.db.Update(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket([]byte("kv"))
+        data := bucket.Get([]byte(key))
+        if data == nil {
+            return nil
+        }
+        
+        kvPair, err := decodePair(data)
+        if err != nil {
+            return err
+        }
+        if kvPair.Version != version {
+            return nil
+        }
+        
+        if err := dropHistory(tx, key); err != nil {
+            return err
+        }
+        return bucket.Delete([]byte(key))
+    })
+}
+
+// PurgePairs 在一个事务中物理删除一批记录及其历史版本，版本已变化的记录不会被删除，返回删除的数量。
+// 删除已过期的记录时追加过期变更
+func (se *BoltEngine) PurgePairs(pairs []*KVPair) (int, error) {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    now := time.Now()
+    purged := 0
+    err := se.db.Update(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket([]byte("kv"))
+        for _, pair := range pairs {
+            data := bucket.Get([]byte(pair.Key))
+            if data == nil {
+                continue
+            }
+            
+            kvPair, err := decodePair(data)
+            if err != nil {
+                return err
+            }
+            if kvPair.Version != pair.Version {
+                continue
+            }
+            
+            if err := dropHistory(tx, pair.Key); err != nil {
+                return err
+            }
+            if err := bucket.Delete([]byte(pair.Key)); err != nil {
+                return err
+            }
+            if !kvPair.Deleted && kvPair.Expired(now) {
+                if err := appendChange(tx, ChangeExpire, kvPair); err != nil {
+                    return err
+                }
+            }
+            purged++
+        }
+        return nil
+    })
+    
+    return purged, err
+}
+
+// Count 返回记录总数（包括墓碑）
+func (se *BoltEngine) Count() (int, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var count int
+    err := se.db.View(func(tx *bolt.Tx) error {
+        count = tx.Bucket([]byte("kv")).Stats().KeyN
+        return nil
+    })
+    
+    return count, err
+}
+
+// Snapshot 在一个只读事务中写出全部记录及其历史版本
+func (se *BoltEngine) Snapshot(w io.Writer) error {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    return se.db.View(func(tx *bolt.Tx) error {
+        return writeSnapshot(w, func(fn func(*KVPair) error) error {
+            return tx.Bucket([]byte("kv")).ForEach(func(k, v []byte) error {
+                current, err := decodePair(v)
+                if err != nil {
+                    return err
+                }
+                if err := fn(current); err != nil {
+                    return err
+                }
+                
+                walkErr := walkHistory(tx, current.Key, func(pair *KVPair) bool {
+                    if pair.Version == current.Version {
+                        return true
+                    }
+                    err = fn(pair)
+                    return err == nil
+                })
+                if walkErr != nil {
+                    return walkErr
+                }
+                return err
+            })
+        })
+    })
+}
+
+// Restore 写入Snapshot生成的数据，与已有记录按版本合并
+func (se *BoltEngine) Restore(r io.Reader) error {
+    return restoreSnapshot(r, se.ApplyPairs)
+}
+
+func (se *BoltEngine) Close() error {
+    se.stopOnce.Do(func() { close(se.migrateStop) })
+    <-se.migrateDone
+    return se.db.Close()
+}
\ No newline at end of file
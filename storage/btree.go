@@ -0,0 +1,268 @@
+package storage
+
+import (
+    "slices"
+    "sort"
+)
+
+// B树的最小度数，除根节点外每个节点保存degree-1到2*degree-1条记录
+const (
+    btreeDegree   = 32
+    btreeMaxItems = 2*btreeDegree - 1
+    btreeMinItems = btreeDegree - 1
+)
+
+// btreeItem 可以保存在B树中的记录
+type btreeItem interface {
+    itemKey() string
+}
+
+// btree 按key排序保存记录的B树，不是并发安全的，由内存引擎加锁访问
+type btree[T btreeItem] struct {
+    root   *btreeNode[T]
+    length int
+}
+
+type btreeNode[T btreeItem] struct {
+    items    []T
+    children []*btreeNode[T]
+}
+
+func (t *btree[T]) Len() int {
+    return t.length
+}
+
+// Get 返回key对应的记录，不存在时返回零值
+func (t *btree[T]) Get(key string) T {
+    for n := t.root; n != nil; {
+        i, found := n.find(key)
+        if found {
+            return n.items[i]
+        }
+        if n.leaf() {
+            break
+        }
+        n = n.children[i]
+    }
+    var zero T
+    return zero
+}
+
+// Set 写入记录，替换同key的已有记录
+func (t *btree[T]) Set(item T) {
+    if t.root == nil {
+        t.root = &btreeNode[T]{}
+    }
+    if len(t.root.items) >= btreeMaxItems {
+        // 根节点已满时先分裂，树高加一
+        old := t.root
+        t.root = &btreeNode[T]{children: []*btreeNode[T]{old}}
+        t.root.split(0)
+    }
+    if t.root.insert(item) {
+        t.length++
+    }
+}
+
+// Delete 删除key对应的记录，返回是否存在
+func (t *btree[T]) Delete(key string) bool {
+    if t.root == nil {
+        return false
+    }
+    removed := t.root.remove(key)
+    if len(t.root.items) == 0 {
+        if t.root.leaf() {
+            t.root = nil
+        } else {
+            t.root = t.root.children[0]
+        }
+    }
+    if removed {
+        t.length--
+    }
+    return removed
+}
+
+// Ascend 按key升序遍历不小于start的记录，fn返回false时停止
+func (t *btree[T]) Ascend(start string, fn func(T) bool) {
+    if t.root != nil {
+        t.root.ascend(start, fn)
+    }
+}
+
+// Descend 按key降序遍历小于end的记录，end为空时遍历全部记录，fn返回false时停止
+func (t *btree[T]) Descend(end string, fn func(T) bool) {
+    if t.root != nil {
+        t.root.descend(end, fn)
+    }
+}
+
+func (n *btreeNode[T]) leaf() bool {
+    return len(n.children) == 0
+}
+
+// find 返回第一条key不小于key的记录的位置，以及该记录的key是否等于key
+func (n *btreeNode[T]) find(key string) (int, bool) {
+    i := sort.Search(len(n.items), func(i int) bool {
+        return n.items[i].itemKey() >= key
+    })
+    return i, i < len(n.items) && n.items[i].itemKey() == key
+}
+
+// split 把已满的第i个子节点从中间分裂为两个，中间的记录上移到n
+func (n *btreeNode[T]) split(i int) {
+    child := n.children[i]
+    mid := len(child.items) / 2
+    item := child.items[mid]
+    
+    next := &btreeNode[T]{items: append([]T(nil), child.items[mid+1:]...)}
+    if !child.leaf() {
+        next.children = append([]*btreeNode[T](nil), child.children[mid+1:]...)
+        child.children = child.children[:mid+1]
+    }
+    child.items = child.items[:mid]
+    
+    n.items = slices.Insert(n.items, i, item)
+    n.children = append(n.children, nil)
+    copy(n.children[i+2:], n.children[i+1:])
+    n.children[i+1] = next
+}
+
+// insert 向未满的节点写入记录，返回是否新增了记录
+func (n *btreeNode[T]) insert(item T) bool {
+    i, found := n.find(item.itemKey())
+    if found {
+        n.items[i] = item
+        return false
+    }
+    if n.leaf() {
+        n.items = slices.Insert(n.items, i, item)
+        return true
+    }
+    
+    if len(n.children[i].items) >= btreeMaxItems {
+        n.split(i)
+        switch {
+        case item.itemKey() == n.items[i].itemKey():
+            n.items[i] = item
+            return false
+        case item.itemKey() > n.items[i].itemKey():
+            i++
+        }
+    }
+    return n.children[i].insert(item)
+}
+
+// remove 从以n为根的子树中删除key，下降前保证子节点的记录数多于下限，
+// 删除后不会出现记录数不足的节点
+func (n *btreeNode[T]) remove(key string) bool {
+    i, found := n.find(key)
+    if n.leaf() {
+        if !found {
+            return false
+        }
+        n.items = append(n.items[:i], n.items[i+1:]...)
+        return true
+    }
+    
+    if len(n.children[i].items) <= btreeMinItems {
+        n.grow(i)
+        return n.remove(key)
+    }
+    if found {
+        // 用左子树中最大的记录替换被删除的记录
+        n.items[i] = n.children[i].removeMax()
+        return true
+    }
+    return n.children[i].remove(key)
+}
+
+// removeMax 删除并返回子树中key最大的记录
+func (n *btreeNode[T]) removeMax() T {
+    if n.leaf() {
+        item := n.items[len(n.items)-1]
+        n.items = n.items[:len(n.items)-1]
+        return item
+    }
+    
+    last := len(n.children) - 1
+    if len(n.children[last].items) <= btreeMinItems {
+        n.grow(last)
+        return n.removeMax()
+    }
+    return n.children[last].removeMax()
+}
+
+// grow 让第i个子节点多出至少一条记录：从相邻节点借一条，或与相邻节点合并
+func (n *btreeNode[T]) grow(i int) {
+    child := n.children[i]
+    switch {
+    case i > 0 && len(n.children[i-1].items) > btreeMinItems:
+        left := n.children[i-1]
+        child.items = append([]T{n.items[i-1]}, child.items...)
+        n.items[i-1] = left.items[len(left.items)-1]
+        left.items = left.items[:len(left.items)-1]
+        if !left.leaf() {
+            child.children = append([]*btreeNode[T]{left.children[len(left.children)-1]}, child.children...)
+            left.children = left.children[:len(left.children)-1]
+        }
+    
+    case i < len(n.items) && len(n.children[i+1].items) > btreeMinItems:
+        right := n.children[i+1]
+        child.items = append(child.items, n.items[i])
+        n.items[i] = right.items[0]
+        right.items = append(right.items[:0], right.items[1:]...)
+        if !right.leaf() {
+            child.children = append(child.children, right.children[0])
+            right.children = append(right.children[:0], right.children[1:]...)
+        }
+    
+    default:
+        // 与右侧节点合并，最后一个子节点与左侧节点合并
+        if i >= len(n.items) {
+            i--
+            child = n.children[i]
+        }
+        right := n.children[i+1]
+        child.items = append(child.items, n.items[i])
+        child.items = append(child.items, right.items...)
+        child.children = append(child.children, right.children...)
+        n.items = append(n.items[:i], n.items[i+1:]...)
+        n.children = append(n.children[:i+1], n.children[i+2:]...)
+    }
+}
+
+func (n *btreeNode[T]) ascend(start string, fn func(T) bool) bool {
+    i, _ := n.find(start)
+    for ; i < len(n.items); i++ {
+        if !n.leaf() && !n.children[i].ascend(start, fn) {
+            return false
+        }
+        if !fn(n.items[i]) {
+            return false
+        }
+    }
+    if !n.leaf() {
+        return n.children[len(n.items)].ascend(start, fn)
+    }
+    return true
+}
+
+func (n *btreeNode[T]) descend(end string, fn func(T) bool) bool {
+    i := len(n.items)
+    if end != "" {
+        i, _ = n.find(end)
+    }
+    if !n.leaf() && !n.children[i].descend(end, fn) {
+        return false
+    }
+    for i--; i >= 0; i-- {
+        if !fn(n.items[i]) {
+            return false
+        }
+        if !n.leaf() && !n.children[i].descend(end, fn) {
+            return false
+        }
+    }
+    return true
+}
\ No newline at end of file
@@ -0,0 +1,192 @@
+package storage
+
+import (
+    "encoding/binary"
+    "time"
+    
+    "github.com/boltdb/bolt"
+)
+
+var changesBucket = []byte("changes")
+
+// 清理变更日志时每个写事务最多删除的变更数
+const trimBatchSize = 10000
+
+// ChangeType 变更日志中变更的类型
+type ChangeType uint8
+
+const (
+    ChangePut    ChangeType = iota // 写入新的值
+    ChangeDelete                   // 写入墓碑
+    ChangeExpire                   // 过期的记录被清理
+)
+
+func (t ChangeType) String() string {
+    switch t {
+    case ChangePut:
+        return "put"
+    case ChangeDelete:
+        return "delete"
+    case ChangeExpire:
+        return "expire"
+    default:
+        return "unknown"
+    }
+}
+
+// Change 变更日志中的一条变更。Seq在本节点内单调递增，清理后也不会重复使用
+type Change struct {
+    Seq  uint64
+    Type ChangeType
+    Pair *KVPair
+    // 变更写入本节点的时间
+    Time time.Time
+}
+
+func (c *Change) clone() *Change {
+    cc := *c
+    cc.Pair = c.Pair.clone()
+    return &cc
+}
+
+// pairChange 写入的记录对应的变更类型
+func pairChange(pair *KVPair) ChangeType {
+    if pair.Deleted {
+        return ChangeDelete
+    }
+    return ChangePut
+}
+
+// appendChange 在写入记录的同一个事务中追加变更
+func appendChange(tx *bolt.Tx, changeType ChangeType, pair *KVPair) error {
+    bucket, err := tx.CreateBucketIfNotExists(changesBucket)
+    if err != nil {
+        return err
+    }
+    seq, err := bucket.NextSequence()
+    if err != nil {
+        return err
+    }
+    return bucket.Put(changeKey(seq), encodeChange(&Change{
+        Type: changeType,
+        Pair: pair,
+        Time: time.Now(),
+    }))
+}
+
+// Changes 按序号返回至多limit条序号不小于from的变更
+func (se *BoltEngine) Changes(from uint64, limit int) ([]*Change, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var changes []*Change
+    err := se.db.View(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket(changesBucket)
+        if bucket == nil {
+            return nil
+        }
+        
+        c := bucket.Cursor()
+        for k, v := c.Seek(changeKey(from)); k != nil && len(changes) < limit; k, v = c.Next() {
+            change, err := decodeChange(v)
+            if err != nil {
+                return err
+            }
+            change.Seq = binary.BigEndian.Uint64(k)
+            changes = append(changes, change)
+        }
+        return nil
+    })
+    
+    return changes, err
+}
+
+// ChangeBounds 返回保留的最早和最新变更的序号，bucket的序号在清理后仍然保留
+func (se *BoltEngine) ChangeBounds() (uint64, uint64, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var first, last uint64
+    err := se.db.View(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket(changesBucket)
+        if bucket == nil {
+            return nil
+        }
+        last = bucket.Sequence()
+        if k, _ := bucket.Cursor().First(); k != nil {
+            first = binary.BigEndian.Uint64(k)
+        }
+        return nil
+    })
+    if first == 0 {
+        first = last + 1
+    }
+    
+    return first, last, err
+}
+
+// TrimChanges 分批删除发生时间早于before或超出maxEntries条的最旧变更，返回删除的数量
+func (se *BoltEngine) TrimChanges(before time.Time, maxEntries int) (int, error) {
+    trimmed := 0
+    for {
+        done := false
+        se.mutex.Lock()
+        err := se.db.Update(func(tx *bolt.Tx) error {
+            bucket := tx.Bucket(changesBucket)
+            if bucket == nil {
+                done = true
+                return nil
+            }
+            
+            // 序号连续，保留的变更数由序号计算
+            last := bucket.Sequence()
+            var keys [][]byte
+            c := bucket.Cursor()
+            k, v := c.First()
+            for ; k != nil && len(keys) < trimBatchSize; k, v = c.Next() {
+                expired, err := changeExpired(binary.BigEndian.Uint64(k), v, last, before, maxEntries)
+                if err != nil {
+                    return err
+                }
+                if !expired {
+                    break
+                }
+                keys = append(keys, append([]byte(nil), k...))
+            }
+            done = len(keys) < trimBatchSize
+            
+            for _, k := range keys {
+                if err := bucket.Delete(k); err != nil {
+                    return err
+                }
+            }
+            trimmed += len(keys)
+            return nil
+        })
+        se.mutex.Unlock()
+        if err != nil || done {
+            return trimmed, err
+        }
+    }
+}
+
+// changeExpired 判断最旧的一条变更是否应该被清理，只有按时间清理时才需要解码
+func changeExpired(seq uint64, data []byte, last uint64, before time.Time, maxEntries int) (bool, error) {
+    if maxEntries > 0 && last-seq+1 > uint64(maxEntries) {
+        return true, nil
+    }
+    if before.IsZero() {
+        return false, nil
+    }
+    change, err := decodeChange(data)
+    if err != nil {
+        return false, err
+    }
+    return change.Time.Before(before), nil
+}
+
+func changeKey(seq uint64) []byte {
+    key := make([]byte, 8)
+    binary.BigEndian.PutUint64(key, seq)
+    return key
+}
\ No newline at end of file
@@ -0,0 +1,248 @@
+package storage
+
+import (
+    "encoding/binary"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "time"
+)
+
+// 数据库的存储格式版本
+const (
+    // FormatJSON 记录、提示和事务以JSON保存，没有格式版本的旧数据库使用该格式
+    FormatJSON = 1
+    // FormatBinary 记录以二进制头部加原始value保存
+    FormatBinary = 2
+    // CurrentFormat 新写入的数据使用的格式
+    CurrentFormat = FormatBinary
+)
+
+// 二进制编码的第一个字节，JSON编码总是以'{'开头，两种格式可以共存
+const (
+    binaryPairTag   = 0x01
+    binaryHintTag   = 0x02
+    binaryTxnTag    = 0x03
+    binaryChangeTag = 0x04
+)
+
+const pairDeletedFlag = 1
+
+var errCorruptRecord = errors.New("corrupt record")
+
+// isJSON 判断数据是否是旧的JSON格式
+func isJSON(data []byte) bool {
+    return len(data) > 0 && data[0] == '{'
+}
+
+// encodePair 记录的二进制格式为：标签、标志、key的长度和内容、版本号、
+// 写入时间和过期时间（纳秒，0表示零值），最后是原始的value
+func encodePair(pair *KVPair) []byte {
+    buf := make([]byte, 0, 2+binary.MaxVarintLen64*4+len(pair.Key)+len(pair.Value))
+    return appendPair(buf, pair)
+}
+
+func appendPair(buf []byte, pair *KVPair) []byte {
+    var flags byte
+    if pair.Deleted {
+        flags |= pairDeletedFlag
+    }
+    buf = append(buf, binaryPairTag, flags)
+    buf = binary.AppendUvarint(buf, uint64(len(pair.Key)))
+    buf = append(buf, pair.Key...)
+    buf = binary.AppendVarint(buf, pair.Version)
+    buf = binary.AppendVarint(buf, unixNano(pair.Timestamp))
+    buf = binary.AppendVarint(buf, unixNano(pair.ExpiresAt))
+    return append(buf, pair.Value...)
+}
+
+// decodePair 解码二进制或JSON格式的记录，返回的记录不引用data
+func decodePair(data []byte) (*KVPair, error) {
+    if isJSON(data) {
+        var pair KVPair
+        if err := json.Unmarshal(data, &pair); err != nil {
+            return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+        }
+        return &pair, nil
+    }
+    if len(data) < 2 || data[0] != binaryPairTag {
+        return nil, fmt.Errorf("failed to decode data: %v", errCorruptRecord)
+    }
+    
+    pair := &KVPair{Deleted: data[1]&pairDeletedFlag != 0}
+    r := &recordReader{buf: data[2:]}
+    pair.Key = string(r.bytes())
+    pair.Version = r.varint()
+    pair.Timestamp = fromUnixNano(r.varint())
+    pair.ExpiresAt = fromUnixNano(r.varint())
+    if r.err != nil {
+        return nil, fmt.Errorf("failed to decode data: %v", r.err)
+    }
+    if len(r.buf) > 0 {
+        pair.Value = append([]byte(nil), r.buf...)
+    }
+    return pair, nil
+}
+
+// encodeHint 提示的二进制格式为：标签、保存时间，然后是记录
+func encodeHint(hint *Hint) []byte {
+    buf := []byte{binaryHintTag}
+    buf = binary.AppendVarint(buf, unixNano(hint.CreatedAt))
+    return appendPair(buf, hint.Pair)
+}
+
+// decodeHint 解码二进制或JSON格式的提示，ID和NodeID由调用者设置
+func decodeHint(data []byte) (*Hint, error) {
+    if isJSON(data) {
+        var hint Hint
+        if err := json.Unmarshal(data, &hint); err != nil {
+            return nil, fmt.Errorf("failed to unmarshal hint: %v", err)
+        }
+        return &hint, nil
+    }
+    if len(data) < 1 || data[0] != binaryHintTag {
+        return nil, fmt.Errorf("failed to decode hint: %v", errCorruptRecord)
+    }
+    
+    r := &recordReader{buf: data[1:]}
+    createdAt := fromUnixNano(r.varint())
+    if r.err != nil {
+        return nil, fmt.Errorf("failed to decode hint: %v", r.err)
+    }
+    pair, err := decodePair(r.buf)
+    if err != nil {
+        return nil, err
+    }
+    return &Hint{Pair: pair, CreatedAt: createdAt}, nil
+}
+
+// encodeTxn 事务的二进制格式为：标签、ID、创建时间、记录数，然后是依次以长度开头的记录
+func encodeTxn(record *TxnRecord) []byte {
+    buf := []byte{binaryTxnTag}
+    buf = binary.AppendUvarint(buf, uint64(len(record.ID)))
+    buf = append(buf, record.ID...)
+    buf = binary.AppendVarint(buf, unixNano(record.CreatedAt))
+    buf = binary.AppendUvarint(buf, uint64(len(record.Pairs)))
+    for _, pair := range record.Pairs {
+        data := encodePair(pair)
+        buf = binary.AppendUvarint(buf, uint64(len(data)))
+        buf = append(buf, data...)
+    }
+    return buf
+}
+
+func decodeTxn(data []byte) (*TxnRecord, error) {
+    if isJSON(data) {
+        var record TxnRecord
+        if err := json.Unmarshal(data, &record); err != nil {
+            return nil, fmt.Errorf("failed to unmarshal txn: %v", err)
+        }
+        return &record, nil
+    }
+    if len(data) < 1 || data[0] != binaryTxnTag {
+        return nil, fmt.Errorf("failed to decode txn: %v", errCorruptRecord)
+    }
+    
+    r := &recordReader{buf: data[1:]}
+    record := &TxnRecord{ID: string(r.bytes())}
+    record.CreatedAt = fromUnixNano(r.varint())
+    count := r.uvarint()
+    for i := uint64(0); i < count && r.err == nil; i++ {
+        pair, err := decodePair(r.bytes())
+        if err != nil {
+            return nil, err
+        }
+        record.Pairs = append(record.Pairs, pair)
+    }
+    if r.err != nil {
+        return nil, fmt.Errorf("failed to decode txn: %v", r.err)
+    }
+    return record, nil
+}
+
+// encodeChange 变更的二进制格式为：标签、变更类型、发生时间，然后是记录。序号保存在key中
+func encodeChange(change *Change) []byte {
+    buf := []byte{binaryChangeTag, byte(change.Type)}
+    buf = binary.AppendVarint(buf, unixNano(change.Time))
+    return appendPair(buf, change.Pair)
+}
+
+// decodeChange 解码变更，Seq由调用者设置
+func decodeChange(data []byte) (*Change, error) {
+    if len(data) < 2 || data[0] != binaryChangeTag {
+        return nil, fmt.Errorf("failed to decode change: %v", errCorruptRecord)
+    }
+    
+    r := &recordReader{buf: data[2:]}
+    changeTime := fromUnixNano(r.varint())
+    if r.err != nil {
+        return nil, fmt.Errorf("failed to decode change: %v", r.err)
+    }
+    pair, err := decodePair(r.buf)
+    if err != nil {
+        return nil, err
+    }
+    return &Change{Type: ChangeType(data[1]), Pair: pair, Time: changeTime}, nil
+}
+
+// recordReader 依次读取二进制编码的字段，出错后的读取都返回零值
+type recordReader struct {
+    buf []byte
+    err error
+}
+
+func (r *recordReader) uvarint() uint64 {
+    if r.err != nil {
+        return 0
+    }
+    v, n := binary.Uvarint(r.buf)
+    if n <= 0 {
+        r.err = errCorruptRecord
+        return 0
+    }
+    r.buf = r.buf[n:]
+    return v
+}
+
+func (r *recordReader) varint() int64 {
+    if r.err != nil {
+        return 0
+    }
+    v, n := binary.Varint(r.buf)
+    if n <= 0 {
+        r.err = errCorruptRecord
+        return 0
+    }
+    r.buf = r.buf[n:]
+    return v
+}
+
+// bytes 读取以长度开头的字节串，返回的切片引用原数据
+func (r *recordReader) bytes() []byte {
+    n := r.uvarint()
+    if r.err != nil {
+        return nil
+    }
+    if uint64(len(r.buf)) < n {
+        r.err = errCorruptRecord
+        return nil
+    }
+    b := r.buf[:n]
+    r.buf = r.buf[n:]
+    return b
+}
+
+// unixNano 零值时间编码为0
+func unixNano(t time.Time) int64 {
+    if t.IsZero() {
+        return 0
+    }
+    return t.UnixNano()
+}
+
+func fromUnixNano(ns int64) time.Time {
+    if ns == 0 {
+        return time.Time{}
+    }
+    return time.Unix(0, ns)
+}
\ No newline at end of file
@@ -0,0 +1,111 @@
+package storage
+
+import (
+    "encoding/json"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func testPair(key string) *KVPair {
+    now := time.Unix(0, time.Now().UnixNano())
+    return &KVPair{
+        Key:       key,
+        Value:     []byte("value of " + key),
+        Version:   42,
+        Timestamp: now,
+        ExpiresAt: now.Add(time.Hour),
+    }
+}
+
+func TestPairRoundTrip(t *testing.T) {
+    tombstone := testPair("deleted")
+    tombstone.Value = nil
+    tombstone.Deleted = true
+    tombstone.ExpiresAt = time.Time{}
+    
+    for _, pair := range []*KVPair{testPair("a"), tombstone, {Key: "", Version: -1}} {
+        data := encodePair(pair)
+        if data[0] != binaryPairTag {
+            t.Errorf("%q encoded with tag %#x, want %#x", pair.Key, data[0], binaryPairTag)
+        }
+        got, err := decodePair(data)
+        if err != nil {
+            t.Fatalf("decodePair(%q): %v", pair.Key, err)
+        }
+        if !reflect.DeepEqual(got, pair) {
+            t.Errorf("decodePair = %+v, want %+v", got, pair)
+        }
+    }
+}
+
+func TestHintTxnChangeRoundTrip(t *testing.T) {
+    now := time.Unix(0, time.Now().UnixNano())
+    
+    hint := &Hint{Pair: testPair("h"), CreatedAt: now}
+    data := encodeHint(hint)
+    if data[0] != binaryHintTag {
+        t.Errorf("hint encoded with tag %#x, want %#x", data[0], binaryHintTag)
+    }
+    if got, err := decodeHint(data); err != nil || !reflect.DeepEqual(got, hint) {
+        t.Errorf("decodeHint = %+v, %v, want %+v", got, err, hint)
+    }
+    
+    record := &TxnRecord{ID: "node1-1", Pairs: []*KVPair{testPair("x"), testPair("y")}, CreatedAt: now}
+    data = encodeTxn(record)
+    if data[0] != binaryTxnTag {
+        t.Errorf("txn encoded with tag %#x, want %#x", data[0], binaryTxnTag)
+    }
+    if got, err := decodeTxn(data); err != nil || !reflect.DeepEqual(got, record) {
+        t.Errorf("decodeTxn = %+v, %v, want %+v", got, err, record)
+    }
+    
+    change := &Change{Type: ChangeExpire, Pair: testPair("c"), Time: now}
+    data = encodeChange(change)
+    if data[0] != binaryChangeTag {
+        t.Errorf("change encoded with tag %#x, want %#x", data[0], binaryChangeTag)
+    }
+    if got, err := decodeChange(data); err != nil || !reflect.DeepEqual(got, change) {
+        t.Errorf("decodeChange = %+v, %v, want %+v", got, err, change)
+    }
+}
+
+func TestDecodeLegacyJSON(t *testing.T) {
+    pair := testPair("legacy")
+    data, _ := json.Marshal(pair)
+    got, err := decodePair(data)
+    if err != nil || !got.Timestamp.Equal(pair.Timestamp) || string(got.Value) != string(pair.Value) || got.Version != pair.Version {
+        t.Errorf("decodePair(JSON) = %+v, %v, want %+v", got, err, pair)
+    }
+    
+    data, _ = json.Marshal(&Hint{Pair: pair, CreatedAt: pair.Timestamp})
+    if hint, err := decodeHint(data); err != nil || hint.Pair.Key != pair.Key {
+        t.Errorf("decodeHint(JSON) = %+v, %v", hint, err)
+    }
+    
+    data, _ = json.Marshal(&TxnRecord{ID: "t", Pairs: []*KVPair{pair}, CreatedAt: pair.Timestamp})
+    if record, err := decodeTxn(data); err != nil || record.ID != "t" || len(record.Pairs) != 1 {
+        t.Errorf("decodeTxn(JSON) = %+v, %v", record, err)
+    }
+}
+
+func TestDecodeRejectsCorruptRecords(t *testing.T) {
+    valid := encodePair(testPair("a"))
+    for name, data := range map[string][]byte{
+        "empty":     nil,
+        "wrong tag": append([]byte{binaryHintTag}, valid[1:]...),
+        "truncated": valid[:4],
+    } {
+        if _, err := decodePair(data); err == nil {
+            t.Errorf("decodePair(%s) succeeded", name)
+        }
+    }
+    
+    txn := encodeTxn(&TxnRecord{ID: "t", Pairs: []*KVPair{testPair("a")}})
+    if _, err := decodeTxn(txn[:len(txn)-10]); err == nil {
+        t.Error("decodeTxn(truncated) succeeded")
+    }
+    if _, err := decodeChange([]byte{binaryPairTag, 0}); err == nil {
+        t.Error("decodeChange(wrong tag) succeeded")
+    }
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "time"
+    
+    "github.com/boltdb/bolt"
+)
+
+// 复制时每个写事务最多写入的key数
+const compactTxSize = 10000
+
+// Compact 把数据复制到新的数据库文件并替换原文件，回收删除数据后留下的空闲页。
+// 复制期间可以继续读取，写入会等待复制完成；返回压缩前后的文件大小
+func (se *BoltEngine) Compact() (int64, int64, error) {
+    se.compactMutex.Lock()
+    defer se.compactMutex.Unlock()
+    
+    dbPath := filepath.Join(se.dataPath, "rushkv.db")
+    tmpPath := dbPath + ".compact"
+    before, err := fileSize(dbPath)
+    if err != nil {
+        return 0, 0, err
+    }
+    
+    se.mutex.RLock()
+    txid, err := se.copyTo(tmpPath)
+    se.mutex.RUnlock()
+    if err != nil {
+        os.Remove(tmpPath)
+        return 0, 0, err
+    }
+    
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    // 释放读锁和获取写锁之间有写入时重新复制
+    if current, err := se.txid(); err != nil || current != txid {
+        if _, err := se.copyTo(tmpPath); err != nil {
+            os.Remove(tmpPath)
+            return 0, 0, err
+        }
+    }
+    
+    if err := se.db.Close(); err != nil {
+        return 0, 0, fmt.Errorf("failed to close database: %v", err)
+    }
+    if err := os.Rename(tmpPath, dbPath); err != nil {
+        os.Remove(tmpPath)
+        err = fmt.Errorf("failed to replace database: %v", err)
+        if reopenErr := se.open(); reopenErr != nil {
+            return 0, 0, fmt.Errorf("%v, and failed to reopen it: %v", err, reopenErr)
+        }
+        return 0, 0, err
+    }
+    if err := se.open(); err != nil {
+        return 0, 0, err
+    }
+    
+    after, err := fileSize(dbPath)
+    return before, after, err
+}
+
+// open 打开数据目录下的数据库文件
+func (se *BoltEngine) open() error {
+    db, err := bolt.Open(filepath.Join(se.dataPath, "rushkv.db"), 0600, &bolt.Options{
+        Timeout: 1 * time.Second,
+    })
+    if err != nil {
+        return fmt.Errorf("failed to open database: %v", err)
+    }
+    se.db = db
+    return nil
+}
+
+// txid 返回最近一次提交的事务编号
+func (se *BoltEngine) txid() (int, error) {
+    var id int
+    err := se.db.View(func(tx *bolt.Tx) error {
+        id = tx.ID()
+        return nil
+    })
+    return id, err
+}
+
+// copyTo 把所有bucket复制到path处的新文件，返回复制时的事务编号
+func (se *BoltEngine) copyTo(path string) (int, error) {
+    os.Remove(path)
+    dst, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+    if err != nil {
+        return 0, fmt.Errorf("failed to create compacted database: %v", err)
+    }
+    defer dst.Close()
+    
+    var txid int
+    err = se.db.View(func(src *bolt.Tx) error {
+        txid = src.ID()
+        w := &compactWriter{db: dst}
+        err := src.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+            return w.copyBucket([][]byte{name}, bucket)
+        })
+        if err != nil {
+            return err
+        }
+        return w.commit()
+    })
+    if err != nil {
+        return 0, fmt.Errorf("failed to copy database: %v", err)
+    }
+    return txid, dst.Sync()
+}
+
+// compactWriter 分批写入新文件，避免单个事务过大
+type compactWriter struct {
+    db    *bolt.DB
+    tx    *bolt.Tx
+    count int
+}
+
+func (w *compactWriter) copyBucket(path [][]byte, src *bolt.Bucket) error {
+    dst, err := w.bucket(path)
+    if err != nil {
+        return err
+    }
+    if err := dst.SetSequence(src.Sequence()); err != nil {
+        return err
+    }
+    
+    return src.ForEach(func(k, v []byte) error {
+        if v == nil {
+            return w.copyBucket(append(append([][]byte(nil), path...), k), src.Bucket(k))
+        }
+        
+        dst, err := w.bucket(path)
+        if err != nil {
+            return err
+        }
+        // 按key顺序写入，页可以填满
+        dst.FillPercent = 1.0
+        if err := dst.Put(k, v); err != nil {
+            return err
+        }
+        
+        w.count++
+        if w.count >= compactTxSize {
+            return w.commit()
+        }
+        return nil
+    })
+}
+
+// bucket 返回当前写事务中path对应的bucket，不存在时创建
+func (w *compactWriter) bucket(path [][]byte) (*bolt.Bucket, error) {
+    if w.tx == nil {
+        tx, err := w.db.Begin(true)
+        if err != nil {
+            return nil, err
+        }
+        w.tx = tx
+        w.count = 0
+    }
+    
+    bucket, err := w.tx.CreateBucketIfNotExists(path[0])
+    if err != nil {
+        return nil, err
+    }
+    for _, name := range path[1:] {
+        if bucket, err = bucket.CreateBucketIfNotExists(name); err != nil {
+            return nil, err
+        }
+    }
+    return bucket, nil
+}
+
+func (w *compactWriter) commit() error {
+    if w.tx == nil {
+        return nil
+    }
+    tx := w.tx
+    w.tx = nil
+    return tx.Commit()
+}
+
+func fileSize(path string) (int64, error) {
+    info, err := os.Stat(path)
+    if err != nil {
+        return 0, err
+    }
+    return info.Size(), nil
+}
\ No newline at end of file
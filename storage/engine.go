@@ -1,137 +1,215 @@
 package storage
 
 import (
+    "bufio"
     "encoding/json"
     "fmt"
-    "os"
-    "path/filepath"
-    "sync"
+    "io"
     "time"
-    
-    "github.com/boltdb/bolt"
 )
 
-type StorageEngine struct {
-    db       *bolt.DB
-    dataPath string
-    mutex    sync.RWMutex
-}
+const (
+    // EngineBolt 基于BoltDB的持久化引擎
+    EngineBolt = "bolt"
+    // EngineLSM 日志结构合并树引擎，适合写入密集的负载
+    EngineLSM = "lsm"
+    // EngineMemory 基于B树的内存引擎，进程退出后数据丢失，用于测试和缓存
+    EngineMemory = "memory"
+)
 
-func NewStorageEngine(dataPath string) (*StorageEngine, error) {
-    if err := os.MkdirAll(dataPath, 0755); err != nil {
-        return nil, fmt.Errorf("failed to create data directory: %v", err)
-    }
+// Engine 存储引擎，保存key的当前记录、历史版本，以及提示和跨节点事务的提交决定。
+// 所有方法都可以并发调用，返回的记录归调用者所有
+type Engine interface {
+    // Put 以version写入key的新值，无条件覆盖本地记录。引擎不生成版本，
+    // 版本由调用者的混合逻辑时钟给出，复制的写入应通过Apply写入
+    Put(key string, value []byte, version int64) error
+    // Get 返回key的值，不存在、已删除或已过期时返回错误
+    Get(key string) ([]byte, error)
+    // Delete 以version把key标记为已删除，key不存在时返回错误
+    Delete(key string, version int64) error
+    // GetPair 返回key对应的完整记录（包括墓碑），不存在时返回nil
+    GetPair(key string) (*KVPair, error)
+    // Apply 写入一条已带版本的记录，只有版本不低于本地记录时才会覆盖，返回记录是否被写入
+    Apply(pair *KVPair) (bool, error)
+    // ApplyPairs 原子地按Apply的规则写入一批记录，返回每条记录是否被写入
+    ApplyPairs(pairs []*KVPair) ([]bool, error)
+    // ListPairs 按key顺序返回至多limit条key大于after的记录（包括墓碑）
+    ListPairs(after string, limit int) ([]*KVPair, error)
+    // ScanPairs 返回key在[start, end)内的至多limit条记录（包括墓碑），end为空表示不设上界，
+    // reverse为true时按key逆序返回
+    ScanPairs(start, end string, reverse bool, limit int) ([]*KVPair, error)
+    // Purge 物理删除key的记录及其历史版本，仅当记录版本仍为version时生效
+    Purge(key string, version int64) error
+    // PurgePairs 物理删除一批记录及其历史版本，版本已变化的记录不会被删除，返回删除的数量
+    PurgePairs(pairs []*KVPair) (int, error)
+    // Count 返回记录总数（包括墓碑）
+    Count() (int, error)
     
-    dbPath := filepath.Join(dataPath, "rushkv.db")
-    db, err := bolt.Open(dbPath, 0600, &bolt.Options{
-        Timeout: 1 * time.Second,
-    })
-    if err != nil {
-        return nil, fmt.Errorf("failed to open database: %v", err)
-    }
+    // SetHistoryVersions 设置每个key最多保留的版本数（包括当前版本）
+    SetHistoryVersions(n int)
+    // GetPairAt 返回版本不超过version的最新记录，不存在时返回nil
+    GetPairAt(key string, version int64) (*KVPair, error)
+    // GetPairAtTime 返回写入时间不晚于t的最新记录，不存在时返回nil
+    GetPairAtTime(key string, t time.Time) (*KVPair, error)
+    // History 按版本从新到旧返回key的至多limit个版本，limit为0表示不限制
+    History(key string, limit int) ([]*KVPair, error)
     
-    // 创建默认bucket
-    err = db.Update(func(tx *bolt.Tx) error {
-        _, err := tx.CreateBucketIfNotExists([]byte("kv"))
-        return err
-    })
-    if err != nil {
-        return nil, fmt.Errorf("failed to create bucket: %v", err)
-    }
+    // AddHint 为nodeID保存一条提示，积压数量达到limit时返回ErrHintsFull
+    AddHint(nodeID string, pair *KVPair, limit int) error
+    // Hints 按保存顺序返回nodeID最早的至多limit条提示
+    Hints(nodeID string, limit int) ([]*Hint, error)
+    // DeleteHints 删除已重放的提示
+    DeleteHints(hints []*Hint) error
+    // DropHints 删除nodeID的全部提示
+    DropHints(nodeID string) error
+    // ExpireHints 删除保存时间早于before的提示，返回删除的数量
+    ExpireHints(before time.Time) (int, error)
+    // HintBacklogs 按节点ID顺序返回每个目标节点积压的提示
+    HintBacklogs() ([]HintBacklog, error)
     
-    return &StorageEngine{
-        db:       db,
-        dataPath: dataPath,
-    }, nil
-}
-
-func (se *StorageEngine) Put(key string, value []byte) error {
-    se.mutex.Lock()
-    defer se.mutex.Unlock()
+    // SaveTxn 保存事务的提交决定
+    SaveTxn(record *TxnRecord) error
+    // DeleteTxn 删除已完成提交的事务
+    DeleteTxn(id string) error
+    // PendingTxns 返回已决定提交但尚未完成的事务
+    PendingTxns() ([]*TxnRecord, error)
     
-    kvPair := &KVPair{
-        Key:       key,
-        Value:     value,
-        Version:   time.Now().UnixNano(),
-        Timestamp: time.Now(),
-        Deleted:   false,
-    }
+    // 被写入的记录（包括墓碑）和PurgePairs清理的过期记录按顺序追加到变更日志
+    // Changes 按序号返回至多limit条序号不小于from的变更
+    Changes(from uint64, limit int) ([]*Change, error)
+    // ChangeBounds 返回保留的最早和最新变更的序号，没有保留的变更时first为last+1
+    ChangeBounds() (first, last uint64, err error)
+    // TrimChanges 删除发生时间早于before的变更，并只保留最新的maxEntries条，返回删除的数量。
+    // before为零值或maxEntries为0时不按该条件删除
+    TrimChanges(before time.Time, maxEntries int) (int, error)
     
-    data, err := json.Marshal(kvPair)
-    if err != nil {
-        return fmt.Errorf("failed to marshal data: %v", err)
+    // Snapshot 把某一时刻的全部记录和历史版本写入w
+    Snapshot(w io.Writer) error
+    // Restore 按Apply的规则写入Snapshot生成的数据
+    Restore(r io.Reader) error
+    // Compact 回收存储空间，返回压缩前后占用的字节数
+    Compact() (int64, int64, error)
+    Close() error
+}
+
+// Open 在dataPath下打开指定类型的存储引擎
+func Open(engine, dataPath string) (Engine, error) {
+    switch engine {
+    case EngineBolt:
+        return NewBoltEngine(dataPath)
+    case EngineLSM:
+        return NewLSMEngine(dataPath)
+    case EngineMemory:
+        return NewMemoryEngine(), nil
+    default:
+        return nil, fmt.Errorf("unknown storage engine: %s", engine)
     }
-    
-    return se.db.Update(func(tx *bolt.Tx) error {
-        bucket := tx.Bucket([]byte("kv"))
-        return bucket.Put([]byte(key), data)
-    })
 }
 
-func (se *StorageEngine) Get(key string) ([]byte, error) {
-    se.mutex.RLock()
-    defer se.mutex.RUnlock()
-    
-    var result []byte
-    err := se.db.View(func(tx *bolt.Tx) error {
-        bucket := tx.Bucket([]byte("kv"))
-        data := bucket.Get([]byte(key))
-        if data == nil {
-            return fmt.Errorf("key not found")
-        }
-        
-        var kvPair KVPair
-        if err := json.Unmarshal(data, &kvPair); err != nil {
-            return fmt.Errorf("failed to unmarshal data: %v", err)
-        }
-        
-        if kvPair.Deleted {
-            return fmt.Errorf("key not found")
+// 恢复快照时每批写入的记录数
+const restoreBatchSize = 1000
+
+// writeSnapshot 快照每行是一条JSON编码的记录，按key排序，同一key的版本从新到旧
+func writeSnapshot(w io.Writer, walk func(fn func(*KVPair) error) error) error {
+    bw := bufio.NewWriter(w)
+    enc := json.NewEncoder(bw)
+    err := walk(func(pair *KVPair) error {
+        if err := enc.Encode(pair); err != nil {
+            return fmt.Errorf("failed to write snapshot: %v", err)
         }
-        
-        result = kvPair.Value
         return nil
     })
-    
-    return result, err
+    if err != nil {
+        return err
+    }
+    return bw.Flush()
 }
 
-func (se *StorageEngine) Delete(key string) error {
-    se.mutex.Lock()
-    defer se.mutex.Unlock()
-    
-    return se.db.Update(func(tx *bolt.Tx) error {
-        bucket := tx.Bucket([]byte("kv"))
-        data := bucket.Get([]byte(key))
-        if data == nil {
-            return fmt.Errorf("key not found")
+// restoreSnapshot 按批次把快照中的记录交给apply写入
+func restoreSnapshot(r io.Reader, apply func([]*KVPair) ([]bool, error)) error {
+    dec := json.NewDecoder(bufio.NewReader(r))
+    batch := make([]*KVPair, 0, restoreBatchSize)
+    for {
+        var pair KVPair
+        err := dec.Decode(&pair)
+        if err == io.EOF {
+            break
         }
-        
-        var kvPair KVPair
-        if err := json.Unmarshal(data, &kvPair); err != nil {
-            return fmt.Errorf("failed to unmarshal data: %v", err)
-        }
-        
-        kvPair.Deleted = true
-        kvPair.Timestamp = time.Now()
-        
-        newData, err := json.Marshal(kvPair)
         if err != nil {
-            return fmt.Errorf("failed to marshal data: %v", err)
+            return fmt.Errorf("failed to read snapshot: %v", err)
         }
         
-        return bucket.Put([]byte(key), newData)
-    })
+        batch = append(batch, &pair)
+        if len(batch) == restoreBatchSize {
+            if _, err := apply(batch); err != nil {
+                return err
+            }
+            batch = batch[:0]
+        }
+    }
+    if len(batch) > 0 {
+        if _, err := apply(batch); err != nil {
+            return err
+        }
+    }
+    return nil
 }
 
-func (se *StorageEngine) Close() error {
-    return se.db.Close()
+// PrefixEnd 返回大于所有以prefix开头的key的最小key，用作扫描的上界，
+// prefix为空或全部为0xff时返回空字符串表示不设上界
+func PrefixEnd(prefix string) string {
+    end := []byte(prefix)
+    for i := len(end) - 1; i >= 0; i-- {
+        if end[i] < 0xff {
+            end[i]++
+            return string(end[:i+1])
+        }
+    }
+    return ""
 }
 
+// KVPair 一个key的一个版本
 type KVPair struct {
     Key       string    `json:"key"`
     Value     []byte    `json:"value"`
     Version   int64     `json:"version"`
     Timestamp time.Time `json:"timestamp"`
     Deleted   bool      `json:"deleted"`
+    // 过期时间，零值表示不过期
+    ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired 判断记录在now时是否已过期
+func (p *KVPair) Expired(now time.Time) bool {
+    return !p.ExpiresAt.IsZero() && !now.Before(p.ExpiresAt)
+}
+
+// NewKVPair 创建一条版本为version的记录，Timestamp为本地物理时间
+func NewKVPair(key string, value []byte, version int64) *KVPair {
+    return &KVPair{
+        Key:       key,
+        Value:     value,
+        Version:   version,
+        Timestamp: time.Now(),
+    }
+}
+
+// NewTombstone 创建一条版本为version的删除标记
+func NewTombstone(key string, version int64) *KVPair {
+    pair := NewKVPair(key, nil, version)
+    pair.Deleted = true
+    return pair
+}
+
+func (p *KVPair) itemKey() string {
+    return p.Key
+}
+
+// clone 返回记录的副本，内存引擎用它隔离保存的记录和调用者持有的记录
+func (p *KVPair) clone() *KVPair {
+    c := *p
+    if p.Value != nil {
+        c.Value = append([]byte(nil), p.Value...)
+    }
+    return &c
 }
\ No newline at end of file
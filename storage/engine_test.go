@@ -0,0 +1,34 @@
+package storage_test
+
+import (
+    "testing"
+    
+    "rushkv/storage"
+    "rushkv/storage/enginetest"
+)
+
+func TestMemoryEngine(t *testing.T) {
+    enginetest.Run(t, func(t *testing.T) storage.Engine {
+        return storage.NewMemoryEngine()
+    })
+}
+
+func TestBoltEngine(t *testing.T) {
+    enginetest.Run(t, func(t *testing.T) storage.Engine {
+        e, err := storage.NewBoltEngine(t.TempDir())
+        if err != nil {
+            t.Fatalf("NewBoltEngine: %v", err)
+        }
+        return e
+    })
+}
+
+func TestLSMEngine(t *testing.T) {
+    enginetest.Run(t, func(t *testing.T) storage.Engine {
+        e, err := storage.NewLSMEngine(t.TempDir())
+        if err != nil {
+            t.Fatalf("NewLSMEngine: %v", err)
+        }
+        return e
+    })
+}
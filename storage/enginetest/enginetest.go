@@ -0,0 +1,691 @@
+// Package enginetest 存储引擎的一致性测试，所有storage.Engine的实现都应通过
+package enginetest
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "math/rand"
+    "sort"
+    "sync"
+    "testing"
+    "time"
+    
+    "rushkv/storage"
+)
+
+// Factory 为每个子测试创建一个空的存储引擎，测试结束后由Run关闭
+type Factory func(t *testing.T) storage.Engine
+
+// Run 对factory创建的引擎运行全部一致性测试，例如：
+//
+//	func TestMemoryEngine(t *testing.T) {
+//	    enginetest.Run(t, func(t *testing.T) storage.Engine {
+//	        return storage.NewMemoryEngine()
+//	    })
+//	}
+func Run(t *testing.T, factory Factory) {
+    tests := []struct {
+        name string
+        fn   func(t *testing.T, e storage.Engine)
+    }{
+        {"PutGetDelete", testPutGetDelete},
+        {"Apply", testApply},
+        {"ApplyPairs", testApplyPairs},
+        {"Expiry", testExpiry},
+        {"ListPairs", testListPairs},
+        {"ScanPairs", testScanPairs},
+        {"Purge", testPurge},
+        {"History", testHistory},
+        {"Hints", testHints},
+        {"Txns", testTxns},
+        {"Changes", testChanges},
+        {"Snapshot", testSnapshot},
+        {"ManyKeys", testManyKeys},
+        {"Concurrent", testConcurrent},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            e := factory(t)
+            defer func() {
+                if err := e.Close(); err != nil {
+                    t.Errorf("Close: %v", err)
+                }
+            }()
+            tt.fn(t, e)
+        })
+    }
+}
+
+func pair(key, value string, version int64) *storage.KVPair {
+    return &storage.KVPair{
+        Key:       key,
+        Value:     []byte(value),
+        Version:   version,
+        Timestamp: time.Unix(0, version),
+    }
+}
+
+func tombstone(key string, version int64) *storage.KVPair {
+    p := pair(key, "", version)
+    p.Value = nil
+    p.Deleted = true
+    return p
+}
+
+func mustApply(t *testing.T, e storage.Engine, pairs ...*storage.KVPair) {
+    t.Helper()
+    if _, err := e.ApplyPairs(pairs); err != nil {
+        t.Fatalf("ApplyPairs: %v", err)
+    }
+}
+
+func keys(pairs []*storage.KVPair) []string {
+    result := make([]string, len(pairs))
+    for i, p := range pairs {
+        result[i] = p.Key
+    }
+    return result
+}
+
+func versions(pairs []*storage.KVPair) []int64 {
+    result := make([]int64, len(pairs))
+    for i, p := range pairs {
+        result[i] = p.Version
+    }
+    return result
+}
+
+func expectKeys(t *testing.T, what string, pairs []*storage.KVPair, want ...string) {
+    t.Helper()
+    if got := keys(pairs); fmt.Sprint(got) != fmt.Sprint(want) {
+        t.Errorf("%s = %v, want %v", what, got, want)
+    }
+}
+
+func expectVersions(t *testing.T, what string, pairs []*storage.KVPair, want ...int64) {
+    t.Helper()
+    if got := versions(pairs); fmt.Sprint(got) != fmt.Sprint(want) {
+        t.Errorf("%s = %v, want %v", what, got, want)
+    }
+}
+
+func expectCount(t *testing.T, e storage.Engine, want int) {
+    t.Helper()
+    count, err := e.Count()
+    if err != nil {
+        t.Fatalf("Count: %v", err)
+    }
+    if count != want {
+        t.Errorf("Count = %d, want %d", count, want)
+    }
+}
+
+func testPutGetDelete(t *testing.T, e storage.Engine) {
+    if _, err := e.Get("a"); err == nil {
+        t.Error("Get of a missing key succeeded")
+    }
+    if err := e.Delete("a", 1); err == nil {
+        t.Error("Delete of a missing key succeeded")
+    }
+    if p, err := e.GetPair("a"); err != nil || p != nil {
+        t.Errorf("GetPair of a missing key = %v, %v", p, err)
+    }
+    
+    if err := e.Put("a", []byte("1"), 10); err != nil {
+        t.Fatalf("Put: %v", err)
+    }
+    value, err := e.Get("a")
+    if err != nil || string(value) != "1" {
+        t.Fatalf("Get = %q, %v, want 1", value, err)
+    }
+    // 版本由调用者给出
+    if p, _ := e.GetPair("a"); p == nil || p.Version != 10 {
+        t.Errorf("GetPair after Put = %+v, want version 10", p)
+    }
+    
+    // 返回的值归调用者所有
+    value[0] = 'x'
+    if value, _ := e.Get("a"); string(value) != "1" {
+        t.Errorf("Get after modifying a returned value = %q, want 1", value)
+    }
+    p, _ := e.GetPair("a")
+    p.Value = []byte("y")
+    p.Deleted = true
+    if value, _ := e.Get("a"); string(value) != "1" {
+        t.Errorf("Get after modifying a returned pair = %q, want 1", value)
+    }
+    
+    if err := e.Delete("a", 11); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+    if _, err := e.Get("a"); err == nil {
+        t.Error("Get of a deleted key succeeded")
+    }
+    p, err = e.GetPair("a")
+    if err != nil || p == nil || !p.Deleted || p.Version != 11 {
+        t.Errorf("GetPair of a deleted key = %+v, %v, want a tombstone at version 11", p, err)
+    }
+    expectCount(t, e, 1)
+}
+
+func testApply(t *testing.T, e storage.Engine) {
+    cases := []struct {
+        pair    *storage.KVPair
+        applied bool
+        current string
+    }{
+        {pair("k", "v10", 10), true, "v10"},
+        {pair("k", "v5", 5), false, "v10"},
+        {pair("k", "v20", 20), true, "v20"},
+        // 相同版本覆盖
+        {pair("k", "v20b", 20), true, "v20b"},
+        {tombstone("k", 15), false, "v20b"},
+        {tombstone("k", 30), true, ""},
+    }
+    for _, c := range cases {
+        applied, err := e.Apply(c.pair)
+        if err != nil {
+            t.Fatalf("Apply(%d): %v", c.pair.Version, err)
+        }
+        if applied != c.applied {
+            t.Errorf("Apply(%d) = %v, want %v", c.pair.Version, applied, c.applied)
+        }
+        current, _ := e.GetPair("k")
+        if c.current == "" {
+            if !current.Deleted {
+                t.Errorf("after Apply(%d) current = %+v, want a tombstone", c.pair.Version, current)
+            }
+        } else if string(current.Value) != c.current {
+            t.Errorf("after Apply(%d) current = %q, want %q", c.pair.Version, current.Value, c.current)
+        }
+    }
+    
+    // 写入的记录不应受调用者之后修改的影响
+    p := pair("m", "orig", 1)
+    e.Apply(p)
+    p.Value[0] = 'X'
+    if value, _ := e.Get("m"); string(value) != "orig" {
+        t.Errorf("Get after modifying an applied pair = %q, want orig", value)
+    }
+}
+
+func testApplyPairs(t *testing.T, e storage.Engine) {
+    mustApply(t, e, pair("b", "2", 10))
+    applied, err := e.ApplyPairs([]*storage.KVPair{
+        pair("a", "1", 5),
+        pair("b", "old", 5),
+        pair("c", "3", 5),
+    })
+    if err != nil {
+        t.Fatalf("ApplyPairs: %v", err)
+    }
+    if fmt.Sprint(applied) != "[true false true]" {
+        t.Errorf("ApplyPairs = %v, want [true false true]", applied)
+    }
+    expectCount(t, e, 3)
+    
+    applied, err = e.ApplyPairs(nil)
+    if err != nil || len(applied) != 0 {
+        t.Errorf("ApplyPairs(nil) = %v, %v", applied, err)
+    }
+}
+
+func testExpiry(t *testing.T, e storage.Engine) {
+    expired := pair("e", "gone", 1)
+    expired.ExpiresAt = time.Now().Add(-time.Second)
+    live := pair("l", "here", 1)
+    live.ExpiresAt = time.Now().Add(time.Hour)
+    mustApply(t, e, expired, live)
+    
+    if _, err := e.Get("e"); err == nil {
+        t.Error("Get of an expired key succeeded")
+    }
+    if value, err := e.Get("l"); err != nil || string(value) != "here" {
+        t.Errorf("Get of an unexpired key = %q, %v", value, err)
+    }
+    
+    // 过期的记录仍可以通过GetPair读到，由调用者决定如何处理
+    p, err := e.GetPair("e")
+    if err != nil || p == nil || !p.Expired(time.Now()) {
+        t.Errorf("GetPair of an expired key = %+v, %v", p, err)
+    }
+    if p, _ := e.GetPair("l"); !p.ExpiresAt.Equal(live.ExpiresAt) {
+        t.Errorf("ExpiresAt = %v, want %v", p.ExpiresAt, live.ExpiresAt)
+    }
+}
+
+func testListPairs(t *testing.T, e storage.Engine) {
+    mustApply(t, e, pair("a", "", 1), pair("b", "", 1), tombstone("c", 1), pair("d", "", 1))
+    
+    all, err := e.ListPairs("", 10)
+    if err != nil {
+        t.Fatalf("ListPairs: %v", err)
+    }
+    expectKeys(t, "ListPairs(\"\", 10)", all, "a", "b", "c", "d")
+    
+    page, _ := e.ListPairs("", 2)
+    expectKeys(t, "ListPairs(\"\", 2)", page, "a", "b")
+    page, _ = e.ListPairs("b", 2)
+    expectKeys(t, "ListPairs(b, 2)", page, "c", "d")
+    page, _ = e.ListPairs("bb", 10)
+    expectKeys(t, "ListPairs(bb, 10)", page, "c", "d")
+    page, _ = e.ListPairs("d", 10)
+    expectKeys(t, "ListPairs(d, 10)", page)
+}
+
+func testScanPairs(t *testing.T, e storage.Engine) {
+    mustApply(t, e, pair("a", "", 1), pair("b1", "", 1), pair("b2", "", 1), tombstone("b3", 1), pair("c", "", 1))
+    
+    cases := []struct {
+        start, end string
+        reverse    bool
+        limit      int
+        want       []string
+    }{
+        {"", "", false, 10, []string{"a", "b1", "b2", "b3", "c"}},
+        {"", "", true, 10, []string{"c", "b3", "b2", "b1", "a"}},
+        {"b", "c", false, 10, []string{"b1", "b2", "b3"}},
+        {"b", "c", true, 10, []string{"b3", "b2", "b1"}},
+        {"b", storage.PrefixEnd("b"), false, 2, []string{"b1", "b2"}},
+        {"b", storage.PrefixEnd("b"), true, 2, []string{"b3", "b2"}},
+        {"b2", "", false, 10, []string{"b2", "b3", "c"}},
+        {"b2", "", true, 10, []string{"c", "b3", "b2"}},
+        {"", "b2", true, 10, []string{"b1", "a"}},
+        {"d", "", false, 10, nil},
+        {"d", "", true, 10, nil},
+        {"", "a", true, 10, nil},
+        {"", "zz", true, 1, []string{"c"}},
+    }
+    for _, c := range cases {
+        pairs, err := e.ScanPairs(c.start, c.end, c.reverse, c.limit)
+        if err != nil {
+            t.Fatalf("ScanPairs: %v", err)
+        }
+        expectKeys(t, fmt.Sprintf("ScanPairs(%q, %q, %v, %d)", c.start, c.end, c.reverse, c.limit), pairs, c.want...)
+    }
+}
+
+func testPurge(t *testing.T, e storage.Engine) {
+    e.SetHistoryVersions(5)
+    mustApply(t, e, pair("a", "1", 1), pair("a", "2", 2), tombstone("b", 1), pair("c", "", 1), pair("d", "", 1))
+    
+    // 版本已变化时不删除
+    if err := e.Purge("a", 1); err != nil {
+        t.Fatalf("Purge: %v", err)
+    }
+    if p, _ := e.GetPair("a"); p == nil {
+        t.Error("Purge with a stale version removed the key")
+    }
+    if err := e.Purge("a", 2); err != nil {
+        t.Fatalf("Purge: %v", err)
+    }
+    if p, _ := e.GetPair("a"); p != nil {
+        t.Errorf("GetPair after Purge = %+v, want nil", p)
+    }
+    if history, _ := e.History("a", 0); len(history) != 0 {
+        t.Errorf("History after Purge = %v, want none", versions(history))
+    }
+    if err := e.Purge("missing", 1); err != nil {
+        t.Errorf("Purge of a missing key: %v", err)
+    }
+    
+    purged, err := e.PurgePairs([]*storage.KVPair{tombstone("b", 1), pair("c", "", 9), pair("d", "", 1), pair("missing", "", 1)})
+    if err != nil {
+        t.Fatalf("PurgePairs: %v", err)
+    }
+    if purged != 2 {
+        t.Errorf("PurgePairs = %d, want 2", purged)
+    }
+    all, _ := e.ListPairs("", 10)
+    expectKeys(t, "ListPairs after PurgePairs", all, "c")
+    expectCount(t, e, 1)
+}
+
+func testHistory(t *testing.T, e storage.Engine) {
+    e.SetHistoryVersions(3)
+    for v := int64(1); v <= 5; v++ {
+        mustApply(t, e, pair("k", fmt.Sprint("v", v), v*10))
+    }
+    
+    // 当前版本加两个历史版本
+    history, err := e.History("k", 0)
+    if err != nil {
+        t.Fatalf("History: %v", err)
+    }
+    expectVersions(t, "History(k, 0)", history, 50, 40, 30)
+    history, _ = e.History("k", 2)
+    expectVersions(t, "History(k, 2)", history, 50, 40)
+    history, _ = e.History("missing", 0)
+    expectVersions(t, "History(missing, 0)", history)
+    
+    // 比当前版本旧的记录存入历史版本
+    mustApply(t, e, pair("k", "v45", 45))
+    history, _ = e.History("k", 0)
+    expectVersions(t, "History after an old write", history, 50, 45, 40)
+    
+    mustApply(t, e, tombstone("k", 60))
+    history, _ = e.History("k", 0)
+    expectVersions(t, "History after a delete", history, 60, 50, 45)
+    if !history[0].Deleted {
+        t.Error("newest version is not a tombstone")
+    }
+    
+    cases := []struct {
+        version int64
+        want    int64
+    }{{100, 60}, {60, 60}, {59, 50}, {47, 45}, {45, 45}, {44, 0}}
+    for _, c := range cases {
+        p, err := e.GetPairAt("k", c.version)
+        if err != nil {
+            t.Fatalf("GetPairAt: %v", err)
+        }
+        if (p == nil && c.want != 0) || (p != nil && p.Version != c.want) {
+            t.Errorf("GetPairAt(%d) = %+v, want version %d", c.version, p, c.want)
+        }
+    }
+    
+    p, _ := e.GetPairAtTime("k", time.Unix(0, 55))
+    if p == nil || p.Version != 50 || string(p.Value) != "v5" {
+        t.Errorf("GetPairAtTime(55) = %+v, want version 50", p)
+    }
+    if p, _ := e.GetPairAtTime("k", time.Unix(0, 1)); p != nil {
+        t.Errorf("GetPairAtTime(1) = %+v, want nil", p)
+    }
+    
+    // 只保留当前版本
+    e.SetHistoryVersions(1)
+    mustApply(t, e, pair("single", "1", 1), pair("single", "2", 2))
+    history, _ = e.History("single", 0)
+    expectVersions(t, "History with history disabled", history, 2)
+}
+
+func testHints(t *testing.T, e storage.Engine) {
+    for i := 1; i <= 3; i++ {
+        if err := e.AddHint("n1", pair(fmt.Sprint("k", i), "", int64(i)), 3); err != nil {
+            t.Fatalf("AddHint: %v", err)
+        }
+    }
+    if err := e.AddHint("n1", pair("k4", "", 4), 3); !errors.Is(err, storage.ErrHintsFull) {
+        t.Errorf("AddHint over the limit = %v, want ErrHintsFull", err)
+    }
+    if err := e.AddHint("n2", pair("x", "", 1), 3); err != nil {
+        t.Fatalf("AddHint: %v", err)
+    }
+    
+    hints, err := e.Hints("n1", 2)
+    if err != nil {
+        t.Fatalf("Hints: %v", err)
+    }
+    if len(hints) != 2 || hints[0].Pair.Key != "k1" || hints[1].Pair.Key != "k2" || hints[0].NodeID != "n1" {
+        t.Fatalf("Hints(n1, 2) = %+v, want k1 and k2", hints)
+    }
+    if hints, _ := e.Hints("missing", 10); len(hints) != 0 {
+        t.Errorf("Hints(missing) = %+v, want none", hints)
+    }
+    
+    backlogs, err := e.HintBacklogs()
+    if err != nil {
+        t.Fatalf("HintBacklogs: %v", err)
+    }
+    if len(backlogs) != 2 || backlogs[0].NodeID != "n1" || backlogs[0].Count != 3 || backlogs[1].Count != 1 || backlogs[0].Oldest.IsZero() {
+        t.Errorf("HintBacklogs = %+v", backlogs)
+    }
+    
+    if err := e.DeleteHints(hints); err != nil {
+        t.Fatalf("DeleteHints: %v", err)
+    }
+    hints, _ = e.Hints("n1", 10)
+    if len(hints) != 1 || hints[0].Pair.Key != "k3" {
+        t.Errorf("Hints after DeleteHints = %+v, want k3", hints)
+    }
+    
+    // 已保存的提示都不早于现在
+    expired, err := e.ExpireHints(time.Now().Add(-time.Hour))
+    if err != nil || expired != 0 {
+        t.Errorf("ExpireHints(an hour ago) = %d, %v, want 0", expired, err)
+    }
+    expired, err = e.ExpireHints(time.Now().Add(time.Second))
+    if err != nil || expired != 2 {
+        t.Errorf("ExpireHints(now) = %d, %v, want 2", expired, err)
+    }
+    
+    e.AddHint("n3", pair("y", "", 1), 3)
+    if err := e.DropHints("n3"); err != nil {
+        t.Fatalf("DropHints: %v", err)
+    }
+    if err := e.DropHints("missing"); err != nil {
+        t.Errorf("DropHints(missing): %v", err)
+    }
+    if backlogs, _ := e.HintBacklogs(); len(backlogs) != 0 {
+        t.Errorf("HintBacklogs after cleanup = %+v, want none", backlogs)
+    }
+}
+
+func testTxns(t *testing.T, e storage.Engine) {
+    records := []*storage.TxnRecord{
+        {ID: "t1", Pairs: []*storage.KVPair{pair("a", "1", 1)}, CreatedAt: time.Now()},
+        {ID: "t2", Pairs: []*storage.KVPair{pair("b", "2", 2), tombstone("c", 2)}, CreatedAt: time.Now()},
+    }
+    for _, record := range records {
+        if err := e.SaveTxn(record); err != nil {
+            t.Fatalf("SaveTxn: %v", err)
+        }
+    }
+    
+    pending, err := e.PendingTxns()
+    if err != nil {
+        t.Fatalf("PendingTxns: %v", err)
+    }
+    sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+    if len(pending) != 2 || len(pending[1].Pairs) != 2 || !pending[1].Pairs[1].Deleted {
+        t.Fatalf("PendingTxns = %+v", pending)
+    }
+    
+    if err := e.DeleteTxn("t1"); err != nil {
+        t.Fatalf("DeleteTxn: %v", err)
+    }
+    if err := e.DeleteTxn("missing"); err != nil {
+        t.Errorf("DeleteTxn(missing): %v", err)
+    }
+    pending, _ = e.PendingTxns()
+    if len(pending) != 1 || pending[0].ID != "t2" {
+        t.Errorf("PendingTxns after DeleteTxn = %+v, want t2", pending)
+    }
+}
+
+func testChanges(t *testing.T, e storage.Engine) {
+    if first, last, err := e.ChangeBounds(); err != nil || first != last+1 {
+        t.Fatalf("ChangeBounds of an empty log = %d, %d, %v", first, last, err)
+    }
+    
+    expired := pair("e", "gone", 1)
+    expired.ExpiresAt = time.Now().Add(-time.Second)
+    // 较旧的版本和被拒绝的写入不产生变更
+    mustApply(t, e, pair("a", "1", 2), pair("a", "0", 1), tombstone("b", 3), expired)
+    if err := e.Put("c", []byte("3"), 4); err != nil {
+        t.Fatalf("Put: %v", err)
+    }
+    if err := e.Delete("c", 5); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+    // 清理墓碑不产生变更，清理过期记录产生过期变更
+    if _, err := e.PurgePairs([]*storage.KVPair{tombstone("b", 3), expired}); err != nil {
+        t.Fatalf("PurgePairs: %v", err)
+    }
+    
+    changes, err := e.Changes(0, 100)
+    if err != nil {
+        t.Fatalf("Changes: %v", err)
+    }
+    want := []string{"1 put a", "2 delete b", "3 put e", "4 put c", "5 delete c", "6 expire e"}
+    var got []string
+    for _, change := range changes {
+        got = append(got, fmt.Sprint(change.Seq, " ", change.Type, " ", change.Pair.Key))
+        if change.Time.IsZero() {
+            t.Errorf("change %d has no time", change.Seq)
+        }
+    }
+    if fmt.Sprint(got) != fmt.Sprint(want) {
+        t.Fatalf("Changes = %v, want %v", got, want)
+    }
+    if string(changes[0].Pair.Value) != "1" || changes[0].Pair.Version != 2 {
+        t.Errorf("change 1 = %+v", changes[0].Pair)
+    }
+    
+    if changes, _ := e.Changes(5, 1); len(changes) != 1 || changes[0].Seq != 5 {
+        t.Errorf("Changes(5, 1) = %+v, want change 5", changes)
+    }
+    
+    // 保存的变更都不早于现在
+    if trimmed, err := e.TrimChanges(time.Now().Add(-time.Hour), 0); err != nil || trimmed != 0 {
+        t.Errorf("TrimChanges(an hour ago) = %d, %v, want 0", trimmed, err)
+    }
+    if trimmed, err := e.TrimChanges(time.Time{}, 4); err != nil || trimmed != 2 {
+        t.Errorf("TrimChanges(keep 4) = %d, %v, want 2", trimmed, err)
+    }
+    if first, last, _ := e.ChangeBounds(); first != 3 || last != 6 {
+        t.Errorf("ChangeBounds after trimming = %d, %d, want 3, 6", first, last)
+    }
+    if trimmed, err := e.TrimChanges(time.Now().Add(time.Second), 0); err != nil || trimmed != 4 {
+        t.Errorf("TrimChanges(now) = %d, %v, want 4", trimmed, err)
+    }
+    
+    // 清理后序号不会重复使用
+    mustApply(t, e, pair("d", "4", 4))
+    first, last, _ := e.ChangeBounds()
+    changes, _ = e.Changes(0, 100)
+    if first != 7 || last != 7 || len(changes) != 1 || changes[0].Seq != 7 {
+        t.Errorf("after trimming everything: bounds %d, %d, changes %+v", first, last, changes)
+    }
+}
+
+func testSnapshot(t *testing.T, e storage.Engine) {
+    e.SetHistoryVersions(3)
+    mustApply(t, e, pair("a", "1", 1), pair("a", "2", 2), tombstone("b", 3), pair("c", "3", 3))
+    
+    var buf bytes.Buffer
+    if err := e.Snapshot(&buf); err != nil {
+        t.Fatalf("Snapshot: %v", err)
+    }
+    // 快照之后的写入不影响已生成的快照
+    mustApply(t, e, pair("d", "4", 4))
+    
+    restored := storage.NewMemoryEngine()
+    if err := restored.Restore(bytes.NewReader(buf.Bytes())); err != nil {
+        t.Fatalf("Restore: %v", err)
+    }
+    all, _ := restored.ListPairs("", 10)
+    expectKeys(t, "restored keys", all, "a", "b", "c")
+    history, _ := restored.History("a", 0)
+    expectVersions(t, "restored History(a)", history, 2, 1)
+    if p, _ := restored.GetPair("b"); p == nil || !p.Deleted {
+        t.Errorf("restored b = %+v, want a tombstone", p)
+    }
+    
+    // 恢复到自身时按版本合并
+    if err := e.Restore(bytes.NewReader(buf.Bytes())); err != nil {
+        t.Fatalf("Restore: %v", err)
+    }
+    expectCount(t, e, 4)
+    if err := e.Restore(bytes.NewReader([]byte("not json"))); err == nil {
+        t.Error("Restore of a corrupt snapshot succeeded")
+    }
+}
+
+// testManyKeys 随机顺序写入和删除大量key，检查顺序和计数
+func testManyKeys(t *testing.T, e storage.Engine) {
+    const n = 5000
+    e.SetHistoryVersions(1)
+    rng := rand.New(rand.NewSource(1))
+    
+    live := make(map[string]bool)
+    for _, i := range rng.Perm(n) {
+        key := fmt.Sprintf("key%05d", i)
+        mustApply(t, e, pair(key, key, 1))
+        live[key] = true
+    }
+    for _, i := range rng.Perm(n)[:n/2] {
+        key := fmt.Sprintf("key%05d", i)
+        if err := e.Purge(key, 1); err != nil {
+            t.Fatalf("Purge: %v", err)
+        }
+        delete(live, key)
+    }
+    
+    var want []string
+    for key := range live {
+        want = append(want, key)
+    }
+    sort.Strings(want)
+    expectCount(t, e, len(want))
+    
+    forward, err := e.ScanPairs("", "", false, n)
+    if err != nil {
+        t.Fatalf("ScanPairs: %v", err)
+    }
+    expectKeys(t, "ScanPairs forward", forward, want...)
+    reverse, _ := e.ScanPairs("", "", true, n)
+    reversed := keys(reverse)
+    for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+        reversed[i], reversed[j] = reversed[j], reversed[i]
+    }
+    if fmt.Sprint(reversed) != fmt.Sprint(want) {
+        t.Error("ScanPairs reverse is not the forward order reversed")
+    }
+    
+    // 分页遍历得到同样的结果
+    var paged []string
+    for after := ""; ; {
+        page, err := e.ListPairs(after, 333)
+        if err != nil {
+            t.Fatalf("ListPairs: %v", err)
+        }
+        if len(page) == 0 {
+            break
+        }
+        paged = append(paged, keys(page)...)
+        after = page[len(page)-1].Key
+    }
+    if fmt.Sprint(paged) != fmt.Sprint(want) {
+        t.Errorf("paged ListPairs returned %d keys, want %d", len(paged), len(want))
+    }
+}
+
+func testConcurrent(t *testing.T, e storage.Engine) {
+    const writers, writes = 8, 200
+    var wg sync.WaitGroup
+    for w := 0; w < writers; w++ {
+        wg.Add(1)
+        go func(w int) {
+            defer wg.Done()
+            for i := 0; i < writes; i++ {
+                key := fmt.Sprintf("k%d", i%20)
+                if _, err := e.Apply(pair(key, fmt.Sprint(w), int64(w*writes+i))); err != nil {
+                    t.Errorf("Apply: %v", err)
+                    return
+                }
+                if _, err := e.ScanPairs("", "", i%2 == 0, 10); err != nil {
+                    t.Errorf("ScanPairs: %v", err)
+                    return
+                }
+            }
+        }(w)
+    }
+    wg.Wait()
+    
+    // 每个key最终保留最高的版本
+    pairs, _ := e.ListPairs("", 100)
+    if len(pairs) != 20 {
+        t.Fatalf("ListPairs returned %d keys, want 20", len(pairs))
+    }
+    for _, p := range pairs {
+        var i int
+        fmt.Sscanf(p.Key, "k%d", &i)
+        highest := int64((writers-1)*writes + (writes - 20 + i))
+        if p.Version != highest {
+            t.Errorf("%s version = %d, want %d", p.Key, p.Version, highest)
+        }
+    }
+}
\ No newline at end of file
@@ -0,0 +1,261 @@
+package storage
+
+import (
+    "encoding/binary"
+    "errors"
+    "time"
+    
+    "github.com/boltdb/bolt"
+)
+
+var hintsBucket = []byte("hints")
+
+// ErrHintsFull 目标节点的提示数量已达上限
+var ErrHintsFull = errors.New("hint backlog is full")
+
+// Hint 副本不可用时代为保存的写入，目标节点恢复后重放
+type Hint struct {
+    ID        uint64    `json:"-"`
+    NodeID    string    `json:"-"`
+    Pair      *KVPair   `json:"pair"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+func (h *Hint) clone() *Hint {
+    c := *h
+    c.Pair = h.Pair.clone()
+    return &c
+}
+
+// HintBacklog 某个目标节点积压的提示
+type HintBacklog struct {
+    NodeID string
+    Count  int
+    Oldest time.Time
+}
+
+// AddHint 为nodeID保存一条提示，积压数量达到limit时返回ErrHintsFull
+func (se *BoltEngine) AddHint(nodeID string, pair *KVPair, limit int) error {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    data := encodeHint(&Hint{Pair: pair, CreatedAt: time.Now()})
+    
+    err := se.db.Update(func(tx *bolt.Tx) error {
+        root, err := tx.CreateBucketIfNotExists(hintsBucket)
+        if err != nil {
+            return err
+        }
+        bucket, err := root.CreateBucketIfNotExists([]byte(nodeID))
+        if err != nil {
+            return err
+        }
+        if se.hintCounts[nodeID] >= limit {
+            return ErrHintsFull
+        }
+        
+        seq, err := bucket.NextSequence()
+        if err != nil {
+            return err
+        }
+        return bucket.Put(hintKey(seq), data)
+    })
+    if err != nil {
+        return err
+    }
+    se.hintCounts[nodeID]++
+    return nil
+}
+
+// Hints 按保存顺序返回nodeID最早的至多limit条提示
+func (se *BoltEngine) Hints(nodeID string, limit int) ([]*Hint, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var hints []*Hint
+    err := se.db.View(func(tx *bolt.Tx) error {
+        bucket := hintBucket(tx, nodeID)
+        if bucket == nil {
+            return nil
+        }
+        
+        c := bucket.Cursor()
+        for k, v := c.First(); k != nil && len(hints) < limit; k, v = c.Next() {
+            hint, err := decodeHint(v)
+            if err != nil {
+                return err
+            }
+            hint.ID = binary.BigEndian.Uint64(k)
+            hint.NodeID = nodeID
+            hints = append(hints, hint)
+        }
+        return nil
+    })
+    
+    return hints, err
+}
+
+// DeleteHints 删除已重放的提示
+func (se *BoltEngine) DeleteHints(hints []*Hint) error {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    deleted := make(map[string]int)
+    err := se.db.Update(func(tx *bolt.Tx) error {
+        for _, hint := range hints {
+            bucket := hintBucket(tx, hint.NodeID)
+            if bucket == nil || bucket.Get(hintKey(hint.ID)) == nil {
+                continue
+            }
+            if err := bucket.Delete(hintKey(hint.ID)); err != nil {
+                return err
+            }
+            deleted[hint.NodeID]++
+        }
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+    for nodeID, count := range deleted {
+        se.hintCounts[nodeID] -= count
+    }
+    return nil
+}
+
+// DropHints 删除nodeID的全部提示
+func (se *BoltEngine) DropHints(nodeID string) error {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    err := se.db.Update(func(tx *bolt.Tx) error {
+        root := tx.Bucket(hintsBucket)
+        if root == nil || root.Bucket([]byte(nodeID)) == nil {
+            return nil
+        }
+        return root.DeleteBucket([]byte(nodeID))
+    })
+    if err != nil {
+        return err
+    }
+    delete(se.hintCounts, nodeID)
+    return nil
+}
+
+// ExpireHints 删除保存时间早于before的提示，返回删除的数量
+func (se *BoltEngine) ExpireHints(before time.Time) (int, error) {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    expired := make(map[string]int)
+    err := se.db.Update(func(tx *bolt.Tx) error {
+        root := tx.Bucket(hintsBucket)
+        if root == nil {
+            return nil
+        }
+        
+        var nodeIDs [][]byte
+        root.ForEach(func(nodeID, _ []byte) error {
+            nodeIDs = append(nodeIDs, append([]byte(nil), nodeID...))
+            return nil
+        })
+        
+        for _, nodeID := range nodeIDs {
+            bucket := root.Bucket(nodeID)
+            // 提示按保存顺序排列，遇到未过期的即可停止
+            var keys [][]byte
+            c := bucket.Cursor()
+            for k, v := c.First(); k != nil; k, v = c.Next() {
+                hint, err := decodeHint(v)
+                if err != nil {
+                    return err
+                }
+                if !hint.CreatedAt.Before(before) {
+                    break
+                }
+                keys = append(keys, append([]byte(nil), k...))
+            }
+            for _, k := range keys {
+                if err := bucket.Delete(k); err != nil {
+                    return err
+                }
+            }
+            expired[string(nodeID)] = len(keys)
+        }
+        return nil
+    })
+    if err != nil {
+        return 0, err
+    }
+    
+    total := 0
+    for nodeID, count := range expired {
+        se.hintCounts[nodeID] -= count
+        total += count
+    }
+    return total, nil
+}
+
+// HintBacklogs 返回每个目标节点积压的提示
+func (se *BoltEngine) HintBacklogs() ([]HintBacklog, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var backlogs []HintBacklog
+    err := se.db.View(func(tx *bolt.Tx) error {
+        root := tx.Bucket(hintsBucket)
+        if root == nil {
+            return nil
+        }
+        
+        return root.ForEach(func(nodeID, _ []byte) error {
+            bucket := root.Bucket(nodeID)
+            backlog := HintBacklog{
+                NodeID: string(nodeID),
+                Count:  se.hintCounts[string(nodeID)],
+            }
+            if backlog.Count == 0 {
+                return nil
+            }
+            if _, v := bucket.Cursor().First(); v != nil {
+                hint, err := decodeHint(v)
+                if err != nil {
+                    return err
+                }
+                backlog.Oldest = hint.CreatedAt
+            }
+            backlogs = append(backlogs, backlog)
+            return nil
+        })
+    })
+    
+    return backlogs, err
+}
+
+// countHints 统计每个节点积压的提示数
+func countHints(tx *bolt.Tx) map[string]int {
+    counts := make(map[string]int)
+    root := tx.Bucket(hintsBucket)
+    if root == nil {
+        return counts
+    }
+    root.ForEach(func(nodeID, _ []byte) error {
+        counts[string(nodeID)] = root.Bucket(nodeID).Stats().KeyN
+        return nil
+    })
+    return counts
+}
+
+func hintBucket(tx *bolt.Tx, nodeID string) *bolt.Bucket {
+    root := tx.Bucket(hintsBucket)
+    if root == nil {
+        return nil
+    }
+    return root.Bucket([]byte(nodeID))
+}
+
+func hintKey(seq uint64) []byte {
+    key := make([]byte, 8)
+    binary.BigEndian.PutUint64(key, seq)
+    return key
+}
\ No newline at end of file
@@ -0,0 +1,48 @@
+package storage_test
+
+import (
+    "errors"
+    "testing"
+    
+    "rushkv/storage"
+)
+
+func TestBoltHintCountsSurviveReopen(t *testing.T) {
+    dir := t.TempDir()
+    e, err := storage.NewBoltEngine(dir)
+    if err != nil {
+        t.Fatalf("NewBoltEngine: %v", err)
+    }
+    for _, key := range []string{"a", "b", "c"} {
+        if err := e.AddHint("n1", storage.NewKVPair(key, nil, 1), 3); err != nil {
+            t.Fatalf("AddHint: %v", err)
+        }
+    }
+    if err := e.Close(); err != nil {
+        t.Fatalf("Close: %v", err)
+    }
+    
+    e, err = storage.NewBoltEngine(dir)
+    if err != nil {
+        t.Fatalf("reopen: %v", err)
+    }
+    defer e.Close()
+    
+    if err := e.AddHint("n1", storage.NewKVPair("d", nil, 1), 3); !errors.Is(err, storage.ErrHintsFull) {
+        t.Fatalf("AddHint over the limit after reopen = %v, want ErrHintsFull", err)
+    }
+    hints, err := e.Hints("n1", 1)
+    if err != nil || len(hints) != 1 {
+        t.Fatalf("Hints = %+v, %v", hints, err)
+    }
+    if err := e.DeleteHints(append(hints, hints...)); err != nil {
+        t.Fatalf("DeleteHints: %v", err)
+    }
+    if err := e.AddHint("n1", storage.NewKVPair("d", nil, 1), 3); err != nil {
+        t.Fatalf("AddHint after DeleteHints: %v", err)
+    }
+    backlogs, err := e.HintBacklogs()
+    if err != nil || len(backlogs) != 1 || backlogs[0].Count != 3 {
+        t.Fatalf("HintBacklogs = %+v, %v, want 3 hints for n1", backlogs, err)
+    }
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+    "bytes"
+    "encoding/binary"
+    "time"
+    
+    "github.com/boltdb/bolt"
+)
+
+var historyBucket = []byte("history")
+
+// DefaultHistoryVersions 默认每个key保留的版本数
+const DefaultHistoryVersions = 10
+
+// SetHistoryVersions 设置每个key最多保留的版本数（包括当前版本），1表示不保留历史版本。
+// 超出的最旧版本在下次写入该key时删除
+func (se *BoltEngine) SetHistoryVersions(n int) {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    if n < 1 {
+        n = 1
+    }
+    se.historyVersions = n
+}
+
+// GetPairAt 返回key在version时的记录，即版本不超过version的最新记录（可能是墓碑），不存在时返回nil
+func (se *BoltEngine) GetPairAt(key string, version int64) (*KVPair, error) {
+    return se.pairAt(key, func(pair *KVPair) bool {
+        return pair.Version <= version
+    })
+}
+
+// GetPairAtTime 返回key在t时的记录，即写入时间不晚于t的最新记录，不存在时返回nil
+func (se *BoltEngine) GetPairAtTime(key string, t time.Time) (*KVPair, error) {
+    return se.pairAt(key, func(pair *KVPair) bool {
+        return !pair.Timestamp.After(t)
+    })
+}
+
+// History 按版本从新到旧返回key的至多limit个版本（包括当前版本和墓碑），limit为0表示不限制
+func (se *BoltEngine) History(key string, limit int) ([]*KVPair, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var pairs []*KVPair
+    err := se.db.View(func(tx *bolt.Tx) error {
+        if data := tx.Bucket([]byte("kv")).Get([]byte(key)); data != nil {
+            current, err := decodePair(data)
+            if err != nil {
+                return err
+            }
+            pairs = append(pairs, current)
+        }
+        
+        return walkHistory(tx, key, func(pair *KVPair) bool {
+            if len(pairs) > 0 && pairs[0].Version == pair.Version {
+                return true
+            }
+            pairs = append(pairs, pair)
+            return limit <= 0 || len(pairs) < limit
+        })
+    })
+    if limit > 0 && len(pairs) > limit {
+        pairs = pairs[:limit]
+    }
+    
+    return pairs, err
+}
+
+// pairAt 从当前记录开始按版本从新到旧查找第一条满足match的记录
+func (se *BoltEngine) pairAt(key string, match func(*KVPair) bool) (*KVPair, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var result *KVPair
+    err := se.db.View(func(tx *bolt.Tx) error {
+        if data := tx.Bucket([]byte("kv")).Get([]byte(key)); data != nil {
+            current, err := decodePair(data)
+            if err != nil {
+                return err
+            }
+            if match(current) {
+                result = current
+                return nil
+            }
+        }
+        
+        return walkHistory(tx, key, func(pair *KVPair) bool {
+            if match(pair) {
+                result = pair
+                return false
+            }
+            return true
+        })
+    })
+    
+    return result, err
+}
+
+// walkHistory 按版本从新到旧遍历key的历史版本，fn返回false时停止
+func walkHistory(tx *bolt.Tx, key string, fn func(*KVPair) bool) error {
+    bucket := tx.Bucket(historyBucket)
+    if bucket == nil {
+        return nil
+    }
+    
+    prefix := historyPrefix(key)
+    c := bucket.Cursor()
+    k, v := c.Seek(historyKey(key, -1))
+    if k == nil {
+        k, v = c.Last()
+    } else {
+        k, v = c.Prev()
+    }
+    for ; k != nil && bytes.HasPrefix(k, prefix); k, v = c.Prev() {
+        pair, err := decodePair(v)
+        if err != nil {
+            return err
+        }
+        if !fn(pair) {
+            return nil
+        }
+    }
+    return nil
+}
+
+// archive 把不再是当前版本的记录存入历史版本，并删除超出保留数量的最旧版本
+func (se *BoltEngine) archive(tx *bolt.Tx, pair *KVPair) error {
+    if se.historyVersions <= 1 {
+        return nil
+    }
+    
+    bucket, err := tx.CreateBucketIfNotExists(historyBucket)
+    if err != nil {
+        return err
+    }
+    if err := bucket.Put(historyKey(pair.Key, pair.Version), encodePair(pair)); err != nil {
+        return err
+    }
+    
+    // 当前版本占一个名额
+    prefix := historyPrefix(pair.Key)
+    var keys [][]byte
+    c := bucket.Cursor()
+    for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+        keys = append(keys, append([]byte(nil), k...))
+    }
+    for i := 0; i < len(keys)-(se.historyVersions-1); i++ {
+        if err := bucket.Delete(keys[i]); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// dropHistory 删除key的所有历史版本
+func dropHistory(tx *bolt.Tx, key string) error {
+    bucket := tx.Bucket(historyBucket)
+    if bucket == nil {
+        return nil
+    }
+    
+    prefix := historyPrefix(key)
+    var keys [][]byte
+    c := bucket.Cursor()
+    for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+        keys = append(keys, append([]byte(nil), k...))
+    }
+    for _, k := range keys {
+        if err := bucket.Delete(k); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// historyPrefix 以key的长度开头，避免一个key是另一个key的前缀时历史版本混在一起
+func historyPrefix(key string) []byte {
+    prefix := make([]byte, 4, 4+len(key))
+    binary.BigEndian.PutUint32(prefix, uint32(len(key)))
+    return append(prefix, key...)
+}
+
+// historyKey 历史版本的key为前缀加上版本号，同一key的版本按版本号升序排列。
+// version为-1时得到该key所有版本之后的位置
+func historyKey(key string, version int64) []byte {
+    k := make([]byte, 8)
+    binary.BigEndian.PutUint64(k, uint64(version))
+    return append(historyPrefix(key), k...)
+}
\ No newline at end of file
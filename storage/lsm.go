@@ -0,0 +1,1246 @@
+package storage
+
+import (
+    "encoding/binary"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+const (
+    // memtable达到该大小后转为不可变，由后台写入L0
+    lsmMemtableSize = 4 << 20
+    lsmLevels       = 7
+    // L0的表数达到该值时合并到L1，达到stop值时写入等待合并
+    lsmL0CompactionTrigger = 4
+    lsmL0StopTrigger       = 12
+    // L1的目标大小，之后每层是上一层的10倍
+    lsmL1MaxBytes      = 10 << 20
+    lsmLevelMultiplier = 10
+    // 合并输出的单个表的目标大小
+    lsmTableSize = 2 << 20
+)
+
+// LSM中各类数据的key前缀
+const (
+    lsmPairPrefix    = "k"
+    lsmHistoryPrefix = "h"
+    lsmHintPrefix    = "n"
+    lsmTxnPrefix     = "t"
+    lsmChangePrefix  = "c"
+    // 已分配的最大变更序号，变更日志被全部清理后重启时从这里继续
+    lsmChangeSeqKey = "s"
+)
+
+var errLSMClosed = errors.New("storage engine is closed")
+
+// LSMEngine 日志结构合并树存储引擎：写入先追加到预写日志并放入memtable，
+// memtable写满后成为L0的SSTable，后台把各层逐层合并到下一层。
+// 并发写入的日志落盘合并为一次fsync，适合写入密集的负载
+type LSMEngine struct {
+    dir   string
+    mutex sync.RWMutex
+    // 写入因memtable或L0已满而等待时使用
+    roomCond *sync.Cond
+    mem      *memtable
+    // 正在写入L0的memtable及其日志的编号
+    imm       *memtable
+    immLogNum uint64
+    log       *wal
+    logNum    uint64
+    version   *lsmVersion
+    // 下一个日志或表文件的编号
+    nextFile uint64
+    // 后台写入或合并失败后拒绝新的写入
+    bgErr  error
+    closed bool
+    
+    // 每个key最多保留的版本数（包括当前版本）
+    historyVersions int
+    // 当前记录数（包括墓碑）
+    count int
+    // 每个节点已分配的最大提示序号和积压的提示数
+    hintSeqs   map[string]uint64
+    hintCounts map[string]int
+    // 已分配的最大变更序号
+    changeSeq uint64
+    
+    // 同时只进行一次memtable写入和一次合并
+    flushMutex   sync.Mutex
+    compactMutex sync.Mutex
+    // 每层下一次合并的起始key，使合并轮流覆盖整层
+    compactPointers [lsmLevels]string
+    workCh          chan struct{}
+    closeCh         chan struct{}
+    wg              sync.WaitGroup
+}
+
+// lsmEntry LSM中的一条内部记录，deleted表示删除标记
+type lsmEntry struct {
+    key     string
+    value   []byte
+    deleted bool
+}
+
+func (e *lsmEntry) itemKey() string {
+    return e.key
+}
+
+type memtable struct {
+    tree btree[*lsmEntry]
+    size int
+}
+
+func (m *memtable) apply(entries []*lsmEntry) {
+    for _, e := range entries {
+        m.tree.Set(e)
+        m.size += len(e.key) + len(e.value) + 16
+    }
+}
+
+// lsmVersion 某一时刻各层的表，L0按从新到旧排列且可能重叠，其余各层按key排序且互不重叠。
+// 安装后不再修改
+type lsmVersion struct {
+    levels [lsmLevels][]*sstable
+}
+
+func (v *lsmVersion) clone() *lsmVersion {
+    c := &lsmVersion{}
+    for level, tables := range v.levels {
+        c.levels[level] = append([]*sstable(nil), tables...)
+    }
+    return c
+}
+
+// lsmManifest 持久化的版本信息，编号小于LogNumber的日志已全部写入表中
+type lsmManifest struct {
+    NextFile  uint64     `json:"next_file"`
+    LogNumber uint64     `json:"log_number"`
+    Levels    [][]uint64 `json:"levels"`
+}
+
+func NewLSMEngine(dataPath string) (*LSMEngine, error) {
+    dir := filepath.Join(dataPath, "lsm")
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return nil, fmt.Errorf("failed to create data directory: %v", err)
+    }
+    
+    le := &LSMEngine{
+        dir:             dir,
+        mem:             &memtable{},
+        version:         &lsmVersion{},
+        nextFile:        1,
+        historyVersions: DefaultHistoryVersions,
+        hintSeqs:        make(map[string]uint64),
+        hintCounts:      make(map[string]int),
+        workCh:          make(chan struct{}, 1),
+        closeCh:         make(chan struct{}),
+    }
+    le.roomCond = sync.NewCond(&le.mutex)
+    
+    if err := le.recover(); err != nil {
+        le.closeTables()
+        return nil, err
+    }
+    
+    le.wg.Add(1)
+    go le.background()
+    le.schedule()
+    return le, nil
+}
+
+// recover 按清单打开各层的表，重放未写入表的日志，删除不再使用的文件
+func (le *LSMEngine) recover() error {
+    manifest := &lsmManifest{NextFile: 1}
+    data, err := os.ReadFile(filepath.Join(le.dir, "MANIFEST"))
+    if err == nil {
+        if err := json.Unmarshal(data, manifest); err != nil {
+            return fmt.Errorf("failed to read manifest: %v", err)
+        }
+    } else if !os.IsNotExist(err) {
+        return fmt.Errorf("failed to read manifest: %v", err)
+    }
+    le.nextFile = manifest.NextFile
+    
+    live := make(map[uint64]bool)
+    for level, nums := range manifest.Levels {
+        for _, num := range nums {
+            table, err := openSSTable(num, le.tablePath(num))
+            if err != nil {
+                return err
+            }
+            le.version.levels[level] = append(le.version.levels[level], table)
+            live[num] = true
+        }
+    }
+    
+    files, err := os.ReadDir(le.dir)
+    if err != nil {
+        return fmt.Errorf("failed to read data directory: %v", err)
+    }
+    var logs []uint64
+    for _, file := range files {
+        name := file.Name()
+        ext := filepath.Ext(name)
+        num, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 10, 64)
+        if err != nil {
+            continue
+        }
+        switch ext {
+        case ".wal":
+            if num >= manifest.LogNumber {
+                logs = append(logs, num)
+            } else {
+                os.Remove(filepath.Join(le.dir, name))
+            }
+        case ".sst":
+            // 写入清单之前崩溃留下的表
+            if !live[num] {
+                os.Remove(filepath.Join(le.dir, name))
+            }
+        }
+        if num >= le.nextFile {
+            le.nextFile = num + 1
+        }
+    }
+    
+    sort.Slice(logs, func(i, j int) bool { return logs[i] < logs[j] })
+    for _, num := range logs {
+        if err := replayWAL(le.walPath(num), le.mem.apply); err != nil {
+            return err
+        }
+    }
+    
+    le.logNum = le.newFileNumber()
+    if le.log, err = createWAL(le.walPath(le.logNum)); err != nil {
+        return err
+    }
+    if le.mem.tree.Len() > 0 {
+        tables, err := le.writeTables(newMemtableIterator(&le.mem.tree, "", "", false), false)
+        if err != nil {
+            return err
+        }
+        le.version.levels[0] = append(tables, le.version.levels[0]...)
+        le.mem = &memtable{}
+    }
+    if err := le.saveManifest(le.version); err != nil {
+        return err
+    }
+    for _, num := range logs {
+        os.Remove(le.walPath(num))
+    }
+    
+    return le.loadCounters()
+}
+
+// loadCounters 统计记录数、最大的变更序号以及每个节点的提示序号和数量
+func (le *LSMEngine) loadCounters() error {
+    err := le.scan(lsmPairPrefix, PrefixEnd(lsmPairPrefix), false, nil, func(*lsmEntry) bool {
+        le.count++
+        return true
+    })
+    if err != nil {
+        return err
+    }
+    
+    e, err := le.get(lsmChangeSeqKey)
+    if err != nil {
+        return err
+    }
+    if e != nil && len(e.value) == 8 {
+        le.changeSeq = binary.BigEndian.Uint64(e.value)
+    }
+    err = le.scan(lsmChangePrefix, PrefixEnd(lsmChangePrefix), true, nil, func(e *lsmEntry) bool {
+        le.changeSeq = max(le.changeSeq, parseLSMChangeKey(e.key))
+        return false
+    })
+    if err != nil {
+        return err
+    }
+    
+    return le.scan(lsmHintPrefix, PrefixEnd(lsmHintPrefix), false, nil, func(e *lsmEntry) bool {
+        nodeID, seq := parseLSMHintKey(e.key)
+        le.hintCounts[nodeID]++
+        if seq > le.hintSeqs[nodeID] {
+            le.hintSeqs[nodeID] = seq
+        }
+        return true
+    })
+}
+
+func (le *LSMEngine) tablePath(num uint64) string {
+    return filepath.Join(le.dir, fmt.Sprintf("%06d.sst", num))
+}
+
+func (le *LSMEngine) walPath(num uint64) string {
+    return filepath.Join(le.dir, fmt.Sprintf("%06d.wal", num))
+}
+
+// newFileNumber 分配一个文件编号，调用者需持有写锁或在启动阶段调用
+func (le *LSMEngine) newFileNumber() uint64 {
+    num := le.nextFile
+    le.nextFile++
+    return num
+}
+
+// saveManifest 原子地替换清单文件，调用者需持有写锁
+func (le *LSMEngine) saveManifest(v *lsmVersion) error {
+    // 不可变memtable的日志在它写入L0之前仍然需要
+    logNum := le.logNum
+    if le.imm != nil {
+        logNum = le.immLogNum
+    }
+    manifest := &lsmManifest{
+        NextFile:  le.nextFile,
+        LogNumber: logNum,
+        Levels:    make([][]uint64, lsmLevels),
+    }
+    for level, tables := range v.levels {
+        manifest.Levels[level] = []uint64{}
+        for _, table := range tables {
+            manifest.Levels[level] = append(manifest.Levels[level], table.num)
+        }
+    }
+    
+    data, err := json.Marshal(manifest)
+    if err != nil {
+        return fmt.Errorf("failed to marshal manifest: %v", err)
+    }
+    path := filepath.Join(le.dir, "MANIFEST")
+    file, err := os.Create(path + ".tmp")
+    if err != nil {
+        return fmt.Errorf("failed to write manifest: %v", err)
+    }
+    if _, err = file.Write(data); err == nil {
+        err = file.Sync()
+    }
+    if closeErr := file.Close(); err == nil {
+        err = closeErr
+    }
+    if err == nil {
+        err = os.Rename(path+".tmp", path)
+    }
+    if err != nil {
+        os.Remove(path + ".tmp")
+        return fmt.Errorf("failed to write manifest: %v", err)
+    }
+    return nil
+}
+
+// get 返回key的最新记录，不存在或已删除时返回nil，调用者需持有锁
+func (le *LSMEngine) get(key string) (*lsmEntry, error) {
+    e, err := le.find(key)
+    if e == nil || e.deleted {
+        return nil, err
+    }
+    return e, nil
+}
+
+// find 从新到旧依次查找memtable和各层的表
+func (le *LSMEngine) find(key string) (*lsmEntry, error) {
+    if e := le.mem.tree.Get(key); e != nil {
+        return e, nil
+    }
+    if le.imm != nil {
+        if e := le.imm.tree.Get(key); e != nil {
+            return e, nil
+        }
+    }
+    
+    for _, table := range le.version.levels[0] {
+        if e, err := table.get(key); e != nil || err != nil {
+            return e, err
+        }
+    }
+    for _, tables := range le.version.levels[1:] {
+        i := sort.Search(len(tables), func(i int) bool {
+            return tables[i].largest >= key
+        })
+        if i < len(tables) {
+            if e, err := tables[i].get(key); e != nil || err != nil {
+                return e, err
+            }
+        }
+    }
+    return nil, nil
+}
+
+// iterator 合并pending（可为nil）、memtable和各层的表，调用者需持有锁
+func (le *LSMEngine) iterator(start, end string, reverse bool, pending *btree[*lsmEntry]) lsmIterator {
+    var sources []lsmIterator
+    if pending != nil {
+        sources = append(sources, newMemtableIterator(pending, start, end, reverse))
+    }
+    sources = append(sources, newMemtableIterator(&le.mem.tree, start, end, reverse))
+    if le.imm != nil {
+        sources = append(sources, newMemtableIterator(&le.imm.tree, start, end, reverse))
+    }
+    for _, table := range le.version.levels[0] {
+        if table.largest >= start && (end == "" || table.smallest < end) {
+            sources = append(sources, newTableIterator(table, start, end, reverse))
+        }
+    }
+    for _, tables := range le.version.levels[1:] {
+        if len(tables) > 0 {
+            sources = append(sources, newLevelIterator(tables, start, end, reverse))
+        }
+    }
+    return newMergeIterator(sources, reverse)
+}
+
+// scan 按key顺序遍历[start, end)内未删除的记录，end为空表示不设上界，fn返回false时停止
+func (le *LSMEngine) scan(start, end string, reverse bool, pending *btree[*lsmEntry], fn func(*lsmEntry) bool) error {
+    it := le.iterator(start, end, reverse, pending)
+    for ; it.valid(); it.next() {
+        e := it.entry()
+        if !reverse && end != "" && e.key >= end {
+            break
+        }
+        if reverse && e.key < start {
+            break
+        }
+        if e.deleted {
+            continue
+        }
+        if !fn(e) {
+            break
+        }
+    }
+    return it.err()
+}
+
+// lsmBatch 一次写操作产生的全部写入，作为一条日志原子地写入。
+// 通过batch读取时能看到batch中尚未提交的写入
+type lsmBatch struct {
+    le      *LSMEngine
+    entries []*lsmEntry
+    pending btree[*lsmEntry]
+}
+
+func (b *lsmBatch) put(key string, value []byte) {
+    e := &lsmEntry{key: key, value: value}
+    b.entries = append(b.entries, e)
+    b.pending.Set(e)
+}
+
+func (b *lsmBatch) delete(key string) {
+    e := &lsmEntry{key: key, deleted: true}
+    b.entries = append(b.entries, e)
+    b.pending.Set(e)
+}
+
+func (b *lsmBatch) get(key string) (*lsmEntry, error) {
+    if e := b.pending.Get(key); e != nil {
+        if e.deleted {
+            return nil, nil
+        }
+        return e, nil
+    }
+    return b.le.get(key)
+}
+
+func (b *lsmBatch) scan(start, end string, reverse bool, fn func(*lsmEntry) bool) error {
+    return b.le.scan(start, end, reverse, &b.pending, fn)
+}
+
+// update 在写锁下由fn生成一个批次，写入日志和memtable，释放锁后等待日志落盘
+func (le *LSMEngine) update(fn func(b *lsmBatch) error) error {
+    le.mutex.Lock()
+    if err := le.makeRoom(); err != nil {
+        le.mutex.Unlock()
+        return err
+    }
+    
+    b := &lsmBatch{le: le}
+    if err := fn(b); err != nil || len(b.entries) == 0 {
+        le.mutex.Unlock()
+        return err
+    }
+    
+    log := le.log
+    seq, err := log.append(b.entries)
+    if err != nil {
+        le.bgErr = err
+        le.mutex.Unlock()
+        return err
+    }
+    le.mem.apply(b.entries)
+    le.mutex.Unlock()
+    
+    return log.sync(seq)
+}
+
+// makeRoom 等待L0的表数降到上限以下，memtable写满时换用新的memtable和日志
+func (le *LSMEngine) makeRoom() error {
+    for {
+        switch {
+        case le.closed:
+            return errLSMClosed
+        case le.bgErr != nil:
+            return le.bgErr
+        case len(le.version.levels[0]) >= lsmL0StopTrigger:
+            le.roomCond.Wait()
+        case le.mem.size < lsmMemtableSize:
+            return nil
+        case le.imm != nil:
+            le.roomCond.Wait()
+        default:
+            if err := le.rotate(); err != nil {
+                return err
+            }
+        }
+    }
+}
+
+// rotate 把当前memtable转为不可变并交给后台写入L0，之后的写入使用新的日志
+func (le *LSMEngine) rotate() error {
+    num := le.newFileNumber()
+    log, err := createWAL(le.walPath(num))
+    if err != nil {
+        return err
+    }
+    if err := le.log.close(); err != nil {
+        log.close()
+        os.Remove(le.walPath(num))
+        return err
+    }
+    
+    le.log = log
+    le.immLogNum = le.logNum
+    le.logNum = num
+    le.imm = le.mem
+    le.mem = &memtable{}
+    le.schedule()
+    return nil
+}
+
+func pairKey(key string) string {
+    return lsmPairPrefix + key
+}
+
+func lsmHistoryKey(key string, version int64) string {
+    return lsmHistoryPrefix + string(historyKey(key, version))
+}
+
+func lsmHistoryPrefixOf(key string) string {
+    return lsmHistoryPrefix + string(historyPrefix(key))
+}
+
+// lsmHintPrefixOf 节点ID以长度开头，避免一个节点ID是另一个的前缀时提示混在一起
+func lsmHintPrefixOf(nodeID string) string {
+    prefix := make([]byte, 4, 4+len(nodeID))
+    binary.BigEndian.PutUint32(prefix, uint32(len(nodeID)))
+    return lsmHintPrefix + string(append(prefix, nodeID...))
+}
+
+func lsmHintKey(nodeID string, seq uint64) string {
+    return lsmHintPrefixOf(nodeID) + string(hintKey(seq))
+}
+
+func parseLSMHintKey(key string) (string, uint64) {
+    n := int(binary.BigEndian.Uint32([]byte(key[1:5])))
+    return key[5 : 5+n], binary.BigEndian.Uint64([]byte(key[5+n:]))
+}
+
+func lsmChangeKey(seq uint64) string {
+    return lsmChangePrefix + string(changeKey(seq))
+}
+
+func parseLSMChangeKey(key string) uint64 {
+    return binary.BigEndian.Uint64([]byte(key[1:]))
+}
+
+// getPair 读取key的当前记录，不存在时返回nil
+func getPair(get func(string) (*lsmEntry, error), key string) (*KVPair, error) {
+    e, err := get(pairKey(key))
+    if e == nil || err != nil {
+        return nil, err
+    }
+    return decodePair(e.value)
+}
+
+func (le *LSMEngine) Put(key string, value []byte, version int64) error {
+    return le.update(func(b *lsmBatch) error {
+        current, err := b.get(pairKey(key))
+        if err != nil {
+            return err
+        }
+        pair := &KVPair{
+            Key:       key,
+            Value:     value,
+            Version:   version,
+            Timestamp: time.Now(),
+        }
+        if current == nil {
+            le.count++
+        }
+        b.put(pairKey(key), encodePair(pair))
+        le.appendChange(b, ChangePut, pair)
+        return nil
+    })
+}
+
+func (le *LSMEngine) Get(key string) ([]byte, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    pair, err := getPair(le.get, key)
+    if err != nil {
+        return nil, err
+    }
+    if pair == nil || pair.Deleted || pair.Expired(time.Now()) {
+        return nil, fmt.Errorf("key not found")
+    }
+    return pair.Value, nil
+}
+
+func (le *LSMEngine) Delete(key string, version int64) error {
+    return le.update(func(b *lsmBatch) error {
+        pair, err := getPair(b.get, key)
+        if err != nil {
+            return err
+        }
+        if pair == nil {
+            return fmt.Errorf("key not found")
+        }
+        
+        pair.Deleted = true
+        pair.Version = version
+        pair.Timestamp = time.Now()
+        data := encodePair(pair)
+        b.put(pairKey(key), data)
+        le.appendChange(b, ChangeDelete, pair)
+        return nil
+    })
+}
+
+func (le *LSMEngine) GetPair(key string) (*KVPair, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    return getPair(le.get, key)
+}
+
+func (le *LSMEngine) Apply(pair *KVPair) (bool, error) {
+    applied := false
+    err := le.update(func(b *lsmBatch) error {
+        var err error
+        applied, err = le.applyPair(b, pair)
+        return err
+    })
+    return applied, err
+}
+
+func (le *LSMEngine) ApplyPairs(pairs []*KVPair) ([]bool, error) {
+    applied := make([]bool, len(pairs))
+    err := le.update(func(b *lsmBatch) error {
+        for i, pair := range pairs {
+            ok, err := le.applyPair(b, pair)
+            if err != nil {
+                return err
+            }
+            applied[i] = ok
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    return applied, nil
+}
+
+// applyPair 与BoltEngine相同：较旧的记录只存入历史版本，被取代的当前记录转入历史版本，
+// 写入的记录追加到变更日志
+func (le *LSMEngine) applyPair(b *lsmBatch, pair *KVPair) (bool, error) {
+    current, err := getPair(b.get, pair.Key)
+    if err != nil {
+        return false, err
+    }
+    if current != nil {
+        if current.Version > pair.Version {
+            return false, le.archive(b, pair)
+        }
+        if current.Version < pair.Version {
+            if err := le.archive(b, current); err != nil {
+                return false, err
+            }
+        }
+    }
+    
+    data := encodePair(pair)
+    if current == nil {
+        le.count++
+    }
+    b.put(pairKey(pair.Key), data)
+    le.appendChange(b, pairChange(pair), pair)
+    return true, nil
+}
+
+// archive 把不再是当前版本的记录存入历史版本，并删除超出保留数量的最旧版本
+func (le *LSMEngine) archive(b *lsmBatch, pair *KVPair) error {
+    if le.historyVersions <= 1 {
+        return nil
+    }
+    
+    b.put(lsmHistoryKey(pair.Key, pair.Version), encodePair(pair))
+    
+    // 当前版本占一个名额
+    prefix := lsmHistoryPrefixOf(pair.Key)
+    var keys []string
+    err := b.scan(prefix, PrefixEnd(prefix), false, func(e *lsmEntry) bool {
+        keys = append(keys, e.key)
+        return true
+    })
+    if err != nil {
+        return err
+    }
+    for i := 0; i < len(keys)-(le.historyVersions-1); i++ {
+        b.delete(keys[i])
+    }
+    return nil
+}
+
+func (le *LSMEngine) ListPairs(after string, limit int) ([]*KVPair, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    var pairs []*KVPair
+    var decodeErr error
+    err := le.scan(pairKey(after), PrefixEnd(lsmPairPrefix), false, nil, func(e *lsmEntry) bool {
+        if e.key == pairKey(after) {
+            return true
+        }
+        if len(pairs) >= limit {
+            return false
+        }
+        pair, err := decodePair(e.value)
+        if err != nil {
+            decodeErr = err
+            return false
+        }
+        pairs = append(pairs, pair)
+        return true
+    })
+    if err == nil {
+        err = decodeErr
+    }
+    return pairs, err
+}
+
+func (le *LSMEngine) ScanPairs(start, end string, reverse bool, limit int) ([]*KVPair, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    upper := PrefixEnd(lsmPairPrefix)
+    if end != "" {
+        upper = pairKey(end)
+    }
+    
+    var pairs []*KVPair
+    var decodeErr error
+    err := le.scan(pairKey(start), upper, reverse, nil, func(e *lsmEntry) bool {
+        if len(pairs) >= limit {
+            return false
+        }
+        pair, err := decodePair(e.value)
+        if err != nil {
+            decodeErr = err
+            return false
+        }
+        pairs = append(pairs, pair)
+        return true
+    })
+    if err == nil {
+        err = decodeErr
+    }
+    return pairs, err
+}
+
+func (le *LSMEngine) Purge(key string, version int64) error {
+    return le.update(func(b *lsmBatch) error {
+        _, err := le.purge(b, key, version)
+        return err
+    })
+}
+
+func (le *LSMEngine) PurgePairs(pairs []*KVPair) (int, error) {
+    now := time.Now()
+    purged := 0
+    err := le.update(func(b *lsmBatch) error {
+        for _, pair := range pairs {
+            current, err := le.purge(b, pair.Key, pair.Version)
+            if err != nil {
+                return err
+            }
+            if current == nil {
+                continue
+            }
+            if !current.Deleted && current.Expired(now) {
+                le.appendChange(b, ChangeExpire, current)
+            }
+            purged++
+        }
+        return nil
+    })
+    return purged, err
+}
+
+// purge 记录版本仍为version时删除记录及其历史版本，返回被删除的记录
+func (le *LSMEngine) purge(b *lsmBatch, key string, version int64) (*KVPair, error) {
+    pair, err := getPair(b.get, key)
+    if err != nil || pair == nil || pair.Version != version {
+        return nil, err
+    }
+    
+    prefix := lsmHistoryPrefixOf(key)
+    var keys []string
+    err = b.scan(prefix, PrefixEnd(prefix), false, func(e *lsmEntry) bool {
+        keys = append(keys, e.key)
+        return true
+    })
+    if err != nil {
+        return nil, err
+    }
+    for _, k := range keys {
+        b.delete(k)
+    }
+    b.delete(pairKey(key))
+    le.count--
+    return pair, nil
+}
+
+func (le *LSMEngine) Count() (int, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    return le.count, nil
+}
+
+func (le *LSMEngine) SetHistoryVersions(n int) {
+    le.mutex.Lock()
+    defer le.mutex.Unlock()
+    
+    if n < 1 {
+        n = 1
+    }
+    le.historyVersions = n
+}
+
+func (le *LSMEngine) GetPairAt(key string, version int64) (*KVPair, error) {
+    return le.pairAt(key, func(pair *KVPair) bool {
+        return pair.Version <= version
+    })
+}
+
+func (le *LSMEngine) GetPairAtTime(key string, t time.Time) (*KVPair, error) {
+    return le.pairAt(key, func(pair *KVPair) bool {
+        return !pair.Timestamp.After(t)
+    })
+}
+
+func (le *LSMEngine) History(key string, limit int) ([]*KVPair, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    var pairs []*KVPair
+    err := le.walkVersions(key, func(pair *KVPair) bool {
+        pairs = append(pairs, pair)
+        return limit <= 0 || len(pairs) < limit
+    })
+    return pairs, err
+}
+
+func (le *LSMEngine) pairAt(key string, match func(*KVPair) bool) (*KVPair, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    var result *KVPair
+    err := le.walkVersions(key, func(pair *KVPair) bool {
+        if match(pair) {
+            result = pair
+            return false
+        }
+        return true
+    })
+    return result, err
+}
+
+// walkVersions 从当前记录开始按版本从新到旧遍历key的所有版本，fn返回false时停止
+func (le *LSMEngine) walkVersions(key string, fn func(*KVPair) bool) error {
+    current, err := getPair(le.get, key)
+    if err != nil {
+        return err
+    }
+    if current != nil && !fn(current) {
+        return nil
+    }
+    
+    prefix := lsmHistoryPrefixOf(key)
+    var decodeErr error
+    err = le.scan(prefix, PrefixEnd(prefix), true, nil, func(e *lsmEntry) bool {
+        pair, err := decodePair(e.value)
+        if err != nil {
+            decodeErr = err
+            return false
+        }
+        if current != nil && pair.Version == current.Version {
+            return true
+        }
+        return fn(pair)
+    })
+    if err == nil {
+        err = decodeErr
+    }
+    return err
+}
+
+func (le *LSMEngine) AddHint(nodeID string, pair *KVPair, limit int) error {
+    data := encodeHint(&Hint{Pair: pair, CreatedAt: time.Now()})
+    
+    return le.update(func(b *lsmBatch) error {
+        if le.hintCounts[nodeID] >= limit {
+            return ErrHintsFull
+        }
+        le.hintSeqs[nodeID]++
+        le.hintCounts[nodeID]++
+        b.put(lsmHintKey(nodeID, le.hintSeqs[nodeID]), data)
+        return nil
+    })
+}
+
+func (le *LSMEngine) Hints(nodeID string, limit int) ([]*Hint, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    var hints []*Hint
+    var decodeErr error
+    prefix := lsmHintPrefixOf(nodeID)
+    err := le.scan(prefix, PrefixEnd(prefix), false, nil, func(e *lsmEntry) bool {
+        if len(hints) >= limit {
+            return false
+        }
+        hint, err := decodeHint(e.value)
+        if err != nil {
+            decodeErr = err
+            return false
+        }
+        _, hint.ID = parseLSMHintKey(e.key)
+        hint.NodeID = nodeID
+        hints = append(hints, hint)
+        return true
+    })
+    if err == nil {
+        err = decodeErr
+    }
+    return hints, err
+}
+
+func (le *LSMEngine) DeleteHints(hints []*Hint) error {
+    return le.update(func(b *lsmBatch) error {
+        for _, hint := range hints {
+            key := lsmHintKey(hint.NodeID, hint.ID)
+            e, err := b.get(key)
+            if err != nil {
+                return err
+            }
+            if e != nil {
+                b.delete(key)
+                le.hintCounts[hint.NodeID]--
+            }
+        }
+        return nil
+    })
+}
+
+func (le *LSMEngine) DropHints(nodeID string) error {
+    return le.update(func(b *lsmBatch) error {
+        prefix := lsmHintPrefixOf(nodeID)
+        var keys []string
+        err := b.scan(prefix, PrefixEnd(prefix), false, func(e *lsmEntry) bool {
+            keys = append(keys, e.key)
+            return true
+        })
+        if err != nil {
+            return err
+        }
+        for _, key := range keys {
+            b.delete(key)
+        }
+        delete(le.hintCounts, nodeID)
+        return nil
+    })
+}
+
+func (le *LSMEngine) ExpireHints(before time.Time) (int, error) {
+    expired := 0
+    err := le.update(func(b *lsmBatch) error {
+        for nodeID := range le.hintCounts {
+            // 提示按保存顺序排列，遇到未过期的即可停止
+            var keys []string
+            var decodeErr error
+            prefix := lsmHintPrefixOf(nodeID)
+            err := b.scan(prefix, PrefixEnd(prefix), false, func(e *lsmEntry) bool {
+                hint, err := decodeHint(e.value)
+                if err != nil {
+                    decodeErr = err
+                    return false
+                }
+                if !hint.CreatedAt.Before(before) {
+                    return false
+                }
+                keys = append(keys, e.key)
+                return true
+            })
+            if err == nil {
+                err = decodeErr
+            }
+            if err != nil {
+                return err
+            }
+            
+            for _, key := range keys {
+                b.delete(key)
+            }
+            le.hintCounts[nodeID] -= len(keys)
+            expired += len(keys)
+        }
+        return nil
+    })
+    return expired, err
+}
+
+func (le *LSMEngine) HintBacklogs() ([]HintBacklog, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    var backlogs []HintBacklog
+    for nodeID, count := range le.hintCounts {
+        if count <= 0 {
+            continue
+        }
+        
+        backlog := HintBacklog{NodeID: nodeID, Count: count}
+        var decodeErr error
+        prefix := lsmHintPrefixOf(nodeID)
+        err := le.scan(prefix, PrefixEnd(prefix), false, nil, func(e *lsmEntry) bool {
+            hint, err := decodeHint(e.value)
+            if err != nil {
+                decodeErr = err
+                return false
+            }
+            backlog.Oldest = hint.CreatedAt
+            return false
+        })
+        if err == nil {
+            err = decodeErr
+        }
+        if err != nil {
+            return nil, err
+        }
+        backlogs = append(backlogs, backlog)
+    }
+    sort.Slice(backlogs, func(i, j int) bool {
+        return backlogs[i].NodeID < backlogs[j].NodeID
+    })
+    return backlogs, nil
+}
+
+// appendChange 在写入记录的同一个批次中追加变更
+func (le *LSMEngine) appendChange(b *lsmBatch, changeType ChangeType, pair *KVPair) {
+    le.changeSeq++
+    b.put(lsmChangeKey(le.changeSeq), encodeChange(&Change{
+        Type: changeType,
+        Pair: pair,
+        Time: time.Now(),
+    }))
+}
+
+func (le *LSMEngine) Changes(from uint64, limit int) ([]*Change, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    var changes []*Change
+    var decodeErr error
+    err := le.scan(lsmChangeKey(from), PrefixEnd(lsmChangePrefix), false, nil, func(e *lsmEntry) bool {
+        if len(changes) >= limit {
+            return false
+        }
+        change, err := decodeChange(e.value)
+        if err != nil {
+            decodeErr = err
+            return false
+        }
+        change.Seq = parseLSMChangeKey(e.key)
+        changes = append(changes, change)
+        return true
+    })
+    if err == nil {
+        err = decodeErr
+    }
+    return changes, err
+}
+
+func (le *LSMEngine) ChangeBounds() (uint64, uint64, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    first := le.changeSeq + 1
+    err := le.scan(lsmChangePrefix, PrefixEnd(lsmChangePrefix), false, nil, func(e *lsmEntry) bool {
+        first = parseLSMChangeKey(e.key)
+        return false
+    })
+    return first, le.changeSeq, err
+}
+
+// TrimChanges 与BoltEngine相同，分批删除最旧的变更。删除时保存最大序号，
+// 变更日志被全部清理后重启也不会重复使用序号
+func (le *LSMEngine) TrimChanges(before time.Time, maxEntries int) (int, error) {
+    trimmed := 0
+    for {
+        var keys []string
+        err := le.update(func(b *lsmBatch) error {
+            var expireErr error
+            err := b.scan(lsmChangePrefix, PrefixEnd(lsmChangePrefix), false, func(e *lsmEntry) bool {
+                if len(keys) >= trimBatchSize {
+                    return false
+                }
+                expired, err := changeExpired(parseLSMChangeKey(e.key), e.value, le.changeSeq, before, maxEntries)
+                if err != nil || !expired {
+                    expireErr = err
+                    return false
+                }
+                keys = append(keys, e.key)
+                return true
+            })
+            if err == nil {
+                err = expireErr
+            }
+            if err != nil || len(keys) == 0 {
+                return err
+            }
+            
+            for _, key := range keys {
+                b.delete(key)
+            }
+            seq := make([]byte, 8)
+            binary.BigEndian.PutUint64(seq, le.changeSeq)
+            b.put(lsmChangeSeqKey, seq)
+            return nil
+        })
+        if err != nil {
+            return trimmed, err
+        }
+        trimmed += len(keys)
+        if len(keys) < trimBatchSize {
+            return trimmed, nil
+        }
+    }
+}
+
+func (le *LSMEngine) SaveTxn(record *TxnRecord) error {
+    data := encodeTxn(record)
+    
+    return le.update(func(b *lsmBatch) error {
+        b.put(lsmTxnPrefix+record.ID, data)
+        return nil
+    })
+}
+
+func (le *LSMEngine) DeleteTxn(id string) error {
+    return le.update(func(b *lsmBatch) error {
+        e, err := b.get(lsmTxnPrefix + id)
+        if e != nil {
+            b.delete(lsmTxnPrefix + id)
+        }
+        return err
+    })
+}
+
+func (le *LSMEngine) PendingTxns() ([]*TxnRecord, error) {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    var records []*TxnRecord
+    var decodeErr error
+    err := le.scan(lsmTxnPrefix, PrefixEnd(lsmTxnPrefix), false, nil, func(e *lsmEntry) bool {
+        record, err := decodeTxn(e.value)
+        if err != nil {
+            decodeErr = err
+            return false
+        }
+        records = append(records, record)
+        return true
+    })
+    if err == nil {
+        err = decodeErr
+    }
+    return records, err
+}
+
+// Snapshot 在读锁下写出全部记录及其历史版本
+func (le *LSMEngine) Snapshot(w io.Writer) error {
+    le.mutex.RLock()
+    defer le.mutex.RUnlock()
+    
+    return writeSnapshot(w, func(fn func(*KVPair) error) error {
+        var walkErr error
+        err := le.scan(lsmPairPrefix, PrefixEnd(lsmPairPrefix), false, nil, func(e *lsmEntry) bool {
+            key := strings.TrimPrefix(e.key, lsmPairPrefix)
+            err := le.walkVersions(key, func(pair *KVPair) bool {
+                walkErr = fn(pair)
+                return walkErr == nil
+            })
+            if walkErr == nil {
+                walkErr = err
+            }
+            return walkErr == nil
+        })
+        if err == nil {
+            err = walkErr
+        }
+        return err
+    })
+}
+
+// Restore 写入Snapshot生成的数据，与已有记录按版本合并
+func (le *LSMEngine) Restore(r io.Reader) error {
+    return restoreSnapshot(r, le.ApplyPairs)
+}
+
+// Close 停止后台合并并关闭日志和表，memtable中的数据在下次打开时从日志恢复
+func (le *LSMEngine) Close() error {
+    le.mutex.Lock()
+    if le.closed {
+        le.mutex.Unlock()
+        return nil
+    }
+    le.closed = true
+    le.roomCond.Broadcast()
+    le.mutex.Unlock()
+    
+    close(le.closeCh)
+    le.wg.Wait()
+    
+    le.mutex.Lock()
+    defer le.mutex.Unlock()
+    
+    err := le.log.close()
+    le.closeTables()
+    return err
+}
+
+func (le *LSMEngine) closeTables() {
+    for _, tables := range le.version.levels {
+        for _, table := range tables {
+            table.close()
+        }
+    }
+}
\ No newline at end of file
@@ -0,0 +1,416 @@
+package storage
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+)
+
+// compaction 一次合并的输入和输出层，输入按从新到旧排列，
+// 每组是L0的一个表或同一层中按key排序的若干表
+type compaction struct {
+    inputs [][]*sstable
+    output int
+}
+
+// schedule 通知后台检查是否需要写入L0或合并
+func (le *LSMEngine) schedule() {
+    select {
+    case le.workCh <- struct{}{}:
+    default:
+    }
+}
+
+// background 把不可变的memtable写入L0，然后合并超出大小的层
+func (le *LSMEngine) background() {
+    defer le.wg.Done()
+    
+    for {
+        select {
+        case <-le.closeCh:
+            return
+        case <-le.workCh:
+        }
+        
+        if err := le.flushImmutable(); err != nil {
+            le.fail(fmt.Errorf("failed to flush memtable: %v", err))
+            continue
+        }
+        for {
+            select {
+            case <-le.closeCh:
+                return
+            default:
+            }
+            
+            le.compactMutex.Lock()
+            le.mutex.Lock()
+            c := le.pickCompaction()
+            le.mutex.Unlock()
+            var err error
+            if c != nil {
+                err = le.runCompaction(c)
+            }
+            le.compactMutex.Unlock()
+            
+            if err != nil {
+                le.fail(fmt.Errorf("failed to compact tables: %v", err))
+            }
+            if c == nil || err != nil {
+                break
+            }
+        }
+    }
+}
+
+// fail 记录后台错误，之后的写入都会返回该错误
+func (le *LSMEngine) fail(err error) {
+    le.mutex.Lock()
+    defer le.mutex.Unlock()
+    
+    if le.bgErr == nil {
+        le.bgErr = err
+    }
+    le.roomCond.Broadcast()
+}
+
+// flushImmutable 把不可变的memtable写成L0的表，之后可以删除对应的日志
+func (le *LSMEngine) flushImmutable() error {
+    le.flushMutex.Lock()
+    defer le.flushMutex.Unlock()
+    
+    le.mutex.RLock()
+    imm := le.imm
+    le.mutex.RUnlock()
+    if imm == nil {
+        return nil
+    }
+    
+    tables, err := le.writeTables(newMemtableIterator(&imm.tree, "", "", false), false)
+    if err != nil {
+        return err
+    }
+    
+    le.mutex.Lock()
+    defer le.mutex.Unlock()
+    
+    v := le.version.clone()
+    v.levels[0] = append(tables, v.levels[0]...)
+    le.imm = nil
+    if err := le.install(v); err != nil {
+        le.imm = imm
+        for _, table := range tables {
+            table.close()
+            os.Remove(le.tablePath(table.num))
+        }
+        return err
+    }
+    le.roomCond.Broadcast()
+    le.removeObsoleteLogs()
+    return nil
+}
+
+// removeObsoleteLogs 删除数据已全部写入表的日志
+func (le *LSMEngine) removeObsoleteLogs() {
+    paths, _ := filepath.Glob(filepath.Join(le.dir, "*.wal"))
+    for _, path := range paths {
+        var num uint64
+        if _, err := fmt.Sscanf(filepath.Base(path), "%d.wal", &num); err == nil && num < le.logNum {
+            os.Remove(path)
+        }
+    }
+}
+
+// install 保存清单并切换到新版本，调用者需持有写锁
+func (le *LSMEngine) install(v *lsmVersion) error {
+    if err := le.saveManifest(v); err != nil {
+        return err
+    }
+    le.version = v
+    return nil
+}
+
+// pickCompaction L0的表数达到阈值时合并到L1，否则选择第一个超出大小的层，
+// 轮流取其中一个表与下一层重叠的表合并。调用者需持有写锁
+func (le *LSMEngine) pickCompaction() *compaction {
+    v := le.version
+    if len(v.levels[0]) >= lsmL0CompactionTrigger {
+        c := &compaction{output: 1}
+        for _, table := range v.levels[0] {
+            c.inputs = append(c.inputs, []*sstable{table})
+        }
+        smallest, largest := keyRange(v.levels[0])
+        if overlapping := overlappingTables(v.levels[1], smallest, largest); len(overlapping) > 0 {
+            c.inputs = append(c.inputs, overlapping)
+        }
+        return c
+    }
+    
+    for level := 1; level < lsmLevels-1; level++ {
+        if levelSize(v.levels[level]) <= levelMaxBytes(level) {
+            continue
+        }
+        
+        tables := v.levels[level]
+        i := sort.Search(len(tables), func(i int) bool {
+            return tables[i].smallest > le.compactPointers[level]
+        })
+        if i == len(tables) {
+            i = 0
+        }
+        table := tables[i]
+        le.compactPointers[level] = table.largest
+        
+        c := &compaction{inputs: [][]*sstable{{table}}, output: level + 1}
+        if overlapping := overlappingTables(v.levels[level+1], table.smallest, table.largest); len(overlapping) > 0 {
+            c.inputs = append(c.inputs, overlapping)
+        }
+        return c
+    }
+    return nil
+}
+
+// runCompaction 合并输入的表并写入输出层，更深的层中没有重叠的数据时丢弃删除标记
+func (le *LSMEngine) runCompaction(c *compaction) error {
+    var all []*sstable
+    var sources []lsmIterator
+    for _, group := range c.inputs {
+        all = append(all, group...)
+        sources = append(sources, newLevelIterator(group, "", "", false))
+    }
+    smallest, largest := keyRange(all)
+    
+    le.mutex.RLock()
+    dropDeleted := true
+    for level := c.output + 1; level < lsmLevels; level++ {
+        if len(overlappingTables(le.version.levels[level], smallest, largest)) > 0 {
+            dropDeleted = false
+        }
+    }
+    le.mutex.RUnlock()
+    
+    outputs, err := le.writeTables(newMergeIterator(sources, false), dropDeleted)
+    if err != nil {
+        return err
+    }
+    
+    le.mutex.Lock()
+    defer le.mutex.Unlock()
+    
+    removed := make(map[uint64]bool)
+    for _, table := range all {
+        removed[table.num] = true
+    }
+    v := le.version.clone()
+    for level, tables := range v.levels {
+        kept := tables[:0]
+        for _, table := range tables {
+            if !removed[table.num] {
+                kept = append(kept, table)
+            }
+        }
+        v.levels[level] = kept
+    }
+    v.levels[c.output] = append(v.levels[c.output], outputs...)
+    sort.Slice(v.levels[c.output], func(i, j int) bool {
+        return v.levels[c.output][i].smallest < v.levels[c.output][j].smallest
+    })
+    
+    if err := le.install(v); err != nil {
+        for _, table := range outputs {
+            table.close()
+            os.Remove(le.tablePath(table.num))
+        }
+        return err
+    }
+    le.roomCond.Broadcast()
+    
+    // 持有写锁时没有读取者在使用旧版本中的表
+    for _, table := range all {
+        table.close()
+        os.Remove(le.tablePath(table.num))
+    }
+    return nil
+}
+
+// writeTables 把迭代器中的记录写成若干个表，每个表约lsmTableSize大小
+func (le *LSMEngine) writeTables(it lsmIterator, dropDeleted bool) ([]*sstable, error) {
+    var tables []*sstable
+    var writer *tableWriter
+    var num uint64
+    discard := func() {
+        if writer != nil {
+            writer.abort()
+        }
+        for _, table := range tables {
+            table.close()
+            os.Remove(le.tablePath(table.num))
+        }
+    }
+    finish := func() error {
+        if err := writer.finish(); err != nil {
+            return err
+        }
+        writer = nil
+        table, err := openSSTable(num, le.tablePath(num))
+        if err != nil {
+            os.Remove(le.tablePath(num))
+            return err
+        }
+        tables = append(tables, table)
+        return nil
+    }
+    
+    for ; it.valid(); it.next() {
+        e := it.entry()
+        if e.deleted && dropDeleted {
+            continue
+        }
+        
+        if writer == nil {
+            le.mutex.Lock()
+            num = le.newFileNumber()
+            le.mutex.Unlock()
+            
+            var err error
+            if writer, err = newTableWriter(le.tablePath(num)); err != nil {
+                discard()
+                return nil, err
+            }
+        }
+        if err := writer.add(e); err != nil {
+            discard()
+            return nil, fmt.Errorf("failed to write table: %v", err)
+        }
+        if writer.estimatedSize() >= lsmTableSize {
+            if err := finish(); err != nil {
+                discard()
+                return nil, err
+            }
+        }
+    }
+    if err := it.err(); err != nil {
+        discard()
+        return nil, err
+    }
+    if writer != nil {
+        if err := finish(); err != nil {
+            discard()
+            return nil, err
+        }
+    }
+    return tables, nil
+}
+
+// Compact 把memtable写入L0，再把所有层合并到最深的一层，丢弃被覆盖的旧数据和删除标记。
+// 返回压缩前后日志和表文件的总大小
+func (le *LSMEngine) Compact() (int64, int64, error) {
+    le.compactMutex.Lock()
+    defer le.compactMutex.Unlock()
+    
+    before, err := le.diskSize()
+    if err != nil {
+        return 0, 0, err
+    }
+    
+    le.mutex.Lock()
+    for le.imm != nil && le.bgErr == nil && !le.closed {
+        le.roomCond.Wait()
+    }
+    if err := le.bgErr; err != nil {
+        le.mutex.Unlock()
+        return 0, 0, err
+    }
+    if le.mem.tree.Len() > 0 {
+        if err := le.rotate(); err != nil {
+            le.mutex.Unlock()
+            return 0, 0, err
+        }
+    }
+    le.mutex.Unlock()
+    if err := le.flushImmutable(); err != nil {
+        return 0, 0, err
+    }
+    
+    le.mutex.RLock()
+    c := &compaction{output: 1}
+    for _, table := range le.version.levels[0] {
+        c.inputs = append(c.inputs, []*sstable{table})
+    }
+    for level := 1; level < lsmLevels; level++ {
+        if tables := le.version.levels[level]; len(tables) > 0 {
+            c.inputs = append(c.inputs, tables)
+            c.output = level
+        }
+    }
+    le.mutex.RUnlock()
+    
+    if len(c.inputs) > 0 {
+        if err := le.runCompaction(c); err != nil {
+            return 0, 0, err
+        }
+    }
+    
+    after, err := le.diskSize()
+    return before, after, err
+}
+
+// diskSize 返回日志和表文件的总大小
+func (le *LSMEngine) diskSize() (int64, error) {
+    var size int64
+    for _, pattern := range []string{"*.wal", "*.sst"} {
+        paths, err := filepath.Glob(filepath.Join(le.dir, pattern))
+        if err != nil {
+            return 0, err
+        }
+        for _, path := range paths {
+            info, err := os.Stat(path)
+            if err != nil {
+                continue
+            }
+            size += info.Size()
+        }
+    }
+    return size, nil
+}
+
+// keyRange 返回一组表覆盖的最小和最大key
+func keyRange(tables []*sstable) (string, string) {
+    smallest, largest := tables[0].smallest, tables[0].largest
+    for _, table := range tables[1:] {
+        if table.smallest < smallest {
+            smallest = table.smallest
+        }
+        if table.largest > largest {
+            largest = table.largest
+        }
+    }
+    return smallest, largest
+}
+
+func overlappingTables(tables []*sstable, smallest, largest string) []*sstable {
+    var result []*sstable
+    for _, table := range tables {
+        if table.overlaps(smallest, largest) {
+            result = append(result, table)
+        }
+    }
+    return result
+}
+
+func levelSize(tables []*sstable) int64 {
+    var size int64
+    for _, table := range tables {
+        size += table.size
+    }
+    return size
+}
+
+func levelMaxBytes(level int) int64 {
+    size := int64(lsmL1MaxBytes)
+    for i := 1; i < level; i++ {
+        size *= lsmLevelMultiplier
+    }
+    return size
+}
\ No newline at end of file
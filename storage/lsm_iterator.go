@@ -0,0 +1,278 @@
+package storage
+
+import "sort"
+
+// lsmIterator 按key升序或降序遍历一个数据源中的记录（包括删除标记）
+type lsmIterator interface {
+    valid() bool
+    entry() *lsmEntry
+    next()
+    err() error
+}
+
+// 内存表迭代器每次从B树中取出的记录数
+const memtableIteratorChunk = 64
+
+// memtableIterator 分批从B树中取出记录，遍历期间B树不能被修改
+type memtableIterator struct {
+    tree    *btree[*lsmEntry]
+    reverse bool
+    entries []*lsmEntry
+    pos     int
+    // 已取完所有记录
+    done bool
+}
+
+// newMemtableIterator 正序时从不小于start的key开始，逆序时从小于end的key开始，end为空表示从最大的key开始
+func newMemtableIterator(tree *btree[*lsmEntry], start, end string, reverse bool) *memtableIterator {
+    it := &memtableIterator{tree: tree, reverse: reverse}
+    if reverse {
+        it.fill(end, false)
+    } else {
+        it.fill(start, true)
+    }
+    return it
+}
+
+// fill 取出from之后的一批记录，inclusive表示包括from本身（只用于正序）
+func (it *memtableIterator) fill(from string, inclusive bool) {
+    it.entries = it.entries[:0]
+    it.pos = 0
+    collect := func(e *lsmEntry) bool {
+        if !inclusive && !it.reverse && e.key == from {
+            return true
+        }
+        it.entries = append(it.entries, e)
+        return len(it.entries) < memtableIteratorChunk
+    }
+    if it.reverse {
+        it.tree.Descend(from, collect)
+    } else {
+        it.tree.Ascend(from, collect)
+    }
+    it.done = len(it.entries) < memtableIteratorChunk
+}
+
+func (it *memtableIterator) valid() bool {
+    return it.pos < len(it.entries)
+}
+
+func (it *memtableIterator) entry() *lsmEntry {
+    return it.entries[it.pos]
+}
+
+func (it *memtableIterator) next() {
+    it.pos++
+    if it.pos >= len(it.entries) && !it.done {
+        it.fill(it.entries[len(it.entries)-1].key, false)
+    }
+}
+
+func (it *memtableIterator) err() error {
+    return nil
+}
+
+// tableIterator 遍历一个SSTable，每次读入一个数据块
+type tableIterator struct {
+    table   *sstable
+    reverse bool
+    block   int
+    entries []*lsmEntry
+    pos     int
+    e       error
+}
+
+// newTableIterator 起点的含义与newMemtableIterator相同
+func newTableIterator(table *sstable, start, end string, reverse bool) *tableIterator {
+    it := &tableIterator{table: table, reverse: reverse}
+    if !reverse {
+        it.load(table.findBlock(start))
+        it.pos = sort.Search(len(it.entries), func(i int) bool {
+            return it.entries[i].key >= start
+        })
+        return it
+    }
+    
+    if end == "" {
+        it.load(len(table.index) - 1)
+        it.pos = len(it.entries) - 1
+        return it
+    }
+    block := table.findBlock(end)
+    if block == len(table.index) {
+        block--
+    }
+    it.load(block)
+    it.pos = sort.Search(len(it.entries), func(i int) bool {
+        return it.entries[i].key >= end
+    }) - 1
+    if it.pos < 0 {
+        it.load(block - 1)
+        it.pos = len(it.entries) - 1
+    }
+    return it
+}
+
+func (it *tableIterator) load(block int) {
+    it.block = block
+    it.entries = nil
+    if it.e != nil || block < 0 || block >= len(it.table.index) {
+        return
+    }
+    it.entries, it.e = it.table.readBlock(block)
+}
+
+func (it *tableIterator) valid() bool {
+    return it.e == nil && it.pos >= 0 && it.pos < len(it.entries)
+}
+
+func (it *tableIterator) entry() *lsmEntry {
+    return it.entries[it.pos]
+}
+
+func (it *tableIterator) next() {
+    if !it.reverse {
+        it.pos++
+        if it.pos >= len(it.entries) {
+            it.load(it.block + 1)
+            it.pos = 0
+        }
+        return
+    }
+    
+    it.pos--
+    if it.pos < 0 {
+        it.load(it.block - 1)
+        it.pos = len(it.entries) - 1
+    }
+}
+
+func (it *tableIterator) err() error {
+    return it.e
+}
+
+// levelIterator 依次遍历一组按key排序且互不重叠的表
+type levelIterator struct {
+    tables  []*sstable
+    reverse bool
+    i       int
+    current *tableIterator
+    e       error
+}
+
+func newLevelIterator(tables []*sstable, start, end string, reverse bool) *levelIterator {
+    it := &levelIterator{tables: tables, reverse: reverse}
+    if !reverse {
+        it.i = sort.Search(len(tables), func(i int) bool {
+            return tables[i].largest >= start
+        })
+    } else if end == "" {
+        it.i = len(tables) - 1
+    } else {
+        it.i = sort.Search(len(tables), func(i int) bool {
+            return tables[i].smallest >= end
+        }) - 1
+    }
+    
+    if it.i >= 0 && it.i < len(tables) {
+        it.current = newTableIterator(tables[it.i], start, end, reverse)
+        it.skipEmpty()
+    }
+    return it
+}
+
+// skipEmpty 当前表遍历完后切换到下一个表
+func (it *levelIterator) skipEmpty() {
+    for it.current != nil && !it.current.valid() {
+        if it.e = it.current.err(); it.e != nil {
+            it.current = nil
+            return
+        }
+        if it.reverse {
+            it.i--
+        } else {
+            it.i++
+        }
+        if it.i < 0 || it.i >= len(it.tables) {
+            it.current = nil
+            return
+        }
+        it.current = newTableIterator(it.tables[it.i], "", "", it.reverse)
+    }
+}
+
+func (it *levelIterator) valid() bool {
+    return it.current != nil
+}
+
+func (it *levelIterator) entry() *lsmEntry {
+    return it.current.entry()
+}
+
+func (it *levelIterator) next() {
+    it.current.next()
+    it.skipEmpty()
+}
+
+func (it *levelIterator) err() error {
+    return it.e
+}
+
+// mergeIterator 合并多个数据源，同一key只返回最靠前（最新）的数据源中的记录
+type mergeIterator struct {
+    sources []lsmIterator
+    reverse bool
+    current int
+}
+
+func newMergeIterator(sources []lsmIterator, reverse bool) *mergeIterator {
+    it := &mergeIterator{sources: sources, reverse: reverse}
+    it.pick()
+    return it
+}
+
+func (it *mergeIterator) pick() {
+    it.current = -1
+    for i, source := range it.sources {
+        if !source.valid() {
+            continue
+        }
+        if it.current < 0 || it.before(source.entry().key, it.sources[it.current].entry().key) {
+            it.current = i
+        }
+    }
+}
+
+func (it *mergeIterator) before(a, b string) bool {
+    if it.reverse {
+        return a > b
+    }
+    return a < b
+}
+
+func (it *mergeIterator) valid() bool {
+    return it.current >= 0 && it.err() == nil
+}
+
+func (it *mergeIterator) entry() *lsmEntry {
+    return it.sources[it.current].entry()
+}
+
+func (it *mergeIterator) next() {
+    key := it.entry().key
+    for _, source := range it.sources {
+        if source.valid() && source.entry().key == key {
+            source.next()
+        }
+    }
+    it.pick()
+}
+
+func (it *mergeIterator) err() error {
+    for _, source := range it.sources {
+        if err := source.err(); err != nil {
+            return err
+        }
+    }
+    return nil
+}
\ No newline at end of file
@@ -0,0 +1,130 @@
+package storage_test
+
+import (
+    "bytes"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "sort"
+    "testing"
+    
+    "rushkv/storage"
+)
+
+// copyDir 在引擎运行时复制数据目录。每次写入都已等待日志落盘，副本即崩溃时磁盘上的状态
+func copyDir(t *testing.T, src, dst string) {
+    t.Helper()
+    if err := os.MkdirAll(dst, 0755); err != nil {
+        t.Fatal(err)
+    }
+    files, err := os.ReadDir(src)
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, file := range files {
+        in, err := os.Open(filepath.Join(src, file.Name()))
+        if err != nil {
+            t.Fatal(err)
+        }
+        out, err := os.Create(filepath.Join(dst, file.Name()))
+        if err != nil {
+            t.Fatal(err)
+        }
+        if _, err := io.Copy(out, in); err != nil {
+            t.Fatal(err)
+        }
+        in.Close()
+        out.Close()
+    }
+}
+
+// lastWAL 返回编号最大的日志文件
+func lastWAL(t *testing.T, dir string) string {
+    t.Helper()
+    logs, err := filepath.Glob(filepath.Join(dir, "*.wal"))
+    if err != nil || len(logs) == 0 {
+        t.Fatalf("no WAL in %s: %v", dir, err)
+    }
+    sort.Strings(logs)
+    return logs[len(logs)-1]
+}
+
+func expectValue(t *testing.T, e storage.Engine, key string, want []byte) {
+    t.Helper()
+    got, err := e.Get(key)
+    if err != nil {
+        t.Fatalf("Get(%s): %v", key, err)
+    }
+    if !bytes.Equal(got, want) {
+        t.Fatalf("Get(%s) = %d bytes, want %d bytes", key, len(got), len(want))
+    }
+}
+
+func TestLSMReopenAfterCrash(t *testing.T) {
+    dir := t.TempDir()
+    e, err := storage.NewLSMEngine(dir)
+    if err != nil {
+        t.Fatalf("NewLSMEngine: %v", err)
+    }
+    
+    // 超过memtable大小，部分数据写入SSTable
+    large := bytes.Repeat([]byte("v"), 8<<10)
+    for i := 0; i < 600; i++ {
+        if err := e.Put(fmt.Sprintf("large%04d", i), large, int64(i+1)); err != nil {
+            t.Fatalf("Put: %v", err)
+        }
+    }
+    if err := e.Close(); err != nil {
+        t.Fatalf("Close: %v", err)
+    }
+    
+    e, err = storage.NewLSMEngine(dir)
+    if err != nil {
+        t.Fatalf("reopen: %v", err)
+    }
+    defer e.Close()
+    for i := 0; i < 100; i++ {
+        if err := e.Put(fmt.Sprintf("small%03d", i), []byte(fmt.Sprint(i)), int64(i+1)); err != nil {
+            t.Fatalf("Put: %v", err)
+        }
+    }
+    if err := e.Delete("small000", 1000); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+    
+    // 不关闭引擎，直接取磁盘上的文件，并在日志末尾留下一个写了一半的批次
+    crashed := t.TempDir()
+    copyDir(t, filepath.Join(dir, "lsm"), filepath.Join(crashed, "lsm"))
+    wal, err := os.OpenFile(lastWAL(t, filepath.Join(crashed, "lsm")), os.O_WRONLY|os.O_APPEND, 0644)
+    if err != nil {
+        t.Fatal(err)
+    }
+    wal.Write([]byte{1, 2, 3, 4, 100, 0, 0, 0, 5})
+    wal.Close()
+    
+    recovered, err := storage.NewLSMEngine(crashed)
+    if err != nil {
+        t.Fatalf("open after crash: %v", err)
+    }
+    defer recovered.Close()
+    
+    for i := 0; i < 600; i += 59 {
+        expectValue(t, recovered, fmt.Sprintf("large%04d", i), large)
+    }
+    for i := 1; i < 100; i++ {
+        expectValue(t, recovered, fmt.Sprintf("small%03d", i), []byte(fmt.Sprint(i)))
+    }
+    if _, err := recovered.Get("small000"); err == nil {
+        t.Error("deleted key is back after recovery")
+    }
+    count, err := recovered.Count()
+    if err != nil || count != 700 {
+        t.Errorf("Count after recovery = %d, %v, want 700 including the tombstone", count, err)
+    }
+    
+    if err := recovered.Put("after", []byte("crash"), 1001); err != nil {
+        t.Fatalf("Put after recovery: %v", err)
+    }
+    expectValue(t, recovered, "after", []byte("crash"))
+}
@@ -0,0 +1,532 @@
+package storage
+
+import (
+    "fmt"
+    "io"
+    "sort"
+    "sync"
+    "time"
+)
+
+// MemoryEngine 基于B树的内存存储引擎，进程退出后数据丢失
+type MemoryEngine struct {
+    mutex sync.RWMutex
+    data  btree[*KVPair]
+    // 每个key的历史版本，按版本号升序排列
+    history map[string][]*KVPair
+    // 每个key最多保留的版本数（包括当前版本）
+    historyVersions int
+    hints           map[string]*memoryHints
+    txns            map[string]*TxnRecord
+    // 变更日志按序号升序排列，changeSeq为已分配的最大序号
+    changes   []*Change
+    changeSeq uint64
+}
+
+// memoryHints 某个目标节点的提示，按保存顺序排列
+type memoryHints struct {
+    seq   uint64
+    hints []*Hint
+}
+
+func NewMemoryEngine() *MemoryEngine {
+    return &MemoryEngine{
+        history:         make(map[string][]*KVPair),
+        historyVersions: DefaultHistoryVersions,
+        hints:           make(map[string]*memoryHints),
+        txns:            make(map[string]*TxnRecord),
+    }
+}
+
+func (me *MemoryEngine) Put(key string, value []byte, version int64) error {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    pair := &KVPair{
+        Key:       key,
+        Value:     append([]byte(nil), value...),
+        Version:   version,
+        Timestamp: time.Now(),
+    }
+    me.data.Set(pair)
+    me.appendChange(ChangePut, pair)
+    return nil
+}
+
+func (me *MemoryEngine) Get(key string) ([]byte, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    pair := me.data.Get(key)
+    if pair == nil || pair.Deleted || pair.Expired(time.Now()) {
+        return nil, fmt.Errorf("key not found")
+    }
+    return append([]byte(nil), pair.Value...), nil
+}
+
+func (me *MemoryEngine) Delete(key string, version int64) error {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    pair := me.data.Get(key)
+    if pair == nil {
+        return fmt.Errorf("key not found")
+    }
+    
+    pair.Deleted = true
+    pair.Version = version
+    pair.Timestamp = time.Now()
+    me.appendChange(ChangeDelete, pair)
+    return nil
+}
+
+func (me *MemoryEngine) GetPair(key string) (*KVPair, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    if pair := me.data.Get(key); pair != nil {
+        return pair.clone(), nil
+    }
+    return nil, nil
+}
+
+func (me *MemoryEngine) Apply(pair *KVPair) (bool, error) {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    return me.applyPair(pair), nil
+}
+
+func (me *MemoryEngine) ApplyPairs(pairs []*KVPair) ([]bool, error) {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    applied := make([]bool, len(pairs))
+    for i, pair := range pairs {
+        applied[i] = me.applyPair(pair)
+    }
+    return applied, nil
+}
+
+// applyPair 与BoltEngine相同：较旧的记录只存入历史版本，被取代的当前记录转入历史版本
+func (me *MemoryEngine) applyPair(pair *KVPair) bool {
+    if current := me.data.Get(pair.Key); current != nil {
+        if current.Version > pair.Version {
+            me.archive(pair.clone())
+            return false
+        }
+        if current.Version < pair.Version {
+            me.archive(current)
+        }
+    }
+    
+    me.data.Set(pair.clone())
+    me.appendChange(pairChange(pair), pair)
+    return true
+}
+
+func (me *MemoryEngine) ListPairs(after string, limit int) ([]*KVPair, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    var pairs []*KVPair
+    me.data.Ascend(after, func(pair *KVPair) bool {
+        if pair.Key == after {
+            return true
+        }
+        if len(pairs) >= limit {
+            return false
+        }
+        pairs = append(pairs, pair.clone())
+        return true
+    })
+    return pairs, nil
+}
+
+func (me *MemoryEngine) ScanPairs(start, end string, reverse bool, limit int) ([]*KVPair, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    var pairs []*KVPair
+    if !reverse {
+        me.data.Ascend(start, func(pair *KVPair) bool {
+            if len(pairs) >= limit || (end != "" && pair.Key >= end) {
+                return false
+            }
+            pairs = append(pairs, pair.clone())
+            return true
+        })
+    } else {
+        me.data.Descend(end, func(pair *KVPair) bool {
+            if len(pairs) >= limit || pair.Key < start {
+                return false
+            }
+            pairs = append(pairs, pair.clone())
+            return true
+        })
+    }
+    return pairs, nil
+}
+
+func (me *MemoryEngine) Purge(key string, version int64) error {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    me.purge(key, version)
+    return nil
+}
+
+func (me *MemoryEngine) PurgePairs(pairs []*KVPair) (int, error) {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    now := time.Now()
+    purged := 0
+    for _, pair := range pairs {
+        current := me.data.Get(pair.Key)
+        if !me.purge(pair.Key, pair.Version) {
+            continue
+        }
+        if !current.Deleted && current.Expired(now) {
+            me.appendChange(ChangeExpire, current)
+        }
+        purged++
+    }
+    return purged, nil
+}
+
+// purge 记录版本仍为version时删除记录及其历史版本
+func (me *MemoryEngine) purge(key string, version int64) bool {
+    pair := me.data.Get(key)
+    if pair == nil || pair.Version != version {
+        return false
+    }
+    me.data.Delete(key)
+    delete(me.history, key)
+    return true
+}
+
+func (me *MemoryEngine) Count() (int, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    return me.data.Len(), nil
+}
+
+func (me *MemoryEngine) SetHistoryVersions(n int) {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    if n < 1 {
+        n = 1
+    }
+    me.historyVersions = n
+}
+
+func (me *MemoryEngine) GetPairAt(key string, version int64) (*KVPair, error) {
+    return me.pairAt(key, func(pair *KVPair) bool {
+        return pair.Version <= version
+    })
+}
+
+func (me *MemoryEngine) GetPairAtTime(key string, t time.Time) (*KVPair, error) {
+    return me.pairAt(key, func(pair *KVPair) bool {
+        return !pair.Timestamp.After(t)
+    })
+}
+
+func (me *MemoryEngine) History(key string, limit int) ([]*KVPair, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    var pairs []*KVPair
+    me.walkVersions(key, func(pair *KVPair) bool {
+        pairs = append(pairs, pair.clone())
+        return limit <= 0 || len(pairs) < limit
+    })
+    return pairs, nil
+}
+
+func (me *MemoryEngine) pairAt(key string, match func(*KVPair) bool) (*KVPair, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    var result *KVPair
+    me.walkVersions(key, func(pair *KVPair) bool {
+        if match(pair) {
+            result = pair.clone()
+            return false
+        }
+        return true
+    })
+    return result, nil
+}
+
+// walkVersions 从当前记录开始按版本从新到旧遍历key的所有版本，fn返回false时停止
+func (me *MemoryEngine) walkVersions(key string, fn func(*KVPair) bool) {
+    current := me.data.Get(key)
+    if current != nil && !fn(current) {
+        return
+    }
+    
+    versions := me.history[key]
+    for i := len(versions) - 1; i >= 0; i-- {
+        if current != nil && versions[i].Version == current.Version {
+            continue
+        }
+        if !fn(versions[i]) {
+            return
+        }
+    }
+}
+
+// archive 把不再是当前版本的记录存入历史版本，并删除超出保留数量的最旧版本
+func (me *MemoryEngine) archive(pair *KVPair) {
+    if me.historyVersions <= 1 {
+        return
+    }
+    
+    versions := me.history[pair.Key]
+    i := sort.Search(len(versions), func(i int) bool {
+        return versions[i].Version >= pair.Version
+    })
+    if i < len(versions) && versions[i].Version == pair.Version {
+        versions[i] = pair
+    } else {
+        versions = append(versions, nil)
+        copy(versions[i+1:], versions[i:])
+        versions[i] = pair
+    }
+    
+    // 当前版本占一个名额
+    if extra := len(versions) - (me.historyVersions - 1); extra > 0 {
+        versions = append([]*KVPair(nil), versions[extra:]...)
+    }
+    me.history[pair.Key] = versions
+}
+
+func (me *MemoryEngine) AddHint(nodeID string, pair *KVPair, limit int) error {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    backlog := me.hints[nodeID]
+    if backlog == nil {
+        backlog = &memoryHints{}
+        me.hints[nodeID] = backlog
+    }
+    if len(backlog.hints) >= limit {
+        return ErrHintsFull
+    }
+    
+    backlog.seq++
+    backlog.hints = append(backlog.hints, &Hint{
+        ID:        backlog.seq,
+        NodeID:    nodeID,
+        Pair:      pair.clone(),
+        CreatedAt: time.Now(),
+    })
+    return nil
+}
+
+func (me *MemoryEngine) Hints(nodeID string, limit int) ([]*Hint, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    var hints []*Hint
+    if backlog := me.hints[nodeID]; backlog != nil {
+        for _, hint := range backlog.hints {
+            if len(hints) >= limit {
+                break
+            }
+            hints = append(hints, hint.clone())
+        }
+    }
+    return hints, nil
+}
+
+func (me *MemoryEngine) DeleteHints(hints []*Hint) error {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    deleted := make(map[string]map[uint64]bool)
+    for _, hint := range hints {
+        if deleted[hint.NodeID] == nil {
+            deleted[hint.NodeID] = make(map[uint64]bool)
+        }
+        deleted[hint.NodeID][hint.ID] = true
+    }
+    
+    for nodeID, ids := range deleted {
+        backlog := me.hints[nodeID]
+        if backlog == nil {
+            continue
+        }
+        kept := backlog.hints[:0]
+        for _, hint := range backlog.hints {
+            if !ids[hint.ID] {
+                kept = append(kept, hint)
+            }
+        }
+        backlog.hints = kept
+    }
+    return nil
+}
+
+func (me *MemoryEngine) DropHints(nodeID string) error {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    delete(me.hints, nodeID)
+    return nil
+}
+
+func (me *MemoryEngine) ExpireHints(before time.Time) (int, error) {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    expired := 0
+    for _, backlog := range me.hints {
+        // 提示按保存顺序排列，遇到未过期的即可停止
+        n := 0
+        for n < len(backlog.hints) && backlog.hints[n].CreatedAt.Before(before) {
+            n++
+        }
+        backlog.hints = append(backlog.hints[:0], backlog.hints[n:]...)
+        expired += n
+    }
+    return expired, nil
+}
+
+func (me *MemoryEngine) HintBacklogs() ([]HintBacklog, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    var backlogs []HintBacklog
+    for nodeID, backlog := range me.hints {
+        if len(backlog.hints) == 0 {
+            continue
+        }
+        backlogs = append(backlogs, HintBacklog{
+            NodeID: nodeID,
+            Count:  len(backlog.hints),
+            Oldest: backlog.hints[0].CreatedAt,
+        })
+    }
+    sort.Slice(backlogs, func(i, j int) bool {
+        return backlogs[i].NodeID < backlogs[j].NodeID
+    })
+    return backlogs, nil
+}
+
+func (me *MemoryEngine) SaveTxn(record *TxnRecord) error {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    me.txns[record.ID] = record.clone()
+    return nil
+}
+
+func (me *MemoryEngine) DeleteTxn(id string) error {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    delete(me.txns, id)
+    return nil
+}
+
+func (me *MemoryEngine) PendingTxns() ([]*TxnRecord, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    var records []*TxnRecord
+    for _, record := range me.txns {
+        records = append(records, record.clone())
+    }
+    sort.Slice(records, func(i, j int) bool {
+        return records[i].ID < records[j].ID
+    })
+    return records, nil
+}
+
+// appendChange 追加变更，保存记录的副本
+func (me *MemoryEngine) appendChange(changeType ChangeType, pair *KVPair) {
+    me.changeSeq++
+    me.changes = append(me.changes, &Change{
+        Seq:  me.changeSeq,
+        Type: changeType,
+        Pair: pair.clone(),
+        Time: time.Now(),
+    })
+}
+
+func (me *MemoryEngine) Changes(from uint64, limit int) ([]*Change, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    i := sort.Search(len(me.changes), func(i int) bool {
+        return me.changes[i].Seq >= from
+    })
+    var changes []*Change
+    for ; i < len(me.changes) && len(changes) < limit; i++ {
+        changes = append(changes, me.changes[i].clone())
+    }
+    return changes, nil
+}
+
+func (me *MemoryEngine) ChangeBounds() (uint64, uint64, error) {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    if len(me.changes) == 0 {
+        return me.changeSeq + 1, me.changeSeq, nil
+    }
+    return me.changes[0].Seq, me.changeSeq, nil
+}
+
+func (me *MemoryEngine) TrimChanges(before time.Time, maxEntries int) (int, error) {
+    me.mutex.Lock()
+    defer me.mutex.Unlock()
+    
+    n := 0
+    for n < len(me.changes) {
+        change := me.changes[n]
+        expired := maxEntries > 0 && me.changeSeq-change.Seq+1 > uint64(maxEntries)
+        if !expired && (before.IsZero() || !change.Time.Before(before)) {
+            break
+        }
+        n++
+    }
+    me.changes = append(me.changes[:0], me.changes[n:]...)
+    return n, nil
+}
+
+// Snapshot 在读锁下写出全部记录及其历史版本
+func (me *MemoryEngine) Snapshot(w io.Writer) error {
+    me.mutex.RLock()
+    defer me.mutex.RUnlock()
+    
+    return writeSnapshot(w, func(fn func(*KVPair) error) error {
+        var err error
+        me.data.Ascend("", func(current *KVPair) bool {
+            me.walkVersions(current.Key, func(pair *KVPair) bool {
+                err = fn(pair)
+                return err == nil
+            })
+            return err == nil
+        })
+        return err
+    })
+}
+
+func (me *MemoryEngine) Restore(r io.Reader) error {
+    return restoreSnapshot(r, me.ApplyPairs)
+}
+
+// Compact 内存引擎没有需要回收的文件空间
+func (me *MemoryEngine) Compact() (int64, int64, error) {
+    return 0, 0, nil
+}
+
+func (me *MemoryEngine) Close() error {
+    return nil
+}
\ No newline at end of file
@@ -0,0 +1,222 @@
+package storage
+
+import (
+    "bytes"
+    "encoding/binary"
+    "errors"
+    "fmt"
+    "log"
+    
+    "github.com/boltdb/bolt"
+)
+
+var (
+    metaBucket = []byte("meta")
+    formatKey  = []byte("format")
+)
+
+// 迁移时每个写事务最多检查的记录数
+const migrateBatchSize = 1000
+
+var errMigrationStopped = errors.New("migration stopped")
+
+// migrateStep 需要迁移的一个bucket及其记录的重新编码方法
+type migrateStep struct {
+    path     [][]byte
+    reencode func([]byte) ([]byte, error)
+}
+
+// initFormat 读取数据库的格式版本。新数据库直接使用当前格式，
+// 已有数据但没有meta bucket的是旧的JSON格式数据库
+func initFormat(tx *bolt.Tx) (int, error) {
+    meta := tx.Bucket(metaBucket)
+    if meta != nil {
+        if data := meta.Get(formatKey); len(data) == 8 {
+            format := int(binary.BigEndian.Uint64(data))
+            if format > CurrentFormat {
+                return 0, fmt.Errorf("database format %d is newer than supported format %d", format, CurrentFormat)
+            }
+            return format, nil
+        }
+    }
+    
+    format := CurrentFormat
+    if kv := tx.Bucket([]byte("kv")); kv != nil && kv.Stats().KeyN > 0 {
+        format = FormatJSON
+    }
+    for _, name := range [][]byte{historyBucket, hintsBucket, txnsBucket} {
+        if tx.Bucket(name) != nil {
+            format = FormatJSON
+        }
+    }
+    return format, setFormat(tx, format)
+}
+
+func setFormat(tx *bolt.Tx, format int) error {
+    meta, err := tx.CreateBucketIfNotExists(metaBucket)
+    if err != nil {
+        return err
+    }
+    data := make([]byte, 8)
+    binary.BigEndian.PutUint64(data, uint64(format))
+    return meta.Put(formatKey, data)
+}
+
+// Format 返回数据库当前的格式版本，迁移完成前为旧版本
+func (se *BoltEngine) Format() (int, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var format int
+    err := se.db.View(func(tx *bolt.Tx) error {
+        if meta := tx.Bucket(metaBucket); meta != nil {
+            if data := meta.Get(formatKey); len(data) == 8 {
+                format = int(binary.BigEndian.Uint64(data))
+            }
+        }
+        return nil
+    })
+    return format, err
+}
+
+// migrate 在后台把旧格式的记录分批重写为当前格式，期间读写正常进行，
+// 新旧格式的记录可以同时存在。全部完成后更新格式版本
+func (se *BoltEngine) migrate() {
+    defer close(se.migrateDone)
+    
+    var nodes [][]byte
+    se.mutex.RLock()
+    err := se.db.View(func(tx *bolt.Tx) error {
+        if root := tx.Bucket(hintsBucket); root != nil {
+            return root.ForEach(func(k, v []byte) error {
+                if v == nil {
+                    nodes = append(nodes, append([]byte(nil), k...))
+                }
+                return nil
+            })
+        }
+        return nil
+    })
+    se.mutex.RUnlock()
+    if err != nil {
+        log.Printf("Storage migration failed: %v", err)
+        return
+    }
+    
+    reencodePair := func(data []byte) ([]byte, error) {
+        pair, err := decodePair(data)
+        if err != nil {
+            return nil, err
+        }
+        return encodePair(pair), nil
+    }
+    reencodeHint := func(data []byte) ([]byte, error) {
+        hint, err := decodeHint(data)
+        if err != nil {
+            return nil, err
+        }
+        return encodeHint(hint), nil
+    }
+    reencodeTxn := func(data []byte) ([]byte, error) {
+        record, err := decodeTxn(data)
+        if err != nil {
+            return nil, err
+        }
+        return encodeTxn(record), nil
+    }
+    
+    total := 0
+    steps := []migrateStep{
+        {[][]byte{[]byte("kv")}, reencodePair},
+        {[][]byte{historyBucket}, reencodePair},
+        {[][]byte{txnsBucket}, reencodeTxn},
+    }
+    for _, node := range nodes {
+        steps = append(steps, migrateStep{[][]byte{hintsBucket, node}, reencodeHint})
+    }
+    for _, step := range steps {
+        n, err := se.migrateBucket(step.path, step.reencode)
+        total += n
+        if err != nil {
+            if err != errMigrationStopped {
+                log.Printf("Storage migration failed: %v", err)
+            }
+            return
+        }
+    }
+    
+    se.mutex.Lock()
+    err = se.db.Update(func(tx *bolt.Tx) error {
+        return setFormat(tx, CurrentFormat)
+    })
+    se.mutex.Unlock()
+    if err != nil {
+        log.Printf("Storage migration failed: %v", err)
+        return
+    }
+    log.Printf("Storage migrated to format %d, %d records rewritten", CurrentFormat, total)
+}
+
+// migrateBucket 重写path对应bucket中的JSON记录，返回重写的记录数
+func (se *BoltEngine) migrateBucket(path [][]byte, reencode func([]byte) ([]byte, error)) (int, error) {
+    var start []byte
+    total := 0
+    for {
+        select {
+        case <-se.migrateStop:
+            return total, errMigrationStopped
+        default:
+        }
+        
+        done := false
+        se.mutex.Lock()
+        err := se.db.Update(func(tx *bolt.Tx) error {
+            bucket := tx.Bucket(path[0])
+            for _, name := range path[1:] {
+                if bucket == nil {
+                    break
+                }
+                bucket = bucket.Bucket(name)
+            }
+            if bucket == nil {
+                done = true
+                return nil
+            }
+            
+            type update struct{ key, value []byte }
+            var updates []update
+            c := bucket.Cursor()
+            k, v := c.Seek(start)
+            if start != nil && bytes.Equal(k, start) {
+                k, v = c.Next()
+            }
+            scanned := 0
+            for ; k != nil && scanned < migrateBatchSize; k, v = c.Next() {
+                start = append(start[:0], k...)
+                scanned++
+                if v == nil || !isJSON(v) {
+                    continue
+                }
+                data, err := reencode(v)
+                if err != nil {
+                    return fmt.Errorf("failed to migrate %q: %v", k, err)
+                }
+                updates = append(updates, update{append([]byte(nil), k...), data})
+            }
+            done = k == nil
+            
+            // 游标遍历期间不能修改bucket，遍历结束后再写入
+            for _, u := range updates {
+                if err := bucket.Put(u.key, u.value); err != nil {
+                    return err
+                }
+            }
+            total += len(updates)
+            return nil
+        })
+        se.mutex.Unlock()
+        if err != nil || done {
+            return total, err
+        }
+    }
+}
\ No newline at end of file
@@ -0,0 +1,236 @@
+package storage
+
+import (
+    "encoding/json"
+    "fmt"
+    "path/filepath"
+    "testing"
+    "time"
+    
+    "github.com/boltdb/bolt"
+)
+
+// writeLegacyDB 以引入二进制格式之前的方式写入数据库：没有meta bucket，记录、历史版本、
+// 提示和事务都以JSON保存
+func writeLegacyDB(t *testing.T, dir string, n int) {
+    t.Helper()
+    
+    db, err := bolt.Open(filepath.Join(dir, "rushkv.db"), 0600, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer db.Close()
+    
+    put := func(bucket *bolt.Bucket, key []byte, v interface{}) error {
+        data, err := json.Marshal(v)
+        if err != nil {
+            return err
+        }
+        return bucket.Put(key, data)
+    }
+    err = db.Update(func(tx *bolt.Tx) error {
+        kv, _ := tx.CreateBucketIfNotExists([]byte("kv"))
+        history, _ := tx.CreateBucketIfNotExists(historyBucket)
+        txns, _ := tx.CreateBucketIfNotExists(txnsBucket)
+        hints, _ := tx.CreateBucketIfNotExists(hintsBucket)
+        node, _ := hints.CreateBucketIfNotExists([]byte("n1"))
+        
+        for i := 0; i < n; i++ {
+            key := fmt.Sprintf("key%05d", i)
+            if err := put(kv, []byte(key), legacyPair(key, 2)); err != nil {
+                return err
+            }
+        }
+        if err := put(history, historyKey("key00000", 1), legacyPair("key00000", 1)); err != nil {
+            return err
+        }
+        if err := put(txns, []byte("t1"), &TxnRecord{ID: "t1", Pairs: []*KVPair{legacyPair("x", 3)}, CreatedAt: time.Now()}); err != nil {
+            return err
+        }
+        return put(node, hintKey(1), &Hint{Pair: legacyPair("h", 4), CreatedAt: time.Now()})
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+func legacyPair(key string, version int64) *KVPair {
+    return &KVPair{Key: key, Value: []byte(fmt.Sprintf("%s@%d", key, version)), Version: version, Timestamp: time.Now()}
+}
+
+// waitMigrated 等待后台迁移完成
+func waitMigrated(t *testing.T, se *BoltEngine) {
+    t.Helper()
+    select {
+    case <-se.migrateDone:
+    case <-time.After(10 * time.Second):
+        t.Fatal("migration did not finish")
+    }
+    if format, err := se.Format(); err != nil || format != CurrentFormat {
+        t.Fatalf("Format = %d, %v, want %d", format, err, CurrentFormat)
+    }
+}
+
+// checkMigrated 检查所有记录都能读出，且数据库中只剩二进制格式的记录
+func checkMigrated(t *testing.T, dir string, se *BoltEngine, n int) {
+    t.Helper()
+    
+    for i := 0; i < n; i++ {
+        key := fmt.Sprintf("key%05d", i)
+        pair, err := se.GetPair(key)
+        if err != nil || pair == nil || string(pair.Value) != key+"@2" {
+            t.Fatalf("GetPair(%s) = %+v, %v", key, pair, err)
+        }
+    }
+    if pair, err := se.GetPairAt("key00000", 1); err != nil || pair == nil || string(pair.Value) != "key00000@1" {
+        t.Errorf("GetPairAt = %+v, %v", pair, err)
+    }
+    if records, err := se.PendingTxns(); err != nil || len(records) != 1 || records[0].Pairs[0].Key != "x" {
+        t.Errorf("PendingTxns = %+v, %v", records, err)
+    }
+    if hints, err := se.Hints("n1", 10); err != nil || len(hints) != 1 || hints[0].Pair.Key != "h" {
+        t.Errorf("Hints = %+v, %v", hints, err)
+    }
+    if err := se.Close(); err != nil {
+        t.Fatal(err)
+    }
+    
+    db, err := bolt.Open(filepath.Join(dir, "rushkv.db"), 0600, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer db.Close()
+    tags := map[string]byte{"kv": binaryPairTag, "history": binaryPairTag, "txns": binaryTxnTag}
+    db.View(func(tx *bolt.Tx) error {
+        for name, tag := range tags {
+            tx.Bucket([]byte(name)).ForEach(func(k, v []byte) error {
+                if v[0] != tag {
+                    t.Errorf("%s/%q has tag %#x, want %#x", name, k, v[0], tag)
+                }
+                return nil
+            })
+        }
+        return tx.Bucket(hintsBucket).Bucket([]byte("n1")).ForEach(func(k, v []byte) error {
+            if v[0] != binaryHintTag {
+                t.Errorf("hint %x has tag %#x, want %#x", k, v[0], binaryHintTag)
+            }
+            return nil
+        })
+    })
+}
+
+func TestMigrateFromLegacyJSON(t *testing.T) {
+    dir := t.TempDir()
+    n := 2*migrateBatchSize + 10
+    writeLegacyDB(t, dir, n)
+    
+    se, err := NewBoltEngine(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // 迁移期间仍能读出JSON记录
+    if pair, err := se.GetPair("key00001"); err != nil || pair == nil || string(pair.Value) != "key00001@2" {
+        t.Errorf("GetPair during migration = %+v, %v", pair, err)
+    }
+    waitMigrated(t, se)
+    checkMigrated(t, dir, se, n)
+}
+
+func TestMigrateResumesAfterInterruption(t *testing.T) {
+    dir := t.TempDir()
+    n := 2*migrateBatchSize + 10
+    writeLegacyDB(t, dir, n)
+    
+    // 模拟第一批已重写、格式版本尚未更新时进程退出
+    db, err := bolt.Open(filepath.Join(dir, "rushkv.db"), 0600, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    err = db.Update(func(tx *bolt.Tx) error {
+        if _, err := initFormat(tx); err != nil {
+            return err
+        }
+        kv := tx.Bucket([]byte("kv"))
+        for i := 0; i < migrateBatchSize; i++ {
+            key := fmt.Sprintf("key%05d", i)
+            pair, err := decodePair(kv.Get([]byte(key)))
+            if err != nil {
+                return err
+            }
+            if err := kv.Put([]byte(key), encodePair(pair)); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+    db.Close()
+    if err != nil {
+        t.Fatal(err)
+    }
+    
+    se, err := NewBoltEngine(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    waitMigrated(t, se)
+    checkMigrated(t, dir, se, n)
+}
+
+func TestMigrateStoppedByClose(t *testing.T) {
+    dir := t.TempDir()
+    n := 5 * migrateBatchSize
+    writeLegacyDB(t, dir, n)
+    
+    se, err := NewBoltEngine(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := se.Close(); err != nil {
+        t.Fatal(err)
+    }
+    
+    // 重新打开后从未完成的位置继续迁移
+    se, err = NewBoltEngine(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    waitMigrated(t, se)
+    checkMigrated(t, dir, se, n)
+}
+
+func TestFormatInMetaBucket(t *testing.T) {
+    dir := t.TempDir()
+    se, err := NewBoltEngine(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if format, err := se.Format(); err != nil || format != CurrentFormat {
+        t.Errorf("new database Format = %d, %v, want %d", format, err, CurrentFormat)
+    }
+    se.Close()
+    
+    legacy := t.TempDir()
+    writeLegacyDB(t, legacy, 1)
+    db, err := bolt.Open(filepath.Join(legacy, "rushkv.db"), 0600, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    var format int
+    db.Update(func(tx *bolt.Tx) error {
+        format, err = initFormat(tx)
+        return err
+    })
+    if err != nil || format != FormatJSON {
+        t.Errorf("legacy database format = %d, %v, want %d", format, err, FormatJSON)
+    }
+    
+    // 比当前版本更新的格式拒绝打开
+    db.Update(func(tx *bolt.Tx) error {
+        return setFormat(tx, CurrentFormat+1)
+    })
+    db.Close()
+    if se, err := NewBoltEngine(legacy); err == nil {
+        se.Close()
+        t.Error("NewBoltEngine opened a database with a newer format")
+    }
+}
@@ -0,0 +1,355 @@
+package storage
+
+import (
+    "bufio"
+    "encoding/binary"
+    "errors"
+    "fmt"
+    "hash/crc32"
+    "os"
+    "sort"
+)
+
+const (
+    // 数据块的目标大小
+    sstableBlockSize = 4 * 1024
+    // 文件末尾依次为索引和布隆过滤器的偏移与长度，以及魔数
+    sstableFooterSize = 40
+    sstableMagic      = 0x5453766b68737572 // "rushkvST"
+)
+
+var errCorruptEntry = errors.New("corrupt LSM entry")
+
+// sstable 不可变的有序表文件：若干数据块、块索引、布隆过滤器和尾部。
+// 每个数据块是依次编码的记录，后跟4字节CRC32
+type sstable struct {
+    num      uint64
+    file     *os.File
+    size     int64
+    smallest string
+    largest  string
+    index    []blockHandle
+    bloom    bloomFilter
+}
+
+// blockHandle 数据块的位置和块内最大的key
+type blockHandle struct {
+    lastKey string
+    offset  int64
+    length  int64
+}
+
+func openSSTable(num uint64, path string) (*sstable, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to open table: %v", err)
+    }
+    t := &sstable{num: num, file: file}
+    if err := t.load(); err != nil {
+        file.Close()
+        return nil, fmt.Errorf("failed to load table %s: %v", path, err)
+    }
+    return t, nil
+}
+
+// load 读取尾部、索引和布隆过滤器，以及最小的key
+func (t *sstable) load() error {
+    info, err := t.file.Stat()
+    if err != nil {
+        return err
+    }
+    t.size = info.Size()
+    if t.size < sstableFooterSize {
+        return errCorruptEntry
+    }
+    
+    footer := make([]byte, sstableFooterSize)
+    if _, err := t.file.ReadAt(footer, t.size-sstableFooterSize); err != nil {
+        return err
+    }
+    if binary.LittleEndian.Uint64(footer[32:]) != sstableMagic {
+        return fmt.Errorf("bad magic number")
+    }
+    indexOffset := int64(binary.LittleEndian.Uint64(footer))
+    indexLength := int64(binary.LittleEndian.Uint64(footer[8:]))
+    bloomOffset := int64(binary.LittleEndian.Uint64(footer[16:]))
+    bloomLength := int64(binary.LittleEndian.Uint64(footer[24:]))
+    
+    index := make([]byte, indexLength)
+    if _, err := t.file.ReadAt(index, indexOffset); err != nil {
+        return err
+    }
+    if t.index, err = decodeIndex(index); err != nil {
+        return err
+    }
+    t.bloom = make(bloomFilter, bloomLength)
+    if _, err := t.file.ReadAt(t.bloom, bloomOffset); err != nil {
+        return err
+    }
+    
+    if len(t.index) == 0 {
+        return errCorruptEntry
+    }
+    t.largest = t.index[len(t.index)-1].lastKey
+    first, err := t.readBlock(0)
+    if err != nil {
+        return err
+    }
+    t.smallest = first[0].key
+    return nil
+}
+
+// readBlock 读取并解码第i个数据块
+func (t *sstable) readBlock(i int) ([]*lsmEntry, error) {
+    handle := t.index[i]
+    buf := make([]byte, handle.length+4)
+    if _, err := t.file.ReadAt(buf, handle.offset); err != nil {
+        return nil, fmt.Errorf("failed to read table %d: %v", t.num, err)
+    }
+    data := buf[:handle.length]
+    if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(buf[handle.length:]) {
+        return nil, fmt.Errorf("table %d block %d: checksum mismatch", t.num, i)
+    }
+    
+    var entries []*lsmEntry
+    for len(data) > 0 {
+        e, n, err := readEntry(data)
+        if err != nil {
+            return nil, fmt.Errorf("table %d block %d: %v", t.num, i, err)
+        }
+        entries = append(entries, e)
+        data = data[n:]
+    }
+    if len(entries) == 0 {
+        return nil, fmt.Errorf("table %d block %d: empty block", t.num, i)
+    }
+    return entries, nil
+}
+
+// findBlock 返回第一个可能包含不小于key的记录的数据块
+func (t *sstable) findBlock(key string) int {
+    return sort.Search(len(t.index), func(i int) bool {
+        return t.index[i].lastKey >= key
+    })
+}
+
+// get 返回key在表中的记录，不存在时返回nil
+func (t *sstable) get(key string) (*lsmEntry, error) {
+    if key < t.smallest || key > t.largest || !t.bloom.mayContain(key) {
+        return nil, nil
+    }
+    
+    entries, err := t.readBlock(t.findBlock(key))
+    if err != nil {
+        return nil, err
+    }
+    i := sort.Search(len(entries), func(i int) bool {
+        return entries[i].key >= key
+    })
+    if i < len(entries) && entries[i].key == key {
+        return entries[i], nil
+    }
+    return nil, nil
+}
+
+// overlaps 判断表的key范围是否与[smallest, largest]相交
+func (t *sstable) overlaps(smallest, largest string) bool {
+    return t.smallest <= largest && t.largest >= smallest
+}
+
+func (t *sstable) close() error {
+    return t.file.Close()
+}
+
+// tableWriter 按key升序写入记录，生成一个SSTable文件
+type tableWriter struct {
+    path     string
+    file     *os.File
+    w        *bufio.Writer
+    offset   int64
+    block    []byte
+    lastKey  string
+    index    []blockHandle
+    keys     []string
+    smallest string
+}
+
+func newTableWriter(path string) (*tableWriter, error) {
+    file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create table: %v", err)
+    }
+    return &tableWriter{path: path, file: file, w: bufio.NewWriterSize(file, 64*1024)}, nil
+}
+
+// add 写入一条记录，key必须大于之前写入的所有key
+func (tw *tableWriter) add(e *lsmEntry) error {
+    if len(tw.keys) == 0 {
+        tw.smallest = e.key
+    }
+    tw.block = appendEntry(tw.block, e)
+    tw.lastKey = e.key
+    tw.keys = append(tw.keys, e.key)
+    if len(tw.block) >= sstableBlockSize {
+        return tw.flushBlock()
+    }
+    return nil
+}
+
+// empty 判断是否还没有写入记录
+func (tw *tableWriter) empty() bool {
+    return len(tw.keys) == 0
+}
+
+// estimatedSize 返回文件目前的大小，用于决定何时切换到新文件
+func (tw *tableWriter) estimatedSize() int64 {
+    return tw.offset + int64(len(tw.block))
+}
+
+func (tw *tableWriter) flushBlock() error {
+    if len(tw.block) == 0 {
+        return nil
+    }
+    
+    crc := binary.LittleEndian.AppendUint32(nil, crc32.ChecksumIEEE(tw.block))
+    if _, err := tw.w.Write(tw.block); err != nil {
+        return err
+    }
+    if _, err := tw.w.Write(crc); err != nil {
+        return err
+    }
+    tw.index = append(tw.index, blockHandle{
+        lastKey: tw.lastKey,
+        offset:  tw.offset,
+        length:  int64(len(tw.block)),
+    })
+    tw.offset += int64(len(tw.block) + len(crc))
+    tw.block = tw.block[:0]
+    return nil
+}
+
+// finish 写入索引、布隆过滤器和尾部，并把文件落盘
+func (tw *tableWriter) finish() error {
+    err := tw.flushBlock()
+    if err == nil {
+        index := encodeIndex(tw.index)
+        bloom := newBloomFilter(tw.keys)
+        
+        footer := make([]byte, sstableFooterSize)
+        binary.LittleEndian.PutUint64(footer, uint64(tw.offset))
+        binary.LittleEndian.PutUint64(footer[8:], uint64(len(index)))
+        binary.LittleEndian.PutUint64(footer[16:], uint64(tw.offset)+uint64(len(index)))
+        binary.LittleEndian.PutUint64(footer[24:], uint64(len(bloom)))
+        binary.LittleEndian.PutUint64(footer[32:], sstableMagic)
+        
+        for _, part := range [][]byte{index, bloom, footer} {
+            if _, err = tw.w.Write(part); err != nil {
+                break
+            }
+        }
+    }
+    if err == nil {
+        err = tw.w.Flush()
+    }
+    if err == nil {
+        err = tw.file.Sync()
+    }
+    if closeErr := tw.file.Close(); err == nil {
+        err = closeErr
+    }
+    if err != nil {
+        os.Remove(tw.path)
+        return fmt.Errorf("failed to write table: %v", err)
+    }
+    return nil
+}
+
+// abort 放弃写入并删除文件
+func (tw *tableWriter) abort() {
+    tw.file.Close()
+    os.Remove(tw.path)
+}
+
+func encodeIndex(index []blockHandle) []byte {
+    buf := binary.AppendUvarint(nil, uint64(len(index)))
+    for _, handle := range index {
+        buf = binary.AppendUvarint(buf, uint64(len(handle.lastKey)))
+        buf = append(buf, handle.lastKey...)
+        buf = binary.AppendUvarint(buf, uint64(handle.offset))
+        buf = binary.AppendUvarint(buf, uint64(handle.length))
+    }
+    return buf
+}
+
+func decodeIndex(buf []byte) ([]blockHandle, error) {
+    count, n := binary.Uvarint(buf)
+    if n <= 0 {
+        return nil, errCorruptEntry
+    }
+    buf = buf[n:]
+    
+    index := make([]blockHandle, 0, count)
+    for i := uint64(0); i < count; i++ {
+        keyLen, n := binary.Uvarint(buf)
+        if n <= 0 || uint64(len(buf)-n) < keyLen {
+            return nil, errCorruptEntry
+        }
+        key := string(buf[n : n+int(keyLen)])
+        buf = buf[n+int(keyLen):]
+        
+        offset, n := binary.Uvarint(buf)
+        if n <= 0 {
+            return nil, errCorruptEntry
+        }
+        buf = buf[n:]
+        length, n := binary.Uvarint(buf)
+        if n <= 0 {
+            return nil, errCorruptEntry
+        }
+        buf = buf[n:]
+        
+        index = append(index, blockHandle{lastKey: key, offset: int64(offset), length: int64(length)})
+    }
+    return index, nil
+}
+
+// appendEntry 记录的格式为1字节标志（1表示删除标记）、key的长度和内容、value的长度和内容
+func appendEntry(buf []byte, e *lsmEntry) []byte {
+    var flags byte
+    if e.deleted {
+        flags = 1
+    }
+    buf = append(buf, flags)
+    buf = binary.AppendUvarint(buf, uint64(len(e.key)))
+    buf = append(buf, e.key...)
+    buf = binary.AppendUvarint(buf, uint64(len(e.value)))
+    return append(buf, e.value...)
+}
+
+// readEntry 解码buf开头的一条记录，返回记录和占用的字节数
+func readEntry(buf []byte) (*lsmEntry, int, error) {
+    if len(buf) < 1 {
+        return nil, 0, errCorruptEntry
+    }
+    e := &lsmEntry{deleted: buf[0] == 1}
+    pos := 1
+    
+    keyLen, n := binary.Uvarint(buf[pos:])
+    if n <= 0 || uint64(len(buf)-pos-n) < keyLen {
+        return nil, 0, errCorruptEntry
+    }
+    pos += n
+    e.key = string(buf[pos : pos+int(keyLen)])
+    pos += int(keyLen)
+    
+    valueLen, n := binary.Uvarint(buf[pos:])
+    if n <= 0 || uint64(len(buf)-pos-n) < valueLen {
+        return nil, 0, errCorruptEntry
+    }
+    pos += n
+    if valueLen > 0 {
+        e.value = append([]byte(nil), buf[pos:pos+int(valueLen)]...)
+    }
+    pos += int(valueLen)
+    return e, pos, nil
+}
\ No newline at end of file
@@ -0,0 +1,81 @@
+package storage
+
+import (
+    "time"
+    
+    "github.com/boltdb/bolt"
+)
+
+var txnsBucket = []byte("txns")
+
+// TxnRecord 跨节点事务的提交决定，协调节点在通知参与者提交前保存，
+// 全部参与者确认后删除，重启后据此继续未完成的提交
+type TxnRecord struct {
+    ID        string    `json:"id"`
+    Pairs     []*KVPair `json:"pairs"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+func (r *TxnRecord) clone() *TxnRecord {
+    c := *r
+    c.Pairs = make([]*KVPair, len(r.Pairs))
+    for i, pair := range r.Pairs {
+        c.Pairs[i] = pair.clone()
+    }
+    return &c
+}
+
+// SaveTxn 保存事务的提交决定
+func (se *BoltEngine) SaveTxn(record *TxnRecord) error {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    data := encodeTxn(record)
+    
+    return se.db.Update(func(tx *bolt.Tx) error {
+        bucket, err := tx.CreateBucketIfNotExists(txnsBucket)
+        if err != nil {
+            return err
+        }
+        return bucket.Put([]byte(record.ID), data)
+    })
+}
+
+// DeleteTxn 删除已完成提交的事务
+func (se *BoltEngine) DeleteTxn(id string) error {
+    se.mutex.Lock()
+    defer se.mutex.Unlock()
+    
+    return se.db.Update(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket(txnsBucket)
+        if bucket == nil {
+            return nil
+        }
+        return bucket.Delete([]byte(id))
+    })
+}
+
+// PendingTxns 返回已决定提交但尚未完成的事务
+func (se *BoltEngine) PendingTxns() ([]*TxnRecord, error) {
+    se.mutex.RLock()
+    defer se.mutex.RUnlock()
+    
+    var records []*TxnRecord
+    err := se.db.View(func(tx *bolt.Tx) error {
+        bucket := tx.Bucket(txnsBucket)
+        if bucket == nil {
+            return nil
+        }
+        
+        return bucket.ForEach(func(_, v []byte) error {
+            record, err := decodeTxn(v)
+            if err != nil {
+                return err
+            }
+            records = append(records, record)
+            return nil
+        })
+    })
+    
+    return records, err
+}
\ No newline at end of file
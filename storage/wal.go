@@ -0,0 +1,155 @@
+package storage
+
+import (
+    "bufio"
+    "encoding/binary"
+    "errors"
+    "fmt"
+    "hash/crc32"
+    "io"
+    "os"
+    "sync"
+)
+
+// wal LSM引擎的预写日志，每条记录是一个原子写入的批次：
+// 4字节CRC32、4字节长度，然后是批次内容
+type wal struct {
+    file *os.File
+    // 保护buf和written
+    mutex   sync.Mutex
+    buf     *bufio.Writer
+    written uint64
+    // 同一时刻只有一个写入者执行fsync，其余写入者等待并共享结果
+    syncMutex sync.Mutex
+    synced    uint64
+}
+
+func createWAL(path string) (*wal, error) {
+    file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create WAL: %v", err)
+    }
+    return &wal{file: file, buf: bufio.NewWriterSize(file, 64*1024)}, nil
+}
+
+// append 写入一个批次但不等待落盘，返回批次的序号，供sync使用
+func (w *wal) append(entries []*lsmEntry) (uint64, error) {
+    payload := encodeBatch(entries)
+    header := make([]byte, 8)
+    binary.LittleEndian.PutUint32(header, crc32.ChecksumIEEE(payload))
+    binary.LittleEndian.PutUint32(header[4:], uint32(len(payload)))
+    
+    w.mutex.Lock()
+    defer w.mutex.Unlock()
+    
+    if _, err := w.buf.Write(header); err != nil {
+        return 0, fmt.Errorf("failed to write WAL: %v", err)
+    }
+    if _, err := w.buf.Write(payload); err != nil {
+        return 0, fmt.Errorf("failed to write WAL: %v", err)
+    }
+    w.written++
+    return w.written, nil
+}
+
+// sync 等待序号不超过seq的批次落盘，并发的写入者合并为一次fsync
+func (w *wal) sync(seq uint64) error {
+    w.syncMutex.Lock()
+    defer w.syncMutex.Unlock()
+    
+    if w.synced >= seq {
+        return nil
+    }
+    
+    w.mutex.Lock()
+    err := w.buf.Flush()
+    target := w.written
+    w.mutex.Unlock()
+    if err != nil {
+        return fmt.Errorf("failed to write WAL: %v", err)
+    }
+    
+    if err := w.file.Sync(); err != nil {
+        return fmt.Errorf("failed to sync WAL: %v", err)
+    }
+    w.synced = target
+    return nil
+}
+
+func (w *wal) close() error {
+    w.mutex.Lock()
+    written := w.written
+    w.mutex.Unlock()
+    
+    if err := w.sync(written); err != nil {
+        w.file.Close()
+        return err
+    }
+    return w.file.Close()
+}
+
+// replayWAL 按写入顺序把日志中的批次交给fn，末尾不完整或损坏的批次是崩溃时
+// 未写完的，忽略
+func replayWAL(path string, fn func([]*lsmEntry)) error {
+    file, err := os.Open(path)
+    if err != nil {
+        return fmt.Errorf("failed to open WAL: %v", err)
+    }
+    defer file.Close()
+    
+    r := bufio.NewReader(file)
+    header := make([]byte, 8)
+    for {
+        if _, err := io.ReadFull(r, header); err != nil {
+            if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+                return nil
+            }
+            return fmt.Errorf("failed to read WAL: %v", err)
+        }
+        
+        payload := make([]byte, binary.LittleEndian.Uint32(header[4:]))
+        if _, err := io.ReadFull(r, payload); err != nil {
+            if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+                return nil
+            }
+            return fmt.Errorf("failed to read WAL: %v", err)
+        }
+        if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(header) {
+            return nil
+        }
+        
+        entries, err := decodeBatch(payload)
+        if err != nil {
+            return nil
+        }
+        fn(entries)
+    }
+}
+
+// encodeBatch 批次的格式为记录数加上依次编码的记录
+func encodeBatch(entries []*lsmEntry) []byte {
+    buf := binary.AppendUvarint(nil, uint64(len(entries)))
+    for _, e := range entries {
+        buf = appendEntry(buf, e)
+    }
+    return buf
+}
+
+func decodeBatch(buf []byte) ([]*lsmEntry, error) {
+    count, n := binary.Uvarint(buf)
+    if n <= 0 {
+        return nil, errCorruptEntry
+    }
+    buf = buf[n:]
+    
+    entries := make([]*lsmEntry, 0, count)
+    for i := uint64(0); i < count; i++ {
+        e, n, err := readEntry(buf)
+        if err != nil {
+            return nil, err
+        }
+        entries = append(entries, e)
+        buf = buf[n:]
+    }
+    return entries, nil
+}
\ No newline at end of file
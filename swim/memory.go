@@ -0,0 +1,103 @@
+package swim
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+)
+
+var ErrUnreachable = errors.New("node unreachable")
+
+// MemoryNetwork 进程内的模拟网络，按丢包率随机丢弃请求和响应，用于测试故障检测
+type MemoryNetwork struct {
+	mutex     sync.Mutex
+	detectors map[string]*Detector
+	down      map[string]bool
+	lossRate  float64
+	rand      *rand.Rand
+}
+
+func NewMemoryNetwork(lossRate float64, seed int64) *MemoryNetwork {
+	return &MemoryNetwork{
+		detectors: make(map[string]*Detector),
+		down:      make(map[string]bool),
+		lossRate:  lossRate,
+		rand:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Transport 返回节点id使用的传输层
+func (n *MemoryNetwork) Transport(id string) Transport {
+	return &memoryTransport{network: n, from: id}
+}
+
+// Register 接入检测器，之后其他节点可以探测到它
+func (n *MemoryNetwork) Register(d *Detector) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.detectors[d.ID()] = d
+}
+
+func (n *MemoryNetwork) SetLossRate(rate float64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.lossRate = rate
+}
+
+// SetDown 模拟节点宕机或恢复，宕机节点收发的消息全部丢失
+func (n *MemoryNetwork) SetDown(id string, down bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.down[id] = down
+}
+
+// deliver 判断一条从from发往to的消息能否送达，送达时返回接收方的检测器
+func (n *MemoryNetwork) deliver(from, to string) (*Detector, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	d, ok := n.detectors[to]
+	if !ok || n.down[from] || n.down[to] || n.rand.Float64() < n.lossRate {
+		return nil, false
+	}
+	return d, true
+}
+
+type memoryTransport struct {
+	network *MemoryNetwork
+	from    string
+}
+
+func (t *memoryTransport) Ping(ctx context.Context, target string, updates []Update) ([]Update, error) {
+	d, ok := t.network.deliver(t.from, target)
+	if !ok {
+		return nil, t.lost(ctx)
+	}
+	acked := d.HandlePing(updates)
+	if _, ok := t.network.deliver(target, t.from); !ok {
+		return nil, t.lost(ctx)
+	}
+	return acked, nil
+}
+
+func (t *memoryTransport) PingReq(ctx context.Context, via, target string, updates []Update) ([]Update, error) {
+	d, ok := t.network.deliver(t.from, via)
+	if !ok {
+		return nil, t.lost(ctx)
+	}
+	acked, err := d.HandlePingReq(ctx, target, updates)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := t.network.deliver(via, t.from); !ok {
+		return nil, t.lost(ctx)
+	}
+	return acked, nil
+}
+
+// lost 消息丢失时和真实网络一样等到超时才返回
+func (t *memoryTransport) lost(ctx context.Context) error {
+	<-ctx.Done()
+	return ErrUnreachable
+}
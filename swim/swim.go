@@ -0,0 +1,452 @@
+package swim
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// State 成员的存活状态
+type State int
+
+const (
+	StateAlive State = iota
+	StateSuspect
+	StateDead
+)
+
+func (s State) String() string {
+	switch s {
+	case StateSuspect:
+		return "suspect"
+	case StateDead:
+		return "dead"
+	default:
+		return "alive"
+	}
+}
+
+// Update 捎带在探测消息上传播的成员状态
+type Update struct {
+	ID          string
+	State       State
+	Incarnation uint64
+}
+
+// Member 本节点看到的成员状态
+type Member struct {
+	ID          string
+	State       State
+	Incarnation uint64
+	Since       time.Time // 进入当前状态的时间
+}
+
+// Transport 节点间的探测消息
+type Transport interface {
+	// Ping 直接探测target，返回对方捎带的状态更新
+	Ping(ctx context.Context, target string, updates []Update) ([]Update, error)
+	// PingReq 请求via代为探测target
+	PingReq(ctx context.Context, via, target string, updates []Update) ([]Update, error)
+}
+
+type Config struct {
+	ID string
+	// 每个周期探测一个成员
+	ProbeInterval time.Duration
+	// 直接探测的超时时间，超时后通过其他成员间接探测
+	ProbeTimeout time.Duration
+	// 间接探测时请求的成员数
+	IndirectProbes int
+	// 怀疑状态持续该时间未被反驳则判定为死亡
+	SuspicionTimeout time.Duration
+	// 每条状态更新被捎带的次数为RetransmitMult*ceil(log10(n+1))
+	RetransmitMult int
+}
+
+func DefaultConfig(id string) Config {
+	return Config{
+		ID:               id,
+		ProbeInterval:    1 * time.Second,
+		ProbeTimeout:     300 * time.Millisecond,
+		IndirectProbes:   3,
+		SuspicionTimeout: 5 * time.Second,
+		RetransmitMult:   3,
+	}
+}
+
+// 单条消息最多捎带的状态更新数
+const maxPiggyback = 8
+
+type broadcast struct {
+	update    Update
+	transmits int
+}
+
+// Detector SWIM故障检测器。成员列表由外部维护，检测器只负责判断成员是否存活，
+// 状态变化通过探测消息捎带传播
+type Detector struct {
+	config    Config
+	transport Transport
+	onChange  func(Member)
+
+	mutex       sync.Mutex
+	incarnation uint64
+	members     map[string]*Member
+	probeOrder  []string
+	probeIndex  int
+	broadcasts  []*broadcast
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDetector 创建故障检测器，onChange在成员状态变化时调用
+func NewDetector(config Config, transport Transport, onChange func(Member)) *Detector {
+	return &Detector{
+		config:    config,
+		transport: transport,
+		onChange:  onChange,
+		// 重启后的节点以更大的incarnation覆盖集群中关于它的旧状态
+		incarnation: uint64(time.Now().UnixNano()),
+		members:     make(map[string]*Member),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+func (d *Detector) ID() string {
+	return d.config.ID
+}
+
+func (d *Detector) Start() {
+	go d.probeLoop()
+}
+
+func (d *Detector) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopCh)
+	})
+}
+
+// SetMembers 设置需要探测的成员，新成员视为存活，不在列表中的成员被移除
+func (d *Detector) SetMembers(ids []string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	current := make(map[string]bool)
+	for _, id := range ids {
+		if id == d.config.ID {
+			continue
+		}
+		current[id] = true
+		if _, ok := d.members[id]; !ok {
+			d.members[id] = &Member{
+				ID:    id,
+				State: StateAlive,
+				Since: time.Now(),
+			}
+		}
+	}
+	for id := range d.members {
+		if !current[id] {
+			delete(d.members, id)
+		}
+	}
+}
+
+// Members 返回除自身外的所有成员
+func (d *Detector) Members() []Member {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	members := make([]Member, 0, len(d.members))
+	for _, m := range d.members {
+		members = append(members, *m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].ID < members[j].ID
+	})
+	return members
+}
+
+// State 返回成员的状态，自身始终存活
+func (d *Detector) State(id string) (State, bool) {
+	if id == d.config.ID {
+		return StateAlive, true
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	m, ok := d.members[id]
+	if !ok {
+		return StateAlive, false
+	}
+	return m.State, true
+}
+
+// HandlePing 处理直接探测，返回本节点捎带的状态更新
+func (d *Detector) HandlePing(updates []Update) []Update {
+	d.merge(updates)
+	return d.piggyback()
+}
+
+// HandlePingReq 代其他成员探测target
+func (d *Detector) HandlePingReq(ctx context.Context, target string, updates []Update) ([]Update, error) {
+	d.merge(updates)
+
+	ctx, cancel := context.WithTimeout(ctx, d.config.ProbeTimeout)
+	defer cancel()
+
+	acked, err := d.transport.Ping(ctx, target, d.piggyback())
+	if err != nil {
+		return nil, err
+	}
+	d.merge(acked)
+	return d.piggyback(), nil
+}
+
+func (d *Detector) probeLoop() {
+	ticker := time.NewTicker(d.config.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+			d.expireSuspects()
+			if target, ok := d.nextTarget(); ok {
+				d.probe(target)
+			}
+		}
+	}
+}
+
+// nextTarget 按随机顺序轮流选取探测对象，每轮结束后重新打乱
+func (d *Detector) nextTarget() (string, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for attempts := 0; attempts <= len(d.probeOrder); attempts++ {
+		if d.probeIndex >= len(d.probeOrder) {
+			d.probeOrder = d.probeOrder[:0]
+			for id := range d.members {
+				d.probeOrder = append(d.probeOrder, id)
+			}
+			rand.Shuffle(len(d.probeOrder), func(i, j int) {
+				d.probeOrder[i], d.probeOrder[j] = d.probeOrder[j], d.probeOrder[i]
+			})
+			d.probeIndex = 0
+			if len(d.probeOrder) == 0 {
+				return "", false
+			}
+		}
+
+		// 死亡的成员同样会被探测，恢复后的节点借此得知自己的状态并反驳
+		id := d.probeOrder[d.probeIndex]
+		d.probeIndex++
+		if _, ok := d.members[id]; ok {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// probe 直接探测失败后请求其他成员间接探测，都失败时怀疑target
+func (d *Detector) probe(target string) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.ProbeTimeout)
+	acked, err := d.transport.Ping(ctx, target, d.piggyback())
+	cancel()
+	if err == nil {
+		d.merge(acked)
+		return
+	}
+	if state, _ := d.State(target); state == StateDead {
+		return
+	}
+
+	helpers := d.randomMembers(d.config.IndirectProbes, target)
+	if len(helpers) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), d.config.ProbeInterval-d.config.ProbeTimeout)
+		defer cancel()
+
+		results := make(chan []Update, len(helpers))
+		for _, via := range helpers {
+			go func(via string) {
+				acked, err := d.transport.PingReq(ctx, via, target, d.piggyback())
+				if err != nil {
+					acked = nil
+				}
+				results <- acked
+			}(via)
+		}
+		for range helpers {
+			if acked := <-results; acked != nil {
+				d.merge(acked)
+				return
+			}
+		}
+	}
+
+	d.suspect(target)
+}
+
+// randomMembers 随机选取最多k个存活的成员，排除exclude
+func (d *Detector) randomMembers(k int, exclude string) []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var candidates []string
+	for id, m := range d.members {
+		if id != exclude && m.State == StateAlive {
+			candidates = append(candidates, id)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	return candidates
+}
+
+func (d *Detector) suspect(id string) {
+	d.mutex.Lock()
+	m, ok := d.members[id]
+	if !ok || m.State != StateAlive {
+		d.mutex.Unlock()
+		return
+	}
+	changed := d.applyLocked(Update{ID: id, State: StateSuspect, Incarnation: m.Incarnation})
+	d.mutex.Unlock()
+
+	d.notify(changed)
+}
+
+// expireSuspects 怀疑超时未被反驳的成员判定为死亡
+func (d *Detector) expireSuspects() {
+	d.mutex.Lock()
+	var changed []Member
+	for _, m := range d.members {
+		if m.State == StateSuspect && time.Since(m.Since) >= d.config.SuspicionTimeout {
+			changed = append(changed, d.applyLocked(Update{ID: m.ID, State: StateDead, Incarnation: m.Incarnation})...)
+		}
+	}
+	d.mutex.Unlock()
+
+	d.notify(changed)
+}
+
+// merge 合并收到的状态更新
+func (d *Detector) merge(updates []Update) {
+	d.mutex.Lock()
+	var changed []Member
+	for _, update := range updates {
+		if update.ID == d.config.ID {
+			d.refuteLocked(update)
+			continue
+		}
+		changed = append(changed, d.applyLocked(update)...)
+	}
+	d.mutex.Unlock()
+
+	d.notify(changed)
+}
+
+// refuteLocked 其他成员怀疑本节点时，以更大的incarnation宣告自己存活，
+// 新的incarnation随每条消息捎带出去
+func (d *Detector) refuteLocked(update Update) {
+	if update.State == StateAlive || update.Incarnation < d.incarnation {
+		return
+	}
+	d.incarnation = update.Incarnation + 1
+}
+
+// applyLocked 按incarnation判断更新是否比已知状态新，生效时继续传播，返回状态发生变化的成员
+func (d *Detector) applyLocked(update Update) []Member {
+	m, ok := d.members[update.ID]
+	if !ok {
+		return nil
+	}
+
+	// 被怀疑或判定死亡的成员仍在发送消息时，把已知状态传回给它以便反驳
+	if update.State == StateAlive && m.State != StateAlive && update.Incarnation <= m.Incarnation {
+		d.enqueueLocked(Update{ID: m.ID, State: m.State, Incarnation: m.Incarnation})
+		return nil
+	}
+
+	var newer bool
+	switch update.State {
+	case StateAlive:
+		newer = update.Incarnation > m.Incarnation
+	case StateSuspect:
+		newer = update.Incarnation > m.Incarnation ||
+			(update.Incarnation == m.Incarnation && m.State == StateAlive)
+	case StateDead:
+		newer = update.Incarnation >= m.Incarnation && m.State != StateDead
+	}
+	if !newer {
+		return nil
+	}
+
+	previous := m.State
+	m.Incarnation = update.Incarnation
+	m.State = update.State
+	d.enqueueLocked(update)
+	if previous == m.State {
+		return nil
+	}
+	m.Since = time.Now()
+	return []Member{*m}
+}
+
+// enqueueLocked 加入待传播的状态更新，同一成员只保留最新一条
+func (d *Detector) enqueueLocked(update Update) {
+	for i, b := range d.broadcasts {
+		if b.update.ID == update.ID {
+			d.broadcasts = append(d.broadcasts[:i], d.broadcasts[i+1:]...)
+			break
+		}
+	}
+	d.broadcasts = append(d.broadcasts, &broadcast{update: update})
+}
+
+// piggyback 取出需要捎带的状态更新，本节点的存活状态始终附带
+func (d *Detector) piggyback() []Update {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	limit := d.config.RetransmitMult * int(math.Ceil(math.Log10(float64(len(d.members)+2))))
+	updates := []Update{{ID: d.config.ID, State: StateAlive, Incarnation: d.incarnation}}
+
+	// 传播次数少的更新优先
+	sort.SliceStable(d.broadcasts, func(i, j int) bool {
+		return d.broadcasts[i].transmits < d.broadcasts[j].transmits
+	})
+	kept := d.broadcasts[:0]
+	for _, b := range d.broadcasts {
+		if len(updates) < maxPiggyback {
+			updates = append(updates, b.update)
+			b.transmits++
+		}
+		if b.transmits < limit {
+			kept = append(kept, b)
+		}
+	}
+	d.broadcasts = kept
+
+	return updates
+}
+
+func (d *Detector) notify(changed []Member) {
+	if d.onChange == nil {
+		return
+	}
+	for _, m := range changed {
+		d.onChange(m)
+	}
+}
@@ -0,0 +1,228 @@
+package swim
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testProbeInterval = 20 * time.Millisecond
+
+func testConfig(id string) Config {
+	return Config{
+		ID:               id,
+		ProbeInterval:    testProbeInterval,
+		ProbeTimeout:     5 * time.Millisecond,
+		IndirectProbes:   3,
+		SuspicionTimeout: 10 * testProbeInterval,
+		RetransmitMult:   3,
+	}
+}
+
+// event 某个检测器观察到的成员状态变化
+type event struct {
+	observer string
+	member   Member
+	at       time.Time
+}
+
+type recorder struct {
+	mutex  sync.Mutex
+	events []event
+}
+
+func (r *recorder) record(observer string) func(Member) {
+	return func(m Member) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.events = append(r.events, event{observer: observer, member: m, at: time.Now()})
+	}
+}
+
+// first 返回observer第一次看到id进入state的时间
+func (r *recorder) first(observer, id string, state State) (time.Time, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, e := range r.events {
+		if e.observer == observer && e.member.ID == id && e.member.State == state {
+			return e.at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// earliest 返回任一节点第一次看到id进入state的时间
+func (r *recorder) earliest(id string, state State) (time.Time, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, e := range r.events {
+		if e.member.ID == id && e.member.State == state {
+			return e.at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *recorder) count(state State) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	n := 0
+	for _, e := range r.events {
+		if e.member.State == state {
+			n++
+		}
+	}
+	return n
+}
+
+// startCluster 在模拟网络上启动n个互相探测的检测器
+func startCluster(t *testing.T, network *MemoryNetwork, n int, r *recorder) []*Detector {
+	t.Helper()
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("node%d", i+1)
+	}
+
+	detectors := make([]*Detector, n)
+	for i, id := range ids {
+		detectors[i] = NewDetector(testConfig(id), network.Transport(id), r.record(id))
+		detectors[i].SetMembers(ids)
+		network.Register(detectors[i])
+	}
+	for _, d := range detectors {
+		d.Start()
+	}
+	t.Cleanup(func() {
+		for _, d := range detectors {
+			d.Stop()
+		}
+	})
+	return detectors
+}
+
+// waitFor 等待cond成立，超时后测试失败
+func waitFor(t *testing.T, timeout time.Duration, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out after %v waiting for %s", timeout, what)
+		}
+		time.Sleep(testProbeInterval / 4)
+	}
+}
+
+func TestNoFalseDeathUnderPacketLoss(t *testing.T) {
+	network := NewMemoryNetwork(0.2, 1)
+	r := &recorder{}
+	detectors := startCluster(t, network, 5, r)
+
+	time.Sleep(150 * testProbeInterval)
+
+	if dead := r.count(StateDead); dead > 0 {
+		t.Errorf("%d members were declared dead under 20%% packet loss", dead)
+	}
+	for _, d := range detectors {
+		for _, m := range d.Members() {
+			if m.State == StateDead {
+				t.Errorf("%s sees %s as dead", d.ID(), m.ID)
+			}
+		}
+	}
+}
+
+func TestDownNodeBecomesSuspectThenDead(t *testing.T) {
+	network := NewMemoryNetwork(0, 1)
+	r := &recorder{}
+	n := 5
+	detectors := startCluster(t, network, n, r)
+	observer := detectors[0].ID()
+
+	// 等待各节点开始探测后再让node5宕机
+	time.Sleep(2 * testProbeInterval)
+	downAt := time.Now()
+	network.SetDown("node5", true)
+
+	config := testConfig(observer)
+	// 每个节点在两轮内必然直接探测到node5，每轮探测n-1个成员
+	suspectWithin := time.Duration(2*(n-1)+2) * config.ProbeInterval
+	deadWithin := suspectWithin + config.SuspicionTimeout + 2*config.ProbeInterval
+
+	waitFor(t, 2*deadWithin, "node5 to be declared dead", func() bool {
+		state, _ := detectors[0].State("node5")
+		return state == StateDead
+	})
+
+	suspectAt, ok := r.first(observer, "node5", StateSuspect)
+	if !ok {
+		t.Fatal("node5 was declared dead without being suspected first")
+	}
+	deadAt, _ := r.first(observer, "node5", StateDead)
+	if elapsed := suspectAt.Sub(downAt); elapsed > suspectWithin {
+		t.Errorf("node5 suspected after %v, want within %v", elapsed, suspectWithin)
+	}
+	if elapsed := deadAt.Sub(downAt); elapsed > deadWithin {
+		t.Errorf("node5 declared dead after %v, want within %v", elapsed, deadWithin)
+	}
+	// 死亡可能由其他节点传播而来，但不会早于集群中第一次怀疑之后的怀疑超时
+	firstSuspect, _ := r.earliest("node5", StateSuspect)
+	if deadAt.Sub(firstSuspect) < config.SuspicionTimeout-config.ProbeInterval {
+		t.Errorf("node5 declared dead %v after the first suspicion, before the suspicion timeout %v", deadAt.Sub(firstSuspect), config.SuspicionTimeout)
+	}
+
+	// 其他存活的节点不受影响
+	for _, d := range detectors[:n-1] {
+		for _, m := range d.Members() {
+			if m.ID != "node5" && m.State == StateDead {
+				t.Errorf("%s sees %s as dead", d.ID(), m.ID)
+			}
+		}
+	}
+}
+
+func TestRecoveredNodeRefutesDeath(t *testing.T) {
+	network := NewMemoryNetwork(0, 1)
+	r := &recorder{}
+	detectors := startCluster(t, network, 4, r)
+	timeout := 100 * testProbeInterval
+
+	network.SetDown("node4", true)
+	waitFor(t, timeout, "node4 to be declared dead everywhere", func() bool {
+		for _, d := range detectors[:3] {
+			if state, _ := d.State("node4"); state != StateDead {
+				return false
+			}
+		}
+		return true
+	})
+
+	deadIncarnation := make(map[string]uint64)
+	for _, d := range detectors[:3] {
+		for _, m := range d.Members() {
+			if m.ID == "node4" {
+				deadIncarnation[d.ID()] = m.Incarnation
+			}
+		}
+	}
+
+	network.SetDown("node4", false)
+	waitFor(t, timeout, "all members to be alive again", func() bool {
+		for _, d := range detectors {
+			for _, m := range d.Members() {
+				if m.State != StateAlive {
+					return false
+				}
+			}
+		}
+		return true
+	})
+
+	for _, d := range detectors[:3] {
+		for _, m := range d.Members() {
+			if m.ID == "node4" && m.Incarnation <= deadIncarnation[d.ID()] {
+				t.Errorf("%s accepted node4 as alive without a newer incarnation", d.ID())
+			}
+		}
+	}
+}